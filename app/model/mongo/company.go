@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Company is an employer in the company directory. Pekerjaan alumni merujuk
+// ke company lewat company_id agar satu perusahaan tidak tercatat dengan
+// banyak ejaan; nama_perusahaan pada pekerjaan tetap disimpan sebagai salinan.
+type Company struct {
+	ID             primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	Nama           string             `json:"nama" bson:"nama"`
+	Aliases        []string           `json:"aliases" bson:"aliases"`
+	BidangIndustri string             `json:"bidang_industri" bson:"bidang_industri"`
+	LokasiKerja    string             `json:"lokasi_kerja" bson:"lokasi_kerja"`
+	Website        *string            `json:"website" bson:"website,omitempty"`
+	Keys           []string           `json:"-" bson:"keys"` // Nama dan alias yang sudah dinormalisasi, untuk pencocokan persis
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type CreateCompanyRequest struct {
+	Nama           string   `json:"nama" validate:"required"`
+	Aliases        []string `json:"aliases"`
+	BidangIndustri string   `json:"bidang_industri"`
+	LokasiKerja    string   `json:"lokasi_kerja"`
+	Website        *string  `json:"website" validate:"omitempty,http_url"`
+}
+
+type UpdateCompanyRequest struct {
+	Nama           string   `json:"nama" validate:"required"`
+	Aliases        []string `json:"aliases"`
+	BidangIndustri string   `json:"bidang_industri"`
+	LokasiKerja    string   `json:"lokasi_kerja"`
+	Website        *string  `json:"website" validate:"omitempty,http_url"`
+}
+
+// MergeCompaniesRequest merges the source companies into the company in the
+// URL. Pekerjaan dipindahkan ke company tujuan, nama sumber menjadi alias.
+type MergeCompaniesRequest struct {
+	SourceIDs []primitive.ObjectID `json:"source_ids" validate:"required,min=1"`
+}
+
+// CompanySummary is a company with the number of alumni currently working there
+type CompanySummary struct {
+	Company
+	AlumniCount int `json:"alumni_count"`
+}
+
+// CompanySuggestion is a fuzzy match of a typed company name
+type CompanySuggestion struct {
+	Company
+	Score float64 `json:"score"`
+}
+
+// CompanyAlumni is an alumni who works or worked at a company
+type CompanyAlumni struct {
+	AlumniID            primitive.ObjectID `json:"alumni_id" bson:"alumni_id"`
+	Nama                string             `json:"nama" bson:"nama"`
+	Jurusan             string             `json:"jurusan" bson:"jurusan"`
+	TahunLulus          int                `json:"tahun_lulus" bson:"tahun_lulus"`
+	PekerjaanID         primitive.ObjectID `json:"pekerjaan_id" bson:"pekerjaan_id"`
+	PosisiJabatan       string             `json:"posisi_jabatan" bson:"posisi_jabatan"`
+	StatusPekerjaan     string             `json:"status_pekerjaan" bson:"status_pekerjaan"`
+	TanggalMulaiKerja   Date               `json:"tanggal_mulai_kerja" bson:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *Date              `json:"tanggal_selesai_kerja" bson:"tanggal_selesai_kerja,omitempty"`
+}
+
+// MergeCompaniesResult reports what a merge changed
+type MergeCompaniesResult struct {
+	Company        Company `json:"company"`
+	MergedCount    int     `json:"merged_count"`
+	PekerjaanMoved int64   `json:"pekerjaan_moved"`
+}
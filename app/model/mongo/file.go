@@ -8,20 +8,61 @@ import (
 
 type File struct {
 	ID           primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
-	UserID       string             `json:"user_id" bson:"user_id"`
+	UserID       string             `json:"user_id" bson:"user_id"`                 // ID pemilik, user atau alumni sesuai OwnerType
+	OwnerType    string             `json:"owner_type" bson:"owner_type,omitempty"` // "user" atau "alumni", kosong berarti "user"
 	FileName     string             `json:"file_name" bson:"file_name"`
 	OriginalName string             `json:"original_name" bson:"original_name"`
 	FilePath     string             `json:"file_path" bson:"file_path"`
 	FileSize     int64              `json:"file_size" bson:"file_size"`
 	FileType     string             `json:"file_type" bson:"file_type"`
-	Category     string             `json:"category" bson:"category"` // "photo" atau "certificate"
+	Category     string             `json:"category" bson:"category"` // "photo", "certificate", "transcript", "portfolio"
 	UploadedAt   time.Time          `json:"uploaded_at" bson:"uploaded_at"`
-	UploadedBy   string             `json:"uploaded_by" bson:"uploaded_by"` // Admin atau User ID
+	UploadedBy   string             `json:"uploaded_by" bson:"uploaded_by"` // Admin, User atau Alumni ID
+	UploaderType string             `json:"uploader_type,omitempty" bson:"uploader_type,omitempty"`
 	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
 	DeletedAt    *time.Time         `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+
+	// Hasil scan malware
+	ScanStatus     string     `json:"scan_status" bson:"scan_status"`
+	ScanSignature  *string    `json:"scan_signature,omitempty" bson:"scan_signature,omitempty"`
+	ScannerVersion string     `json:"scanner_version,omitempty" bson:"scanner_version,omitempty"`
+	ScannedAt      *time.Time `json:"scanned_at,omitempty" bson:"scanned_at,omitempty"`
+
+	// Verifikasi sertifikat oleh verifier, hanya untuk kategori certificate
+	VerificationStatus string     `json:"verification_status,omitempty" bson:"verification_status,omitempty"`
+	RejectionReason    *string    `json:"rejection_reason,omitempty" bson:"rejection_reason,omitempty"`
+	VerifiedBy         string     `json:"verified_by,omitempty" bson:"verified_by,omitempty"`
+	VerifiedAt         *time.Time `json:"verified_at,omitempty" bson:"verified_at,omitempty"`
+
+	// Versi dokumen, satu rantai per pemilik dan kategori. Versi yang
+	// digantikan upload baru tetap disimpan sebagai riwayat.
+	Version      int                 `json:"version" bson:"version,omitempty"`
+	SupersededAt *time.Time          `json:"superseded_at,omitempty" bson:"superseded_at,omitempty"`
+	SupersededBy *primitive.ObjectID `json:"superseded_by,omitempty" bson:"superseded_by,omitempty"`
 }
 
+// Jenis pemilik file
+const (
+	OwnerTypeUser   = "user"
+	OwnerTypeAlumni = "alumni"
+)
+
+// Status verifikasi sertifikat
+const (
+	VerificationPending  = "pending"
+	VerificationApproved = "approved"
+	VerificationRejected = "rejected"
+)
+
+// Status scan file
+const (
+	ScanStatusClean       = "clean"       // Lolos scan
+	ScanStatusQuarantined = "quarantined" // Malware terdeteksi, file dipindah ke karantina
+	ScanStatusPending     = "pending"     // Scanner tidak bisa dihubungi, akan di-scan ulang
+	ScanStatusSkipped     = "skipped"     // Scanner tidak dikonfigurasi
+)
+
 type UserInfo struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
@@ -31,6 +72,7 @@ type UserInfo struct {
 type FileResponse struct {
 	ID           string    `json:"id"`
 	UserID       string    `json:"user_id"`
+	OwnerType    string    `json:"owner_type"`
 	FileName     string    `json:"file_name"`
 	OriginalName string    `json:"original_name"`
 	FilePath     string    `json:"file_path"`
@@ -39,8 +81,17 @@ type FileResponse struct {
 	Category     string    `json:"category"`
 	UploadedAt   time.Time `json:"uploaded_at"`
 	UploadedBy   UserInfo  `json:"uploaded_by"` // Contains username, email, role
+	ScanStatus   string    `json:"scan_status"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	VerificationStatus string     `json:"verification_status,omitempty"`
+	RejectionReason    *string    `json:"rejection_reason,omitempty"`
+	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
+
+	Version      int        `json:"version"`
+	IsCurrent    bool       `json:"is_current"`
+	SupersededAt *time.Time `json:"superseded_at,omitempty"`
 }
 
 type UploadPhotoRequest struct {
@@ -50,3 +101,168 @@ type UploadPhotoRequest struct {
 type UploadCertificateRequest struct {
 	UserID string `json:"user_id" validate:"required"`
 }
+
+// UploadPolicy defines upload limits for a file category.
+// Nilai 0 pada MaxFiles atau MaxTotalBytes berarti tidak dibatasi.
+type UploadPolicy struct {
+	Category          string    `json:"category" bson:"category"`
+	MaxFileSize       int64     `json:"max_file_size" bson:"max_file_size"`
+	AllowedTypes      []string  `json:"allowed_types" bson:"allowed_types"`           // MIME types
+	AllowedExtensions []string  `json:"allowed_extensions" bson:"allowed_extensions"` // e.g. ".pdf"
+	MaxFiles          int       `json:"max_files" bson:"max_files"`
+	MaxTotalBytes     int64     `json:"max_total_bytes" bson:"max_total_bytes"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+}
+
+type UpdateUploadPolicyRequest struct {
+	MaxFileSize       int64    `json:"max_file_size" validate:"required,min=1"`
+	AllowedTypes      []string `json:"allowed_types"`
+	AllowedExtensions []string `json:"allowed_extensions"`
+	MaxFiles          int      `json:"max_files" validate:"min=0"`
+	MaxTotalBytes     int64    `json:"max_total_bytes" validate:"min=0"`
+}
+
+// CategoryUsage is a user's storage usage for one category
+type CategoryUsage struct {
+	Category      string `json:"category" bson:"category"`
+	FileCount     int    `json:"file_count" bson:"file_count"`   // Hanya versi terbaru
+	TotalBytes    int64  `json:"total_bytes" bson:"total_bytes"` // Termasuk versi lama
+	MaxFiles      int    `json:"max_files"`
+	MaxTotalBytes int64  `json:"max_total_bytes"`
+}
+
+// UserFileUsage reports an owner's usage against quota for every category
+type UserFileUsage struct {
+	UserID     string          `json:"user_id"`
+	OwnerType  string          `json:"owner_type"`
+	Categories []CategoryUsage `json:"categories"`
+}
+
+// CertificateReview is an item of the verifier queue
+type CertificateReview struct {
+	FileResponse
+	Owner UserInfo `json:"owner"`
+}
+
+// CertificateReviewFilter narrows the verifier queue
+type CertificateReviewFilter struct {
+	Status       string
+	OwnerType    string
+	UploadedFrom *time.Time
+	UploadedTo   *time.Time
+}
+
+// FileAdminFilter narrows the admin file browser
+type FileAdminFilter struct {
+	Category     string
+	OwnerType    string
+	OwnerID      string
+	UploaderType string
+	UploadedBy   string
+	FileType     string // MIME type, "image/*" cocok dengan semua image
+	MinSize      *int64
+	MaxSize      *int64
+	UploadedFrom *time.Time
+	UploadedTo   *time.Time
+	Deleted      string // "exclude" (default), "include" atau "only"
+	CurrentOnly  bool
+}
+
+// FileAdminItem is a file listed in the admin file browser
+type FileAdminItem struct {
+	FileResponse
+	Owner     UserInfo   `json:"owner"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CategoryTotal aggregates the files matching an admin browser filter
+type CategoryTotal struct {
+	Category   string `json:"category" bson:"_id"`
+	FileCount  int    `json:"file_count" bson:"file_count"`
+	TotalBytes int64  `json:"total_bytes" bson:"total_bytes"`
+}
+
+// Nilai filter deleted pada file browser admin
+const (
+	FileDeletedExclude = "exclude"
+	FileDeletedInclude = "include"
+	FileDeletedOnly    = "only"
+)
+
+type RejectCertificateRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// FileWithUploader is a file with its uploader resolved in the same query,
+// Uploader nil jika uploader sudah tidak ada
+type FileWithUploader struct {
+	File     File
+	Uploader *UserInfo
+}
+
+// AlumniFiles holds the files shown on an alumni profile
+type AlumniFiles struct {
+	Photo        *FileResponse  `json:"photo"`
+	Certificates []FileResponse `json:"certificates"`
+}
+
+// Upload session statuses. Complete mengklaim sesi dengan mengubah status
+// dari uploading ke completing sehingga hanya satu request yang menyimpan file.
+const (
+	UploadSessionUploading  = "uploading"
+	UploadSessionCompleting = "completing"
+)
+
+// UploadSession tracks a resumable upload until it is completed or expires
+type UploadSession struct {
+	ID           primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID       string             `json:"user_id" bson:"user_id"`
+	OwnerType    string             `json:"owner_type" bson:"owner_type"`
+	UploadedBy   string             `json:"uploaded_by" bson:"uploaded_by"`
+	UploaderType string             `json:"uploader_type" bson:"uploader_type"`
+	Category     string             `json:"category" bson:"category"`
+	OriginalName string             `json:"original_name" bson:"original_name"`
+	FileType     string             `json:"file_type" bson:"file_type"`
+	FileSize     int64              `json:"file_size" bson:"file_size"`
+	Offset       int64              `json:"offset" bson:"offset"`
+	Status       string             `json:"status" bson:"status"`
+	TempPath     string             `json:"-" bson:"temp_path"`
+	ExpiresAt    time.Time          `json:"expires_at" bson:"expires_at"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type CreateUploadSessionRequest struct {
+	UserID   string `json:"user_id"`
+	AlumniID string `json:"alumni_id"`
+	Category string `json:"category" validate:"required"`
+	FileName string `json:"file_name" validate:"required"`
+	FileType string `json:"file_type"`
+	FileSize int64  `json:"file_size" validate:"required,min=1"`
+}
+
+type CompleteUploadSessionRequest struct {
+	// Checksum opsional untuk seluruh file, format "sha256 <base64>"
+	Checksum string `json:"checksum"`
+}
+
+// FileGCItem is a single finding of the file garbage collector
+type FileGCItem struct {
+	FileID   string `json:"file_id,omitempty"`
+	FilePath string `json:"file_path"`
+	FileSize int64  `json:"file_size"`
+	Action   string `json:"action"` // "purged", "record_removed", "object_removed" atau "none" saat dry run
+}
+
+// FileGCReport summarises one reconciliation run
+type FileGCReport struct {
+	DryRun         bool         `json:"dry_run"`
+	Retention      string       `json:"retention"`
+	StartedAt      time.Time    `json:"started_at"`
+	FinishedAt     time.Time    `json:"finished_at"`
+	Purged         []FileGCItem `json:"purged"`          // Soft-deleted lebih lama dari retention
+	MissingObjects []FileGCItem `json:"missing_objects"` // Record tanpa file di disk
+	OrphanObjects  []FileGCItem `json:"orphan_objects"`  // File di disk tanpa record
+	BytesReclaimed int64        `json:"bytes_reclaimed"`
+	Errors         []string     `json:"errors"`
+}
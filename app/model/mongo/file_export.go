@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FileExportRequest selects the alumni documents of a bulk ZIP download.
+// Minimal salah satu dari alumni_ids, angkatan atau jurusan harus diisi.
+type FileExportRequest struct {
+	AlumniIDs []string `json:"alumni_ids" bson:"alumni_ids,omitempty"`
+	Angkatan  []int    `json:"angkatan" bson:"angkatan,omitempty" validate:"dive,tahun"`
+	Jurusan   []string `json:"jurusan" bson:"jurusan,omitempty"`
+	Category  string   `json:"category" bson:"category,omitempty"` // kosong berarti semua kategori
+	Async     bool     `json:"async" bson:"-"`                     // paksa diproses sebagai background job
+}
+
+// FileExportJob tracks a bulk download that is too large to stream directly
+type FileExportJob struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Status       string             `json:"status" bson:"status"`
+	RequestedBy  string             `json:"requested_by" bson:"requested_by"`
+	Filter       FileExportRequest  `json:"filter" bson:"filter"`
+	FileCount    int                `json:"file_count" bson:"file_count"`
+	TotalBytes   int64              `json:"total_bytes" bson:"total_bytes"` // Ukuran file sumber
+	ArtifactPath string             `json:"-" bson:"artifact_path,omitempty"`
+	ArtifactSize int64              `json:"artifact_size,omitempty" bson:"artifact_size,omitempty"`
+	Error        *string            `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt    *time.Time         `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	FinishedAt   *time.Time         `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+	ExpiresAt    *time.Time         `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+
+	DownloadURL string `json:"download_url,omitempty" bson:"-"` // Diisi saat job selesai
+}
+
+// Status background job export
+const (
+	ExportJobQueued    = "queued"
+	ExportJobRunning   = "running"
+	ExportJobCompleted = "completed"
+	ExportJobFailed    = "failed"
+)
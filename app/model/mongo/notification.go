@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Notification is an in-app message for a user or alumni
+type Notification struct {
+	ID        primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty"`
+	OwnerType string              `json:"owner_type" bson:"owner_type"` // "user" atau "alumni"
+	OwnerID   string              `json:"owner_id" bson:"owner_id"`
+	Type      string              `json:"type" bson:"type"`
+	Title     string              `json:"title" bson:"title"`
+	Message   string              `json:"message" bson:"message"`
+	FileID    *primitive.ObjectID `json:"file_id,omitempty" bson:"file_id,omitempty"`
+	ReadAt    *time.Time          `json:"read_at,omitempty" bson:"read_at,omitempty"`
+	CreatedAt time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// Jenis notifikasi
+const (
+	NotificationCertificateApproved = "certificate_approved"
+	NotificationCertificateRejected = "certificate_rejected"
+)
@@ -9,6 +9,19 @@ type MetaInfo struct {
 	SortBy string `json:"sortBy"`
 	Order  string `json:"order"`
 	Search string `json:"search"`
+
+	SearchMode string `json:"search_mode,omitempty"`
+
+	// Diisi pada pagination cursor (keyset). Total dan Pages bernilai -1
+	// jika penghitungan dilewati dengan count=false.
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// CursorPage holds the cursors around a keyset paginated page
+type CursorPage struct {
+	Next string
+	Prev string
 }
 
 // AlumniResponse represents the response structure for alumni endpoints with pagination
@@ -30,4 +43,31 @@ type PaginationParams struct {
 	SortBy string `json:"sortBy"`
 	Order  string `json:"order"`
 	Search string `json:"search"`
+
+	SearchMode string `json:"search_mode"` // "regex" (default) atau "fulltext"
+
+	Cursor    string `json:"cursor"`     // Cursor opaque dari next_cursor/prev_cursor
+	SkipCount bool   `json:"skip_count"` // Lewati COUNT total, meta.total menjadi -1
+
+	Fields []string `json:"fields"` // Sparse fieldset, kosong berarti semua field
+}
+
+// Mode pencarian pada endpoint list
+const (
+	SearchModeRegex    = "regex"    // Substring, input user di-escape
+	SearchModeFullText = "fulltext" // Index full-text dengan ranking dan toleransi typo
+)
+
+// AlumniSearchHit is an alumni matched by full-text search
+type AlumniSearchHit struct {
+	Alumni
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"` // Nama field -> teks dengan <mark>
+}
+
+// PekerjaanSearchHit is a job matched by full-text search
+type PekerjaanSearchHit struct {
+	PekerjaanAlumni
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
 }
@@ -14,6 +14,7 @@ type Alumni struct {
 	Role       string     `json:"role" db:"role"`
 	NoTelepon  *string    `json:"no_telepon" db:"no_telepon"`
 	Alamat     *string    `json:"alamat" db:"alamat"`
+	IsVerified bool       `json:"is_verified" db:"is_verified"` // Punya sertifikat yang sudah disetujui
 	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt  *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
@@ -33,15 +34,22 @@ type AlumniLoginResponse struct {
 type AlumniWithJobs struct {
 	Alumni
 	PekerjaanList []PekerjaanAlumni `json:"pekerjaan_list"`
+	AlumniFiles
+}
+
+// AlumniDetail is returned by GET /alumni/:id
+type AlumniDetail struct {
+	Alumni
+	AlumniFiles
 }
 
 type CreateAlumniRequest struct {
 	UserID     *int    `json:"user_id"`
-	NIM        string  `json:"nim" validate:"required"`
+	NIM        string  `json:"nim" validate:"required,nim"`
 	Nama       string  `json:"nama" validate:"required"`
 	Jurusan    string  `json:"jurusan" validate:"required"`
-	Angkatan   int     `json:"angkatan" validate:"required"`
-	TahunLulus int     `json:"tahun_lulus" validate:"required"`
+	Angkatan   int     `json:"angkatan" validate:"required,tahun"`
+	TahunLulus int     `json:"tahun_lulus" validate:"required,tahun,gtefield=Angkatan"`
 	Email      string  `json:"email" validate:"required,email"`
 	Password   string  `json:"password" validate:"required,min=6"`
 	NoTelepon  *string `json:"no_telepon"`
@@ -52,8 +60,8 @@ type UpdateAlumniRequest struct {
 	UserID     *int    `json:"user_id"`
 	Nama       string  `json:"nama" validate:"required"`
 	Jurusan    string  `json:"jurusan" validate:"required"`
-	Angkatan   int     `json:"angkatan" validate:"required"`
-	TahunLulus int     `json:"tahun_lulus" validate:"required"`
+	Angkatan   int     `json:"angkatan" validate:"required,tahun"`
+	TahunLulus int     `json:"tahun_lulus" validate:"required,tahun,gtefield=Angkatan"`
 	Email      string  `json:"email" validate:"required,email"`
 	NoTelepon  *string `json:"no_telepon"`
 	Alamat     *string `json:"alamat"`
@@ -65,3 +73,93 @@ type AlumniStatistics struct {
 	AlumniByAngkatan   map[string]int `json:"alumni_by_angkatan"`
 	AlumniByTahunLulus map[string]int `json:"alumni_by_tahun_lulus"`
 }
+
+// TracerGroup is the employment outcome of a group of alumni in the tracer
+// study. Persentase dihitung terhadap TotalAlumni grup.
+type TracerGroup struct {
+	TahunLulus             *int     `json:"tahun_lulus,omitempty"`
+	Jurusan                *string  `json:"jurusan,omitempty"`
+	TotalAlumni            int      `json:"total_alumni"`
+	Employed               int      `json:"employed"`           // Punya minimal satu riwayat pekerjaan
+	CurrentlyEmployed      int      `json:"currently_employed"` // Punya pekerjaan aktif
+	EmploymentRate         float64  `json:"employment_rate"`
+	CurrentEmploymentRate  float64  `json:"current_employment_rate"`
+	MedianMonthsToFirstJob *float64 `json:"median_months_to_first_job"` // nil jika belum ada yang bekerja
+	EmployedWithin6Months  float64  `json:"employed_within_6_months"`
+	EmployedWithin12Months float64  `json:"employed_within_12_months"`
+}
+
+// TracerShare is the number of employed alumni in one category
+type TracerShare struct {
+	Value      string  `json:"value"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"` // Persen dari alumni yang sudah bekerja
+}
+
+// TracerStatistics is the tracer study report for accreditation. Hanya
+// tahun lulus yang disimpan, jadi tanggal lulus diasumsikan tanggal 1 bulan
+// GraduationMonth. Pekerjaan pertama yang dimulai sebelum lulus dihitung 0 bulan.
+type TracerStatistics struct {
+	GraduationMonth  int           `json:"graduation_month"`
+	Overall          TracerGroup   `json:"overall"`
+	ByTahunLulus     []TracerGroup `json:"by_tahun_lulus"`
+	ByJurusan        []TracerGroup `json:"by_jurusan"`
+	ByCohort         []TracerGroup `json:"by_cohort"`          // Per tahun_lulus dan jurusan
+	ByBidangIndustri []TracerShare `json:"by_bidang_industri"` // Dari pekerjaan pertama
+	ByLokasiKerja    []TracerShare `json:"by_lokasi_kerja"`    // Dari pekerjaan pertama
+}
+
+// SalaryGroup holds the monthly salary percentiles of one group. Persentil
+// dikosongkan (Suppressed) bila responden kurang dari batas minimum agar gaji
+// perorangan tidak bisa ditebak.
+type SalaryGroup struct {
+	Value      string   `json:"value,omitempty"`
+	Count      int      `json:"count"`
+	Suppressed bool     `json:"suppressed,omitempty"`
+	P25        *float64 `json:"p25"`
+	P50        *float64 `json:"p50"`
+	P75        *float64 `json:"p75"`
+	P90        *float64 `json:"p90"`
+}
+
+// SalaryStatistics reports salary percentiles of current jobs. Nilai tiap
+// pekerjaan adalah titik tengah rentang gaji, gaji tahunan dibagi 12.
+type SalaryStatistics struct {
+	Currency         string        `json:"currency"`
+	Period           string        `json:"period"`
+	MinGroupSize     int           `json:"min_group_size"`
+	Overall          SalaryGroup   `json:"overall"`
+	ByJurusan        []SalaryGroup `json:"by_jurusan"`
+	ByBidangIndustri []SalaryGroup `json:"by_bidang_industri"`
+	ByTahunLulus     []SalaryGroup `json:"by_tahun_lulus"`
+}
+
+// AlumniFilter holds the structured filters of the paginated alumni list,
+// lihat utils.ParseAlumniFilter untuk sintaks query-nya. Nilai nil atau
+// kosong berarti tidak difilter.
+type AlumniFilter struct {
+	Jurusan        []string
+	AngkatanMin    *int
+	AngkatanMax    *int
+	TahunLulusMin  *int
+	TahunLulusMax  *int
+	HasJob         *bool    // Punya minimal satu riwayat pekerjaan
+	Employed       *bool    // Sedang bekerja, yaitu punya pekerjaan aktif
+	BidangIndustri []string // Bidang industri pekerjaan aktif
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+}
+
+// Relasi alumni yang bisa di-embed dengan ?include=
+const (
+	IncludePekerjaan  = "pekerjaan"
+	IncludeFiles      = "files"
+	IncludeCurrentJob = "current_job"
+)
+
+// AlumniRelations holds the related data of one alumni loaded for ?include=
+type AlumniRelations struct {
+	Pekerjaan  []PekerjaanAlumni
+	CurrentJob *PekerjaanAlumni
+	Files      []FileWithUploader // Foto dan sertifikat versi terbaru, tanpa yang dikarantina
+}
@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// Company is an employer in the company directory. Pekerjaan alumni merujuk
+// ke company lewat company_id agar satu perusahaan tidak tercatat dengan
+// banyak ejaan; nama_perusahaan pada pekerjaan tetap disimpan sebagai salinan.
+type Company struct {
+	ID             int       `json:"id" db:"id"`
+	Nama           string    `json:"nama" db:"nama"`
+	Aliases        []string  `json:"aliases" db:"aliases"`
+	BidangIndustri string    `json:"bidang_industri" db:"bidang_industri"`
+	LokasiKerja    string    `json:"lokasi_kerja" db:"lokasi_kerja"`
+	Website        *string   `json:"website" db:"website"`
+	Keys           []string  `json:"-" db:"keys"` // Nama dan alias yang sudah dinormalisasi, untuk pencocokan persis
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateCompanyRequest struct {
+	Nama           string   `json:"nama" validate:"required"`
+	Aliases        []string `json:"aliases"`
+	BidangIndustri string   `json:"bidang_industri"`
+	LokasiKerja    string   `json:"lokasi_kerja"`
+	Website        *string  `json:"website" validate:"omitempty,http_url"`
+}
+
+type UpdateCompanyRequest struct {
+	Nama           string   `json:"nama" validate:"required"`
+	Aliases        []string `json:"aliases"`
+	BidangIndustri string   `json:"bidang_industri"`
+	LokasiKerja    string   `json:"lokasi_kerja"`
+	Website        *string  `json:"website" validate:"omitempty,http_url"`
+}
+
+// MergeCompaniesRequest merges the source companies into the company in the
+// URL. Pekerjaan dipindahkan ke company tujuan, nama sumber menjadi alias.
+type MergeCompaniesRequest struct {
+	SourceIDs []int `json:"source_ids" validate:"required,min=1"`
+}
+
+// CompanySummary is a company with the number of alumni currently working there
+type CompanySummary struct {
+	Company
+	AlumniCount int `json:"alumni_count"`
+}
+
+// CompanySuggestion is a fuzzy match of a typed company name
+type CompanySuggestion struct {
+	Company
+	Score float64 `json:"score"`
+}
+
+// CompanyAlumni is an alumni who works or worked at a company
+type CompanyAlumni struct {
+	AlumniID            int    `json:"alumni_id" db:"alumni_id"`
+	Nama                string `json:"nama" db:"nama"`
+	Jurusan             string `json:"jurusan" db:"jurusan"`
+	TahunLulus          int    `json:"tahun_lulus" db:"tahun_lulus"`
+	PekerjaanID         int    `json:"pekerjaan_id" db:"pekerjaan_id"`
+	PosisiJabatan       string `json:"posisi_jabatan" db:"posisi_jabatan"`
+	StatusPekerjaan     string `json:"status_pekerjaan" db:"status_pekerjaan"`
+	TanggalMulaiKerja   Date   `json:"tanggal_mulai_kerja" db:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *Date  `json:"tanggal_selesai_kerja" db:"tanggal_selesai_kerja"`
+}
+
+// MergeCompaniesResult reports what a merge changed
+type MergeCompaniesResult struct {
+	Company        Company `json:"company"`
+	MergedCount    int     `json:"merged_count"`
+	PekerjaanMoved int64   `json:"pekerjaan_moved"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// FileExportRequest selects the alumni documents of a bulk ZIP download.
+// Minimal salah satu dari alumni_ids, angkatan atau jurusan harus diisi.
+type FileExportRequest struct {
+	AlumniIDs []int    `json:"alumni_ids,omitempty"`
+	Angkatan  []int    `json:"angkatan,omitempty" validate:"dive,tahun"`
+	Jurusan   []string `json:"jurusan,omitempty"`
+	Category  string   `json:"category,omitempty"` // kosong berarti semua kategori
+	Async     bool     `json:"async,omitempty"`    // paksa diproses sebagai background job
+}
+
+// FileExportJob tracks a bulk download that is too large to stream directly
+type FileExportJob struct {
+	ID           string            `json:"id" db:"id"` // UUID
+	Status       string            `json:"status" db:"status"`
+	RequestedBy  int               `json:"requested_by" db:"requested_by"`
+	Filter       FileExportRequest `json:"filter" db:"filter"` // Disimpan sebagai JSONB
+	FileCount    int               `json:"file_count" db:"file_count"`
+	TotalBytes   int64             `json:"total_bytes" db:"total_bytes"` // Ukuran file sumber
+	ArtifactPath string            `json:"-" db:"artifact_path"`
+	ArtifactSize int64             `json:"artifact_size,omitempty" db:"artifact_size"`
+	Error        *string           `json:"error,omitempty" db:"error"`
+	StartedAt    *time.Time        `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt   *time.Time        `json:"finished_at,omitempty" db:"finished_at"`
+	ExpiresAt    *time.Time        `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+
+	DownloadURL string `json:"download_url,omitempty" db:"-"` // Diisi saat job selesai
+}
+
+// Status background job export
+const (
+	ExportJobQueued    = "queued"
+	ExportJobRunning   = "running"
+	ExportJobCompleted = "completed"
+	ExportJobFailed    = "failed"
+)
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Notification is an in-app message for a user or alumni
+type Notification struct {
+	ID        int        `json:"id" db:"id"`
+	OwnerType string     `json:"owner_type" db:"owner_type"` // "user" atau "alumni"
+	OwnerID   int        `json:"owner_id" db:"owner_id"`
+	Type      string     `json:"type" db:"type"`
+	Title     string     `json:"title" db:"title"`
+	Message   string     `json:"message" db:"message"`
+	FileID    *int       `json:"file_id,omitempty" db:"file_id"`
+	ReadAt    *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Jenis notifikasi
+const (
+	NotificationCertificateApproved = "certificate_approved"
+	NotificationCertificateRejected = "certificate_rejected"
+)
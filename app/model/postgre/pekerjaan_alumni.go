@@ -34,11 +34,13 @@ func (d Date) MarshalJSON() ([]byte, error) {
 type PekerjaanAlumni struct {
 	ID                  int        `json:"id" db:"id"`
 	AlumniID            int        `json:"alumni_id" db:"alumni_id"`
+	CompanyID           *int       `json:"company_id,omitempty" db:"company_id"`
 	NamaPerusahaan      string     `json:"nama_perusahaan" db:"nama_perusahaan"`
 	PosisiJabatan       string     `json:"posisi_jabatan" db:"posisi_jabatan"`
 	BidangIndustri      string     `json:"bidang_industri" db:"bidang_industri"`
 	LokasiKerja         string     `json:"lokasi_kerja" db:"lokasi_kerja"`
 	GajiRange           *string    `json:"gaji_range" db:"gaji_range"`
+	Gaji                *Gaji      `json:"gaji,omitempty" db:"-"` // Kolom gaji_min, gaji_max, gaji_currency, gaji_period
 	TanggalMulaiKerja   Date       `json:"tanggal_mulai_kerja" db:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *Date      `json:"tanggal_selesai_kerja" db:"tanggal_selesai_kerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan" db:"status_pekerjaan"`
@@ -50,30 +52,113 @@ type PekerjaanAlumni struct {
 }
 
 type CreatePekerjaanRequest struct {
-	AlumniID            int     `json:"alumni_id" validate:"required"`
-	NamaPerusahaan      string  `json:"nama_perusahaan" validate:"required"`
+	AlumniID            int     `json:"alumni_id"` // Diisi dari token alumni
+	CompanyID           *int    `json:"company_id"`
+	NamaPerusahaan      string  `json:"nama_perusahaan" validate:"required_without=CompanyID"`
+	NewCompany          bool    `json:"new_company"` // Konfirmasi nama perusahaan baru walau ada yang mirip
 	PosisiJabatan       string  `json:"posisi_jabatan" validate:"required"`
 	BidangIndustri      string  `json:"bidang_industri" validate:"required"`
 	LokasiKerja         string  `json:"lokasi_kerja" validate:"required"`
 	GajiRange           *string `json:"gaji_range"`
-	TanggalMulaiKerja   Date    `json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *Date   `json:"tanggal_selesai_kerja"`
-	StatusPekerjaan     string  `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
+	Gaji                *Gaji   `json:"gaji"`
+	TanggalMulaiKerja   Date    `json:"tanggal_mulai_kerja" validate:"required,tanggal"`
+	TanggalSelesaiKerja *Date   `json:"tanggal_selesai_kerja" validate:"omitempty,tanggal"`
+	StatusPekerjaan     string  `json:"status_pekerjaan" validate:"required"` // Dicocokkan dengan taksonomi status_pekerjaan
 	DeskripsiPekerjaan  *string `json:"deskripsi_pekerjaan"`
 }
 
 type UpdatePekerjaanRequest struct {
-	NamaPerusahaan      string  `json:"nama_perusahaan" validate:"required"`
+	CompanyID           *int    `json:"company_id"`
+	NamaPerusahaan      string  `json:"nama_perusahaan" validate:"required_without=CompanyID"`
+	NewCompany          bool    `json:"new_company"` // Konfirmasi nama perusahaan baru walau ada yang mirip
 	PosisiJabatan       string  `json:"posisi_jabatan" validate:"required"`
 	BidangIndustri      string  `json:"bidang_industri" validate:"required"`
 	LokasiKerja         string  `json:"lokasi_kerja" validate:"required"`
 	GajiRange           *string `json:"gaji_range"`
-	TanggalMulaiKerja   Date    `json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *Date   `json:"tanggal_selesai_kerja"`
-	StatusPekerjaan     string  `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
+	Gaji                *Gaji   `json:"gaji"`
+	TanggalMulaiKerja   Date    `json:"tanggal_mulai_kerja" validate:"required,tanggal"`
+	TanggalSelesaiKerja *Date   `json:"tanggal_selesai_kerja" validate:"omitempty,tanggal"`
+	StatusPekerjaan     string  `json:"status_pekerjaan" validate:"required"` // Dicocokkan dengan taksonomi status_pekerjaan
 	DeskripsiPekerjaan  *string `json:"deskripsi_pekerjaan"`
 }
 
 type SoftDeletePekerjaanRequest struct {
 	Reason string `json:"reason,omitempty" validate:"max=255"`
 }
+
+// Gaji is a structured salary range in whole currency units. Gaji hanya
+// ditampilkan ke pemiliknya dan admin, user lain hanya melihat statistik.
+type Gaji struct {
+	Min      int64  `json:"min"`
+	Max      int64  `json:"max"`
+	Currency string `json:"currency"` // Kode ISO 4217, mis. IDR
+	Period   string `json:"period"`   // "monthly" atau "yearly"
+}
+
+// Periode gaji
+const (
+	GajiPeriodMonthly = "monthly"
+	GajiPeriodYearly  = "yearly"
+)
+
+// DefaultGajiCurrency is used when a salary has no currency
+const DefaultGajiCurrency = "IDR"
+
+// Status pekerjaan
+const (
+	StatusPekerjaanAktif    = "aktif"
+	StatusPekerjaanSelesai  = "selesai"
+	StatusPekerjaanResigned = "resigned"
+)
+
+// Jenis temuan pada timeline karier
+const (
+	TimelineOverlap                = "overlap"                   // Dua pekerjaan beririsan
+	TimelineGap                    = "gap"                       // Jeda antar pekerjaan melebihi batas
+	TimelineEndBeforeStart         = "end_before_start"          // Tanggal selesai sebelum tanggal mulai
+	TimelineActiveWithEndDate      = "active_with_end_date"      // Status aktif tetapi tanggal selesai sudah lewat
+	TimelineFinishedWithoutEndDate = "finished_without_end_date" // Status selesai/resigned tanpa tanggal selesai
+)
+
+// TimelineIssue is an inconsistency found on an alumni career timeline
+type TimelineIssue struct {
+	Type         string        `json:"type"`
+	PekerjaanIDs []int         `json:"pekerjaan_ids"`
+	From         *Date         `json:"from,omitempty"`
+	To           *Date         `json:"to,omitempty"`
+	Days         int           `json:"days,omitempty"`
+	Args         []interface{} `json:"args,omitempty"`
+	Message      string        `json:"message"` // Diisi dari katalog sesuai Accept-Language
+}
+
+// Aturan konsistensi yang dilanggar saat pekerjaan disimpan
+const (
+	PekerjaanRuleEndBeforeStart         = "end_before_start"
+	PekerjaanRuleFutureStart            = "future_start"
+	PekerjaanRuleActiveWithEndDate      = "active_with_end_date"
+	PekerjaanRuleFinishedWithoutEndDate = "finished_without_end_date"
+	PekerjaanRuleOverlap                = "overlap"
+)
+
+// PekerjaanViolation is a consistency rule broken by a job being saved
+type PekerjaanViolation struct {
+	Code    string        `json:"code"`
+	Args    []interface{} `json:"args,omitempty"`
+	Message string        `json:"message"` // Diisi dari katalog sesuai Accept-Language
+}
+
+// CareerTimelineEntry is a job on the timeline with its computed duration
+type CareerTimelineEntry struct {
+	PekerjaanAlumni
+	DurationDays int  `json:"duration_days"`
+	Ongoing      bool `json:"ongoing"` // Belum ada tanggal selesai, durasi dihitung sampai hari ini
+}
+
+// CareerTimeline is the chronological job history of an alumni
+type CareerTimeline struct {
+	AlumniID            int                   `json:"alumni_id"`
+	Entries             []CareerTimelineEntry `json:"entries"`
+	Issues              []TimelineIssue       `json:"issues"`
+	TotalExperienceDays int                   `json:"total_experience_days"` // Periode yang beririsan dihitung sekali
+	GapThresholdDays    int                   `json:"gap_threshold_days"`
+}
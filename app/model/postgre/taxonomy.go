@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// Jenis taksonomi yang dikelola admin
+const (
+	TaxonomyJurusan         = "jurusan"
+	TaxonomyBidangIndustri  = "bidang_industri"
+	TaxonomyStatusPekerjaan = "status_pekerjaan"
+)
+
+// TaxonomyKinds lists every taxonomy kind
+var TaxonomyKinds = []string{TaxonomyJurusan, TaxonomyBidangIndustri, TaxonomyStatusPekerjaan}
+
+// Asal term taksonomi
+const (
+	TaxonomySourceManual = "manual" // Ditambahkan admin
+	TaxonomySourceKBLI   = "kbli"   // Kategori KBLI 2020
+	TaxonomySourceData   = "data"   // Diambil dari nilai yang sudah ada saat taksonomi pertama dipakai
+	TaxonomySourceSystem = "system" // Dipakai logika aplikasi, tidak bisa dihapus
+)
+
+// TaxonomyTerm is one allowed value of a taxonomy. Input dicocokkan dengan
+// kode, label dan alias tanpa membedakan huruf besar kecil. Jurusan dan bidang
+// industri disimpan sebagai label, status pekerjaan sebagai kode karena
+// dipakai logika aplikasi.
+type TaxonomyTerm struct {
+	ID        int       `json:"id" db:"id"`
+	Kind      string    `json:"kind" db:"kind"`
+	Code      string    `json:"code" db:"code"`
+	Label     string    `json:"label" db:"label"`
+	Aliases   []string  `json:"aliases" db:"aliases"`
+	Active    bool      `json:"active" db:"active"`
+	Source    string    `json:"source" db:"source"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateTaxonomyTermRequest struct {
+	Code    string   `json:"code" validate:"required"`
+	Label   string   `json:"label" validate:"required"`
+	Aliases []string `json:"aliases"`
+	Active  *bool    `json:"active"`
+}
+
+type UpdateTaxonomyTermRequest struct {
+	Label   string   `json:"label" validate:"required"`
+	Aliases []string `json:"aliases"`
+	Active  *bool    `json:"active"`
+}
+
+// TaxonomyGroup is the count of one canonical term
+type TaxonomyGroup struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// TaxonomyUnclassified is the count of a stored value that matches no term
+type TaxonomyUnclassified struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// TaxonomyBreakdown groups the stored values of one taxonomy by canonical code
+type TaxonomyBreakdown struct {
+	Groups       []TaxonomyGroup        `json:"groups"`
+	Unclassified []TaxonomyUnclassified `json:"unclassified"`
+}
+
+// TaxonomyStatistics counts alumni per jurusan, alumni yang sedang bekerja per
+// bidang industri dan riwayat pekerjaan per status, berdasarkan kode kanonik
+type TaxonomyStatistics struct {
+	Jurusan         TaxonomyBreakdown `json:"jurusan"`
+	BidangIndustri  TaxonomyBreakdown `json:"bidang_industri"`
+	StatusPekerjaan TaxonomyBreakdown `json:"status_pekerjaan"`
+}
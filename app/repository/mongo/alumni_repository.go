@@ -4,6 +4,7 @@ import (
 	"clean-arch/app/model/mongo"
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -15,42 +16,76 @@ import (
 
 const alumniCollection = "alumni"
 
-func GetAllAlumniWithPagination(db *mongo.Database, params model.PaginationParams) ([]model.Alumni, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// alumniSortColumns lists the sortable alumni fields
+var alumniSortColumns = map[string]sortKind{
+	"nim": sortString, "nama": sortString, "jurusan": sortString,
+	"angkatan": sortInt, "tahun_lulus": sortInt, "email": sortString, "created_at": sortTime,
+}
 
-	collection := db.Collection(alumniCollection)
+func alumniSortValue(a model.Alumni, sortBy string) interface{} {
+	switch sortBy {
+	case "nim":
+		return a.NIM
+	case "nama":
+		return a.Nama
+	case "jurusan":
+		return a.Jurusan
+	case "angkatan":
+		return a.Angkatan
+	case "tahun_lulus":
+		return a.TahunLulus
+	case "email":
+		return a.Email
+	default:
+		return a.CreatedAt
+	}
+}
 
+// alumniListConditions builds the search and structured filter of the alumni list
+func alumniListConditions(ctx context.Context, db *mongo.Database, params model.PaginationParams, alumniFilter model.AlumniFilter) ([]bson.M, error) {
 	// Build filter for search
-	filter := bson.M{"deleted_at": nil}
+	conditions := []bson.M{{"deleted_at": nil}}
 	if params.Search != "" {
-		filter = bson.M{
-			"$and": []bson.M{
-				{"deleted_at": nil},
-				{
-					"$or": []bson.M{
-						{"nama": bson.M{"$regex": params.Search, "$options": "i"}},
-						{"nim": bson.M{"$regex": params.Search, "$options": "i"}},
-						{"jurusan": bson.M{"$regex": params.Search, "$options": "i"}},
-						{"email": bson.M{"$regex": params.Search, "$options": "i"}},
-					},
-				},
+		conditions = append(conditions, bson.M{
+			"$or": []bson.M{
+				{"nama": containsRegex(params.Search)},
+				{"nim": containsRegex(params.Search)},
+				{"jurusan": containsRegex(params.Search)},
+				{"email": containsRegex(params.Search)},
 			},
-		}
+		})
 	}
 
-	// Get total count
-	total, err := collection.CountDocuments(ctx, filter)
+	structured, err := alumniFilterConditions(ctx, db, alumniFilter)
+	if err != nil {
+		return nil, err
+	}
+	return append(conditions, structured...), nil
+}
+
+func GetAllAlumniWithPagination(ctx context.Context, db *mongo.Database, params model.PaginationParams, alumniFilter model.AlumniFilter) ([]model.Alumni, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(alumniCollection)
+
+	conditions, err := alumniListConditions(ctx, db, params, alumniFilter)
 	if err != nil {
 		return nil, 0, err
 	}
+	filter := andFilter(conditions)
 
-	// Validate sort column
-	validSortColumns := map[string]bool{
-		"nim": true, "nama": true, "jurusan": true,
-		"angkatan": true, "tahun_lulus": true, "email": true, "created_at": true,
+	// Get total count
+	total := int64(-1)
+	if !params.SkipCount {
+		total, err = collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
 	}
-	if !validSortColumns[params.SortBy] {
+
+	// Validate sort column
+	if _, ok := alumniSortColumns[params.SortBy]; !ok {
 		params.SortBy = "created_at"
 	}
 
@@ -60,12 +95,15 @@ func GetAllAlumniWithPagination(db *mongo.Database, params model.PaginationParam
 		sortOrder = -1
 	}
 
-	// Query with pagination
+	// Query with pagination, _id sebagai tie-breaker agar urutan stabil
 	offset := int64((params.Page - 1) * params.Limit)
 	opts := options.Find().
-		SetSort(bson.M{params.SortBy: sortOrder}).
+		SetSort(bson.D{{Key: params.SortBy, Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
 		SetSkip(offset).
 		SetLimit(int64(params.Limit))
+	if len(params.Fields) > 0 {
+		opts.SetProjection(fieldProjection(params.Fields, params.SortBy))
+	}
 
 	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -81,8 +119,96 @@ func GetAllAlumniWithPagination(db *mongo.Database, params model.PaginationParam
 	return alumniList, int(total), nil
 }
 
-func GetAllAlumni(db *mongo.Database) ([]model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// GetAllAlumniByCursor retrieves one page of alumni with keyset pagination.
+// Total bernilai -1 bila params.SkipCount.
+func GetAllAlumniByCursor(ctx context.Context, db *mongo.Database, params model.PaginationParams, alumniFilter model.AlumniFilter) ([]model.Alumni, int, model.CursorPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(alumniCollection)
+
+	kind, ok := alumniSortColumns[params.SortBy]
+	if !ok {
+		params.SortBy, kind = "created_at", sortTime
+	}
+	params.Order = strings.ToLower(params.Order)
+	if params.Order != "desc" {
+		params.Order = "asc"
+	}
+
+	var token *cursorToken
+	var err error
+	if params.Cursor != "" {
+		if token, err = decodeCursor(params.Cursor, params.SortBy, params.Order); err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+	}
+
+	conditions, err := alumniListConditions(ctx, db, params, alumniFilter)
+	if err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+
+	total := int64(-1)
+	if !params.SkipCount {
+		total, err = collection.CountDocuments(ctx, andFilter(conditions))
+		if err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+	}
+
+	// Cursor prev dibaca dengan arah sort terbalik lalu hasilnya dibalik lagi
+	backward := token != nil && token.Prev
+	ascending := (params.Order == "asc") != backward
+	if token != nil {
+		value, err := token.sortValue(kind)
+		if err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+		id, err := primitive.ObjectIDFromHex(token.ID)
+		if err != nil {
+			return nil, 0, model.CursorPage{}, ErrInvalidCursor
+		}
+		conditions = append(conditions, keysetCondition(params.SortBy, value, id, ascending))
+	}
+
+	sortOrder := 1
+	if !ascending {
+		sortOrder = -1
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: params.SortBy, Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
+		SetLimit(int64(params.Limit + 1))
+	if len(params.Fields) > 0 {
+		opts.SetProjection(fieldProjection(params.Fields, params.SortBy))
+	}
+
+	cursor, err := collection.Find(ctx, andFilter(conditions), opts)
+	if err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+	defer cursor.Close(ctx)
+
+	alumniList := []model.Alumni{}
+	if err = cursor.All(ctx, &alumniList); err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+
+	alumniList, page := keysetPage(alumniList, params.Limit, token != nil, backward, func(a model.Alumni, prev bool) string {
+		return encodeCursor(cursorToken{
+			SortBy: params.SortBy,
+			Order:  params.Order,
+			Value:  formatSortValue(alumniSortValue(a, params.SortBy)),
+			ID:     a.ID.Hex(),
+			Prev:   prev,
+		})
+	})
+
+	return alumniList, int(total), page, nil
+}
+
+func GetAllAlumni(ctx context.Context, db *mongo.Database) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -103,8 +229,8 @@ func GetAllAlumni(db *mongo.Database) ([]model.Alumni, error) {
 	return alumniList, nil
 }
 
-func GetAlumniByID(db *mongo.Database, id string) (*model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetAlumniByID(ctx context.Context, db *mongo.Database, id string) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -123,8 +249,8 @@ func GetAlumniByID(db *mongo.Database, id string) (*model.Alumni, error) {
 	return &alumni, nil
 }
 
-func CreateAlumni(db *mongo.Database, req model.CreateAlumniRequest) (*model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func CreateAlumni(ctx context.Context, db *mongo.Database, req model.CreateAlumniRequest) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -155,8 +281,8 @@ func CreateAlumni(db *mongo.Database, req model.CreateAlumniRequest) (*model.Alu
 	return &alumni, nil
 }
 
-func UpdateAlumni(db *mongo.Database, id string, req model.UpdateAlumniRequest) (*model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func UpdateAlumni(ctx context.Context, db *mongo.Database, id string, req model.UpdateAlumniRequest) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -190,8 +316,8 @@ func UpdateAlumni(db *mongo.Database, id string, req model.UpdateAlumniRequest)
 	return &updatedAlumni, nil
 }
 
-func DeleteAlumni(db *mongo.Database, id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func DeleteAlumni(ctx context.Context, db *mongo.Database, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -213,8 +339,8 @@ func DeleteAlumni(db *mongo.Database, id string) error {
 	return nil
 }
 
-func CheckAlumniByNim(db *mongo.Database, nim string) (*model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func CheckAlumniByNim(ctx context.Context, db *mongo.Database, nim string) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -228,8 +354,8 @@ func CheckAlumniByNim(db *mongo.Database, nim string) (*model.Alumni, error) {
 	return &alumni, nil
 }
 
-func GetAlumniStatistics(db *mongo.Database) (*model.AlumniStatistics, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetAlumniStatistics(ctx context.Context, db *mongo.Database) (*model.AlumniStatistics, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -313,8 +439,8 @@ func GetAlumniStatistics(db *mongo.Database) (*model.AlumniStatistics, error) {
 	return stats, nil
 }
 
-func GetTrashedAlumni(db *mongo.Database) ([]model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetTrashedAlumni(ctx context.Context, db *mongo.Database) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -335,8 +461,25 @@ func GetTrashedAlumni(db *mongo.Database) ([]model.Alumni, error) {
 	return list, nil
 }
 
-func SoftDeleteAlumni(db *mongo.Database, id string, userID *string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// CountAlumniRecords returns the number of active and trashed alumni
+func CountAlumniRecords(ctx context.Context, db *mongo.Database) (active, trashed int, err error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	collection := db.Collection(alumniCollection)
+	activeCount, err := collection.CountDocuments(ctx, bson.M{"deleted_at": nil})
+	if err != nil {
+		return 0, 0, err
+	}
+	trashedCount, err := collection.CountDocuments(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(activeCount), int(trashedCount), nil
+}
+
+func SoftDeleteAlumni(ctx context.Context, db *mongo.Database, id string, userID *string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -366,8 +509,8 @@ func SoftDeleteAlumni(db *mongo.Database, id string, userID *string) error {
 	return nil
 }
 
-func RestoreAlumni(db *mongo.Database, id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func RestoreAlumni(ctx context.Context, db *mongo.Database, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -397,8 +540,8 @@ func RestoreAlumni(db *mongo.Database, id string) error {
 	return nil
 }
 
-func HardDeleteAlumni(db *mongo.Database, id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func HardDeleteAlumni(ctx context.Context, db *mongo.Database, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -419,3 +562,405 @@ func HardDeleteAlumni(db *mongo.Database, id string) error {
 
 	return nil
 }
+
+// SetAlumniVerified sets the verified badge of an alumni
+func SetAlumniVerified(ctx context.Context, db *mongo.Database, id string, verified bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(alumniCollection)
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{
+			"is_verified": verified,
+			"updated_at":  time.Now(),
+		},
+	})
+	return err
+}
+
+// GetAlumniForExport retrieves active alumni matching any combination of ids,
+// angkatan and jurusan, ordered by NIM. Filter kosong tidak membatasi.
+func GetAlumniForExport(ctx context.Context, db *mongo.Database, ids []string, angkatan []int, jurusan []string) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := db.Collection(alumniCollection)
+	filter := bson.M{"deleted_at": nil}
+
+	if len(ids) > 0 {
+		objIDs := make([]primitive.ObjectID, 0, len(ids))
+		for _, id := range ids {
+			objID, err := primitive.ObjectIDFromHex(id)
+			if err != nil {
+				return nil, err
+			}
+			objIDs = append(objIDs, objID)
+		}
+		filter["_id"] = bson.M{"$in": objIDs}
+	}
+	if len(angkatan) > 0 {
+		filter["angkatan"] = bson.M{"$in": angkatan}
+	}
+	if len(jurusan) > 0 {
+		filter["jurusan"] = bson.M{"$in": jurusan}
+	}
+
+	opts := options.Find().SetSort(bson.M{"nim": 1})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var alumniList []model.Alumni
+	if err = cursor.All(ctx, &alumniList); err != nil {
+		return nil, err
+	}
+
+	return alumniList, nil
+}
+
+// alumniFilterConditions translates the structured alumni filters into Mongo
+// conditions. Filter pekerjaan diterjemahkan menjadi daftar _id alumni dari
+// collection pekerjaan_alumni.
+func alumniFilterConditions(ctx context.Context, db *mongo.Database, f model.AlumniFilter) ([]bson.M, error) {
+	var conditions []bson.M
+
+	if len(f.Jurusan) > 0 {
+		conditions = append(conditions, bson.M{"jurusan": bson.M{"$in": exactMatchRegexes(f.Jurusan)}})
+	}
+	if r := intRange(f.AngkatanMin, f.AngkatanMax); r != nil {
+		conditions = append(conditions, bson.M{"angkatan": r})
+	}
+	if r := intRange(f.TahunLulusMin, f.TahunLulusMax); r != nil {
+		conditions = append(conditions, bson.M{"tahun_lulus": r})
+	}
+	if f.CreatedFrom != nil || f.CreatedTo != nil {
+		created := bson.M{}
+		if f.CreatedFrom != nil {
+			created["$gte"] = *f.CreatedFrom
+		}
+		if f.CreatedTo != nil {
+			created["$lte"] = *f.CreatedTo
+		}
+		conditions = append(conditions, bson.M{"created_at": created})
+	}
+
+	if f.HasJob != nil {
+		ids, err := alumniIDsWithJob(ctx, db, bson.M{})
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, idCondition(ids, *f.HasJob))
+	}
+
+	if f.Employed != nil {
+		ids, err := alumniIDsWithJob(ctx, db, currentJobFilter())
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, idCondition(ids, *f.Employed))
+	}
+
+	if len(f.BidangIndustri) > 0 {
+		jobFilter := currentJobFilter()
+		jobFilter["bidang_industri"] = bson.M{"$in": exactMatchRegexes(f.BidangIndustri)}
+		ids, err := alumniIDsWithJob(ctx, db, jobFilter)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, idCondition(ids, true))
+	}
+
+	return conditions, nil
+}
+
+// currentJobFilter matches active jobs without an end date in the past.
+// Date disimpan sebagai subdocument, sehingga tanggalnya ada di field "time".
+func currentJobFilter() bson.M {
+	return bson.M{
+		"status_pekerjaan": "aktif",
+		"$or": []bson.M{
+			{"tanggal_selesai_kerja": nil},
+			{"tanggal_selesai_kerja.time": bson.M{"$gte": time.Now()}},
+		},
+	}
+}
+
+// alumniIDsWithJob returns the distinct alumni with a non-deleted job matching filter
+func alumniIDsWithJob(ctx context.Context, db *mongo.Database, filter bson.M) ([]interface{}, error) {
+	filter["deleted_at"] = nil
+	return db.Collection(pekerjaanCollection).Distinct(ctx, "alumni_id", filter)
+}
+
+func idCondition(ids []interface{}, include bool) bson.M {
+	if include {
+		return bson.M{"_id": bson.M{"$in": ids}}
+	}
+	return bson.M{"_id": bson.M{"$nin": ids}}
+}
+
+func intRange(min, max *int) bson.M {
+	if min == nil && max == nil {
+		return nil
+	}
+	r := bson.M{}
+	if min != nil {
+		r["$gte"] = *min
+	}
+	if max != nil {
+		r["$lte"] = *max
+	}
+	return r
+}
+
+// exactMatchRegexes builds case-insensitive exact matches for $in
+func exactMatchRegexes(values []string) bson.A {
+	regexes := make(bson.A, 0, len(values))
+	for _, v := range values {
+		regexes = append(regexes, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(v) + "$", Options: "i"})
+	}
+	return regexes
+}
+
+// SearchAlumniFullText searches alumni through the text index, ranked by
+// relevance. Jika tidak ada hasil, dicoba lagi dengan toleransi satu typo per term.
+func SearchAlumniFullText(ctx context.Context, db *mongo.Database, params model.PaginationParams, alumniFilter model.AlumniFilter) ([]model.AlumniSearchHit, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(alumniCollection)
+
+	terms := searchTerms(params.Search)
+	if len(terms) == 0 {
+		return []model.AlumniSearchHit{}, 0, nil
+	}
+
+	conditions := []bson.M{{"deleted_at": nil}}
+	structured, err := alumniFilterConditions(ctx, db, alumniFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+	conditions = append(conditions, structured...)
+
+	textFilter := andFilter(append(conditions, bson.M{"$text": bson.M{"$search": strings.Join(terms, " ")}}))
+	total, err := collection.CountDocuments(ctx, textFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := []model.AlumniSearchHit{}
+	if total > 0 {
+		cursor, err := collection.Find(ctx, textFilter, textSearchOptions(params.Page, params.Limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		defer cursor.Close(ctx)
+
+		var docs []struct {
+			model.Alumni `bson:",inline"`
+			Score        float64 `bson:"score"`
+		}
+		if err := cursor.All(ctx, &docs); err != nil {
+			return nil, 0, err
+		}
+		for _, doc := range docs {
+			hits = append(hits, model.AlumniSearchHit{
+				Alumni:     doc.Alumni,
+				Score:      doc.Score,
+				Highlights: searchHighlights(alumniSearchFields(doc.Alumni), terms),
+			})
+		}
+		return hits, int(total), nil
+	}
+
+	// Tidak ada kata yang cocok persis, coba toleransi typo
+	fuzzyFilter := andFilter(append(conditions, fuzzySearchFilter(terms, alumniSearchWeights)...))
+	cursor, err := collection.Find(ctx, fuzzyFilter, options.Find().SetLimit(maxFuzzyCandidates))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var candidates []model.Alumni
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, 0, err
+	}
+
+	scores := make([]float64, len(candidates))
+	for i, a := range candidates {
+		scores[i] = fuzzyScore(alumniSearchFields(a), alumniSearchWeights, terms)
+	}
+	page, pageScores := rankFuzzy(candidates, scores, params.Page, params.Limit)
+	for i, a := range page {
+		hits = append(hits, model.AlumniSearchHit{
+			Alumni:     a,
+			Score:      pageScores[i],
+			Highlights: searchHighlights(alumniSearchFields(a), terms),
+		})
+	}
+
+	return hits, len(candidates), nil
+}
+
+func alumniSearchFields(a model.Alumni) map[string]string {
+	return map[string]string{
+		"nama":    a.Nama,
+		"nim":     a.NIM,
+		"jurusan": a.Jurusan,
+		"email":   a.Email,
+	}
+}
+
+// GetAlumniRelations loads the requested relations of several alumni in one
+// aggregation, relasi di-join dengan $lookup agar tidak ada query per alumni.
+func GetAlumniRelations(ctx context.Context, db *mongo.Database, ids []primitive.ObjectID, include []string) (map[primitive.ObjectID]model.AlumniRelations, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	relations := map[primitive.ObjectID]model.AlumniRelations{}
+	if len(ids) == 0 || len(include) == 0 {
+		return relations, nil
+	}
+
+	jobMatch := func(extra bson.M) bson.M {
+		match := bson.M{"$expr": bson.M{"$eq": bson.A{"$alumni_id", "$$aid"}}, "deleted_at": nil}
+		for k, v := range extra {
+			match[k] = v
+		}
+		return match
+	}
+	jobSort := bson.D{{Key: "tanggal_mulai_kerja.time", Value: -1}, {Key: "_id", Value: -1}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": ids}}}},
+		{{Key: "$project", Value: bson.M{"_id": 1}}},
+	}
+	for _, name := range include {
+		switch name {
+		case model.IncludePekerjaan:
+			pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.M{
+				"from": pekerjaanCollection,
+				"let":  bson.M{"aid": "$_id"},
+				"pipeline": bson.A{
+					bson.M{"$match": jobMatch(nil)},
+					bson.M{"$sort": jobSort},
+				},
+				"as": "pekerjaan",
+			}}})
+		case model.IncludeCurrentJob:
+			pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.M{
+				"from": pekerjaanCollection,
+				"let":  bson.M{"aid": "$_id"},
+				"pipeline": bson.A{
+					bson.M{"$match": jobMatch(currentJobFilter())},
+					bson.M{"$sort": jobSort},
+					bson.M{"$limit": 1},
+				},
+				"as": "current_job",
+			}}})
+		case model.IncludeFiles:
+			pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: alumniFilesLookup()}})
+		}
+	}
+
+	cursor, err := db.Collection(alumniCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	type uploader struct {
+		Username string `bson:"username"`
+		NIM      string `bson:"nim"`
+		Email    string `bson:"email"`
+		Role     string `bson:"role"`
+	}
+	var docs []struct {
+		ID         primitive.ObjectID      `bson:"_id"`
+		Pekerjaan  []model.PekerjaanAlumni `bson:"pekerjaan"`
+		CurrentJob []model.PekerjaanAlumni `bson:"current_job"`
+		Files      []struct {
+			model.File     `bson:",inline"`
+			UploaderUser   []uploader `bson:"uploader_user"`
+			UploaderAlumni []uploader `bson:"uploader_alumni"`
+		} `bson:"files"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	for _, doc := range docs {
+		rel := model.AlumniRelations{Pekerjaan: doc.Pekerjaan}
+		if len(doc.CurrentJob) > 0 {
+			rel.CurrentJob = &doc.CurrentJob[0]
+		}
+		for _, f := range doc.Files {
+			item := model.FileWithUploader{File: f.File}
+			if f.UploaderType == model.OwnerTypeAlumni && len(f.UploaderAlumni) > 0 {
+				u := f.UploaderAlumni[0]
+				item.Uploader = &model.UserInfo{Username: u.NIM, Email: u.Email, Role: u.Role}
+			} else if f.UploaderType != model.OwnerTypeAlumni && len(f.UploaderUser) > 0 {
+				u := f.UploaderUser[0]
+				item.Uploader = &model.UserInfo{Username: u.Username, Email: u.Email, Role: u.Role}
+			}
+			rel.Files = append(rel.Files, item)
+		}
+		relations[doc.ID] = rel
+	}
+
+	return relations, nil
+}
+
+// alumniFilesLookup joins the current, non quarantined photo and certificates
+// of an alumni together with the uploader from users or alumni
+func alumniFilesLookup() bson.M {
+	uploaderLookup := func(from string, fields bson.M, as string) bson.M {
+		return bson.M{"$lookup": bson.M{
+			"from": from,
+			"let":  bson.M{"uid": "$uploaded_by"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{bson.M{"$toString": "$_id"}, "$$uid"}}}},
+				bson.M{"$project": fields},
+			},
+			"as": as,
+		}}
+	}
+
+	return bson.M{
+		"from": fileCollection,
+		"let":  bson.M{"aid": bson.M{"$toString": "$_id"}},
+		"pipeline": bson.A{
+			bson.M{"$match": bson.M{
+				"$expr":         bson.M{"$eq": bson.A{"$user_id", "$$aid"}},
+				"owner_type":    model.OwnerTypeAlumni,
+				"category":      bson.M{"$in": bson.A{"photo", "certificate"}},
+				"deleted_at":    nil,
+				"superseded_at": nil,
+				"scan_status":   bson.M{"$ne": model.ScanStatusQuarantined},
+			}},
+			bson.M{"$sort": bson.D{{Key: "uploaded_at", Value: -1}}},
+			uploaderLookup("users", bson.M{"username": 1, "email": 1, "role": 1}, "uploader_user"),
+			uploaderLookup(alumniCollection, bson.M{"nim": 1, "email": 1, "role": 1}, "uploader_alumni"),
+		},
+		"as": "files",
+	}
+}
+
+// fieldProjection projects the requested JSON fields, _id dan field sort
+// selalu ikut karena dibutuhkan untuk cursor
+func fieldProjection(fields []string, sortBy string) bson.M {
+	projection := bson.M{"_id": 1, sortBy: 1}
+	for _, f := range fields {
+		if f != "id" {
+			projection[f] = 1
+		}
+	}
+	return projection
+}
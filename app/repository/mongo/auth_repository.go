@@ -10,8 +10,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func GetUserByUsernameOrEmail(db *mongo.Database, identifier string) (*model.User, string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetUserByUsernameOrEmail(ctx context.Context, db *mongo.Database, identifier string) (*model.User, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection("users")
@@ -40,8 +40,8 @@ func GetUserByUsernameOrEmail(db *mongo.Database, identifier string) (*model.Use
 	return user, passwordHash, nil
 }
 
-func GetAlumniByNIM(db *mongo.Database, nim string) (*model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetAlumniByNIM(ctx context.Context, db *mongo.Database, nim string) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -55,13 +55,13 @@ func GetAlumniByNIM(db *mongo.Database, nim string) (*model.Alumni, error) {
 	return &alumni, nil
 }
 
-func GetAlumniWithJobs(db *mongo.Database, alumniID string) (*model.AlumniWithJobs, error) {
-	alumni, err := GetAlumniByID(db, alumniID)
+func GetAlumniWithJobs(ctx context.Context, db *mongo.Database, alumniID string) (*model.AlumniWithJobs, error) {
+	alumni, err := GetAlumniByID(ctx, db, alumniID)
 	if err != nil {
 		return nil, err
 	}
 
-	jobs, err := GetPekerjaanByAlumniID(db, alumniID)
+	jobs, err := GetPekerjaanByAlumniID(ctx, db, alumniID)
 	if err != nil {
 		return nil, err
 	}
@@ -72,8 +72,8 @@ func GetAlumniWithJobs(db *mongo.Database, alumniID string) (*model.AlumniWithJo
 	}, nil
 }
 
-func CreateAlumniWithAuth(db *mongo.Database, req model.CreateAlumniRequest, hashedPassword string) (*model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func CreateAlumniWithAuth(ctx context.Context, db *mongo.Database, req model.CreateAlumniRequest, hashedPassword string) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(alumniCollection)
@@ -109,8 +109,8 @@ func CreateAlumniWithAuth(db *mongo.Database, req model.CreateAlumniRequest, has
 	return &alumni, nil
 }
 
-func GetUserByID(db *mongo.Database, userID string) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetUserByID(ctx context.Context, db *mongo.Database, userID string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection("users")
@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"clean-arch/app/model/mongo"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const companyCollection = "companies"
+
+// ErrCompanyInUse is returned when deleting a company that still has jobs.
+// Tautan yang dilepas akan dibuat ulang oleh InitCompanyDirectory saat
+// restart, sehingga company harus di-merge, bukan dihapus.
+var ErrCompanyInUse = errors.New("company still has linked jobs")
+
+// ErrCompanyKeyTaken is returned when a name or alias of the company is
+// already used by another company
+var ErrCompanyKeyTaken = errors.New("company name or alias already used")
+
+const companyKeysIndex = "keys_unique"
+
+// EnsureCompanyIndexes creates the indexes used to match company names and
+// to find the jobs of a company
+func EnsureCompanyIndexes(ctx context.Context, db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Satu nama ternormalisasi hanya boleh dimiliki satu company. Company tanpa
+	// keys (sumber merge yang belum terhapus) tidak diindeks.
+	indexes := db.Collection(companyCollection).Indexes()
+	if _, err := indexes.CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "keys", Value: 1}},
+		Options: options.Index().
+			SetName(companyKeysIndex).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"keys": bson.M{"$type": "string"}}),
+	}); err != nil {
+		return err
+	}
+
+	// Index keys_1 dari versi sebelumnya tidak unik dan sudah digantikan
+	specs, err := indexes.ListSpecifications(ctx)
+	if err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		if spec.Name == "keys_1" {
+			if _, err := indexes.DropOne(ctx, spec.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = db.Collection(pekerjaanCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "company_id", Value: 1}},
+	})
+	return err
+}
+
+// CreateCompany saves a new company
+func CreateCompany(ctx context.Context, db *mongo.Database, company *model.Company) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	company.CreatedAt = now
+	company.UpdatedAt = now
+
+	result, err := db.Collection(companyCollection).InsertOne(ctx, company)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrCompanyKeyTaken
+	}
+	if err != nil {
+		return err
+	}
+
+	company.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetAllCompanies returns every company sorted by name
+func GetAllCompanies(ctx context.Context, db *mongo.Database) ([]model.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "nama", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := db.Collection(companyCollection).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	companies := []model.Company{}
+	if err = cursor.All(ctx, &companies); err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+// GetCompanyByID returns mongo.ErrNoDocuments when the company does not exist
+func GetCompanyByID(ctx context.Context, db *mongo.Database, id primitive.ObjectID) (*model.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var company model.Company
+	if err := db.Collection(companyCollection).FindOne(ctx, bson.M{"_id": id}).Decode(&company); err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+// GetCompanyByKey finds the company whose normalized name or alias is key
+func GetCompanyByKey(ctx context.Context, db *mongo.Database, key string) (*model.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var company model.Company
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if err := db.Collection(companyCollection).FindOne(ctx, bson.M{"keys": key}, opts).Decode(&company); err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+// UpdateCompany saves the editable fields of a company. Nama perusahaan pada
+// pekerjaan yang tertaut ikut diperbarui.
+func UpdateCompany(ctx context.Context, db *mongo.Database, company *model.Company) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	company.UpdatedAt = time.Now()
+	result, err := db.Collection(companyCollection).UpdateOne(ctx, bson.M{"_id": company.ID}, bson.M{"$set": bson.M{
+		"nama":            company.Nama,
+		"aliases":         company.Aliases,
+		"keys":            company.Keys,
+		"bidang_industri": company.BidangIndustri,
+		"lokasi_kerja":    company.LokasiKerja,
+		"website":         company.Website,
+		"updated_at":      company.UpdatedAt,
+	}})
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrCompanyKeyTaken
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	_, err = db.Collection(pekerjaanCollection).UpdateMany(ctx,
+		bson.M{"company_id": company.ID},
+		bson.M{"$set": bson.M{"nama_perusahaan": company.Nama}})
+	return err
+}
+
+// DeleteCompany removes a company without linked jobs. Pekerjaan di trash
+// ikut dihitung karena bisa direstore.
+func DeleteCompany(ctx context.Context, db *mongo.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	linked, err := CountCompanyPekerjaan(ctx, db, id)
+	if err != nil {
+		return err
+	}
+	if linked > 0 {
+		if _, err := GetCompanyByID(ctx, db, id); err != nil {
+			return err
+		}
+		return ErrCompanyInUse
+	}
+
+	result, err := db.Collection(companyCollection).DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// CountCompanyPekerjaan returns the number of jobs linked to a company,
+// termasuk yang ada di trash
+func CountCompanyPekerjaan(ctx context.Context, db *mongo.Database, id primitive.ObjectID) (int, error) {
+	count, err := db.Collection(pekerjaanCollection).CountDocuments(ctx, bson.M{"company_id": id})
+	return int(count), err
+}
+
+// CountCompanyAlumni returns per company the number of distinct alumni whose
+// current job is there
+func CountCompanyAlumni(ctx context.Context, db *mongo.Database) (map[primitive.ObjectID]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	match := currentJobFilter()
+	match["deleted_at"] = nil
+	match["company_id"] = bson.M{"$ne": nil}
+
+	cursor, err := db.Collection(pekerjaanCollection).Aggregate(ctx, []bson.M{
+		{"$match": match},
+		{"$group": bson.M{"_id": "$company_id", "alumni": bson.M{"$addToSet": "$alumni_id"}}},
+		{"$project": bson.M{"count": bson.M{"$size": "$alumni"}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Count int                `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[primitive.ObjectID]int, len(rows))
+	for _, row := range rows {
+		counts[row.ID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetCompanyAlumni lists the alumni with a job at the company, pekerjaan
+// terbaru lebih dulu. currentOnly membatasi ke pekerjaan yang masih aktif.
+func GetCompanyAlumni(ctx context.Context, db *mongo.Database, companyID primitive.ObjectID, currentOnly bool) ([]model.CompanyAlumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	match := bson.M{}
+	if currentOnly {
+		match = currentJobFilter()
+	}
+	match["company_id"] = companyID
+	match["deleted_at"] = nil
+
+	cursor, err := db.Collection(pekerjaanCollection).Aggregate(ctx, []bson.M{
+		{"$match": match},
+		{"$sort": bson.D{{Key: "tanggal_mulai_kerja.time", Value: -1}, {Key: "_id", Value: -1}}},
+		{"$lookup": bson.M{
+			"from":         alumniCollection,
+			"localField":   "alumni_id",
+			"foreignField": "_id",
+			"as":           "alumni",
+		}},
+		{"$unwind": "$alumni"},
+		{"$match": bson.M{"alumni.deleted_at": nil}},
+		{"$project": bson.M{
+			"_id":                   0,
+			"alumni_id":             1,
+			"nama":                  "$alumni.nama",
+			"jurusan":               "$alumni.jurusan",
+			"tahun_lulus":           "$alumni.tahun_lulus",
+			"pekerjaan_id":          "$_id",
+			"posisi_jabatan":        1,
+			"status_pekerjaan":      1,
+			"tanggal_mulai_kerja":   1,
+			"tanggal_selesai_kerja": 1,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.CompanyAlumni{}
+	if err = cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// MergeCompanies re-points the jobs of the source companies to target,
+// menyimpan alias target yang sudah digabung, lalu menghapus company sumber.
+// Mengembalikan jumlah pekerjaan yang dipindahkan.
+func MergeCompanies(ctx context.Context, db *mongo.Database, target *model.Company, sourceIDs []primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	result, err := db.Collection(pekerjaanCollection).UpdateMany(ctx,
+		bson.M{"company_id": bson.M{"$in": sourceIDs}},
+		bson.M{"$set": bson.M{
+			"company_id":      target.ID,
+			"nama_perusahaan": target.Nama,
+			"updated_at":      time.Now(),
+		}})
+	if err != nil {
+		return 0, err
+	}
+
+	// Keys sumber dikosongkan agar index unik menerima keys baru target.
+	// Target diperbarui sebelum sumber dihapus agar nama dan alias sumber
+	// tidak hilang bila terjadi kegagalan.
+	if _, err := db.Collection(companyCollection).UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": sourceIDs}},
+		bson.M{"$set": bson.M{"keys": []string{}}}); err != nil {
+		return result.ModifiedCount, err
+	}
+	if err := UpdateCompany(ctx, db, target); err != nil {
+		return result.ModifiedCount, err
+	}
+	if _, err := db.Collection(companyCollection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": sourceIDs}}); err != nil {
+		return result.ModifiedCount, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// GetPekerjaanWithoutCompany returns the jobs not linked to a company yet,
+// termasuk yang sudah di-soft delete
+func GetPekerjaanWithoutCompany(ctx context.Context, db *mongo.Database) ([]model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := db.Collection(pekerjaanCollection).Find(ctx, bson.M{"company_id": nil})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []model.PekerjaanAlumni
+	if err = cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// SetPekerjaanCompany links a job to a company
+func SetPekerjaanCompany(ctx context.Context, db *mongo.Database, pekerjaanID, companyID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection(pekerjaanCollection).UpdateOne(ctx,
+		bson.M{"_id": pekerjaanID},
+		bson.M{"$set": bson.M{"company_id": companyID}})
+	return err
+}
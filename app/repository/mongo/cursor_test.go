@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 30, 0, 123000000, time.UTC)
+	encoded := encodeCursor(cursorToken{
+		SortBy: "created_at",
+		Order:  "desc",
+		Value:  formatSortValue(created),
+		ID:     "65f1c0ffee0000000000abcd",
+	})
+
+	token, err := decodeCursor(encoded, "created_at", "desc")
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	value, err := token.sortValue(sortTime)
+	if err != nil {
+		t.Fatalf("sortValue: %v", err)
+	}
+	if !value.(time.Time).Equal(created) {
+		t.Errorf("sort value = %v, want %v", value, created)
+	}
+}
+
+func TestDecodeCursorRejectsInvalidOrForeignCursor(t *testing.T) {
+	if _, err := decodeCursor("not a cursor!", "nama", "asc"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("garbage cursor: err = %v", err)
+	}
+
+	encoded := encodeCursor(cursorToken{SortBy: "nama", Order: "asc", Value: "Budi", ID: "x"})
+	if _, err := decodeCursor(encoded, "nama", "desc"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("cursor for another order: err = %v", err)
+	}
+
+	token, _ := decodeCursor(encodeCursor(cursorToken{SortBy: "angkatan", Order: "asc", Value: "abc"}), "angkatan", "asc")
+	if _, err := token.sortValue(sortInt); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("non numeric value: err = %v", err)
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	cursorOf := func(item string, prev bool) string {
+		if prev {
+			return "prev:" + item
+		}
+		return "next:" + item
+	}
+
+	// Halaman pertama, masih ada data berikutnya
+	items, page := keysetPage([]string{"a", "b", "c"}, 2, false, false, cursorOf)
+	if strings.Join(items, "") != "ab" || page.Next != "next:b" || page.Prev != "" {
+		t.Errorf("first page = %v %+v", items, page)
+	}
+
+	// Halaman terakhir dari arah next
+	items, page = keysetPage([]string{"c"}, 2, true, false, cursorOf)
+	if strings.Join(items, "") != "c" || page.Next != "" || page.Prev != "prev:c" {
+		t.Errorf("last page = %v %+v", items, page)
+	}
+
+	// Mundur: query terbalik dikembalikan ke urutan tampilan
+	items, page = keysetPage([]string{"b", "a"}, 2, true, true, cursorOf)
+	if strings.Join(items, "") != "ab" || page.Next != "next:b" || page.Prev != "" {
+		t.Errorf("backward page = %v %+v", items, page)
+	}
+}
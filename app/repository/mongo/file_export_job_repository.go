@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"clean-arch/app/model/mongo"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const fileExportJobCollection = "file_export_jobs"
+
+// CreateFileExportJob saves a new export job
+func CreateFileExportJob(ctx context.Context, db *mongo.Database, job *model.FileExportJob) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileExportJobCollection)
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+
+	result, err := collection.InsertOne(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	job.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetFileExportJobByID retrieves an export job
+func GetFileExportJobByID(ctx context.Context, db *mongo.Database, id string) (*model.FileExportJob, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileExportJobCollection)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var job model.FileExportJob
+	err = collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&job)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// UpdateFileExportJob stores the progress or outcome of an export job
+func UpdateFileExportJob(ctx context.Context, db *mongo.Database, job *model.FileExportJob) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileExportJobCollection)
+	job.UpdatedAt = time.Now()
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": job.ID}, bson.M{
+		"$set": bson.M{
+			"status":        job.Status,
+			"artifact_path": job.ArtifactPath,
+			"artifact_size": job.ArtifactSize,
+			"error":         job.Error,
+			"started_at":    job.StartedAt,
+			"finished_at":   job.FinishedAt,
+			"expires_at":    job.ExpiresAt,
+			"updated_at":    job.UpdatedAt,
+		},
+	})
+	return err
+}
+
+// GetExpiredFileExportJobs retrieves finished jobs whose artifact expired
+func GetExpiredFileExportJobs(ctx context.Context, db *mongo.Database, now time.Time) ([]model.FileExportJob, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileExportJobCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{"expires_at": bson.M{"$lt": now}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []model.FileExportJob
+	if err = cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// DeleteFileExportJob removes an export job record
+func DeleteFileExportJob(ctx context.Context, db *mongo.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileExportJobCollection)
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// FailUnfinishedFileExportJobs marks queued or running jobs as failed. Dipakai
+// saat start, karena job yang terputus oleh restart tidak akan dilanjutkan.
+func FailUnfinishedFileExportJobs(ctx context.Context, db *mongo.Database, reason string, expiresAt time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileExportJobCollection)
+
+	now := time.Now()
+	result, err := collection.UpdateMany(ctx, bson.M{
+		"status": bson.M{"$in": bson.A{model.ExportJobQueued, model.ExportJobRunning}},
+	}, bson.M{
+		"$set": bson.M{
+			"status":      model.ExportJobFailed,
+			"error":       reason,
+			"finished_at": now,
+			"expires_at":  expiresAt,
+			"updated_at":  now,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.ModifiedCount), nil
+}
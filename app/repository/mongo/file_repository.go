@@ -3,18 +3,21 @@ package repository
 import (
 	"clean-arch/app/model/mongo"
 	"context"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const fileCollection = "files"
 
 // CreateFile saves file metadata to database
-func CreateFile(db *mongo.Database, file *model.File) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func CreateFile(ctx context.Context, db *mongo.Database, file *model.File) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(fileCollection)
@@ -30,20 +33,48 @@ func CreateFile(db *mongo.Database, file *model.File) error {
 	return nil
 }
 
-// GetFileByUserID retrieves files for a specific user
-func GetFileByUserID(db *mongo.Database, userID string, category string) ([]model.File, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// GetFilesByOwner retrieves the current versions of a user's or alumni's
+// files, newest first
+func GetFilesByOwner(ctx context.Context, db *mongo.Database, ownerType, ownerID string, category string) ([]model.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(fileCollection)
 
-	filter := bson.M{
-		"user_id":    userID,
-		"category":   category,
-		"deleted_at": nil,
+	filter := ownerFilter(ownerType, ownerID)
+	filter["category"] = category
+	filter["deleted_at"] = nil
+	filter["superseded_at"] = nil
+
+	opts := options.Find().SetSort(bson.D{{Key: "uploaded_at", Value: -1}})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
 	}
+	defer cursor.Close(ctx)
 
-	cursor, err := collection.Find(ctx, filter)
+	var files []model.File
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// GetFileVersions retrieves every version of an owner's category, current
+// and superseded, highest version first
+func GetFileVersions(ctx context.Context, db *mongo.Database, ownerType, ownerID, category string) ([]model.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	filter := ownerFilter(ownerType, ownerID)
+	filter["category"] = category
+	filter["deleted_at"] = nil
+
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}, {Key: "uploaded_at", Value: -1}})
+	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -57,9 +88,143 @@ func GetFileByUserID(db *mongo.Database, userID string, category string) ([]mode
 	return files, nil
 }
 
+// NextFileVersion returns the version number for a new upload. Record lama
+// tanpa nomor versi ikut dihitung agar nomor baru tidak bertabrakan.
+func NextFileVersion(ctx context.Context, db *mongo.Database, ownerType, ownerID, category string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	match := ownerFilter(ownerType, ownerID)
+	match["category"] = category
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   nil,
+			"max":   bson.M{"$max": "$version"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Max   int `bson:"max"`
+		Count int `bson:"count"`
+	}
+	if err = cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 1, nil
+	}
+
+	return nextFileVersion(result[0].Max, result[0].Count), nil
+}
+
+// nextFileVersion numbers the upload after a chain of count records whose
+// highest version is maxVersion. Record lama bernilai version 0.
+func nextFileVersion(maxVersion, count int) int {
+	return max(maxVersion, count) + 1
+}
+
+// SupersedeFileVersions marks the other current versions of file's owner and
+// category as superseded by file. Versi yang di-upload setelah file tidak
+// disentuh, sehingga dua upload bersamaan tetap menyisakan satu versi aktif.
+func SupersedeFileVersions(ctx context.Context, db *mongo.Database, file *model.File) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	now := time.Now()
+	_, err := collection.UpdateMany(ctx, supersedeFilter(file), bson.M{
+		"$set": bson.M{
+			"superseded_at": now,
+			"superseded_by": file.ID,
+			"updated_at":    now,
+		},
+	})
+	return err
+}
+
+// supersedeFilter matches the current versions that file replaces
+func supersedeFilter(file *model.File) bson.M {
+	filter := ownerFilter(fileOwnerTypeOf(file), file.UserID)
+	filter["category"] = file.Category
+	filter["_id"] = bson.M{"$ne": file.ID}
+	filter["uploaded_at"] = bson.M{"$lte": file.UploadedAt}
+	filter["superseded_at"] = nil
+	return filter
+}
+
+// RestoreFileVersion makes file the current version again and supersedes
+// every other current version of the same owner and category
+func RestoreFileVersion(ctx context.Context, db *mongo.Database, file *model.File) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	filter := ownerFilter(fileOwnerTypeOf(file), file.UserID)
+	filter["category"] = file.Category
+	filter["_id"] = bson.M{"$ne": file.ID}
+	filter["superseded_at"] = nil
+
+	now := time.Now()
+	_, err := collection.UpdateMany(ctx, filter, bson.M{
+		"$set": bson.M{
+			"superseded_at": now,
+			"superseded_by": file.ID,
+			"updated_at":    now,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": file.ID, "deleted_at": nil}, bson.M{
+		"$unset": bson.M{"superseded_at": "", "superseded_by": ""},
+		"$set":   bson.M{"updated_at": now},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	file.SupersededAt = nil
+	file.SupersededBy = nil
+	return nil
+}
+
+// fileOwnerTypeOf treats records without owner_type as user files
+func fileOwnerTypeOf(file *model.File) string {
+	if file.OwnerType == "" {
+		return model.OwnerTypeUser
+	}
+	return file.OwnerType
+}
+
+// ownerFilter matches files of one owner. Record lama tanpa owner_type
+// dianggap milik user.
+func ownerFilter(ownerType, ownerID string) bson.M {
+	if ownerType == model.OwnerTypeAlumni {
+		return bson.M{"user_id": ownerID, "owner_type": model.OwnerTypeAlumni}
+	}
+	return bson.M{"user_id": ownerID, "owner_type": bson.M{"$in": bson.A{model.OwnerTypeUser, nil}}}
+}
+
 // GetFileByID retrieves a specific file by ID
-func GetFileByID(db *mongo.Database, id string) (*model.File, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetFileByID(ctx context.Context, db *mongo.Database, id string) (*model.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(fileCollection)
@@ -79,8 +244,8 @@ func GetFileByID(db *mongo.Database, id string) (*model.File, error) {
 }
 
 // DeleteFile performs soft delete on file
-func DeleteFile(db *mongo.Database, id string, userID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func DeleteFile(ctx context.Context, db *mongo.Database, id string, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(fileCollection)
@@ -101,8 +266,8 @@ func DeleteFile(db *mongo.Database, id string, userID string) error {
 }
 
 // GetAllFilesByCategory retrieves all files of a specific category (admin only)
-func GetAllFilesByCategory(db *mongo.Database, category string) ([]model.File, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetAllFilesByCategory(ctx context.Context, db *mongo.Database, category string) ([]model.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(fileCollection)
@@ -125,3 +290,538 @@ func GetAllFilesByCategory(db *mongo.Database, category string) ([]model.File, e
 
 	return files, nil
 }
+
+// UpdateFileScanResult stores the outcome of a malware scan
+func UpdateFileScanResult(ctx context.Context, db *mongo.Database, id primitive.ObjectID, status string, signature *string, scannerVersion string, filePath string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	now := time.Now()
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{
+			"scan_status":     status,
+			"scan_signature":  signature,
+			"scanner_version": scannerVersion,
+			"scanned_at":      now,
+			"file_path":       filePath,
+			"updated_at":      now,
+		},
+	})
+	return err
+}
+
+// GetFilesForRescan retrieves active files that were not scanned with the
+// given signature version or whose previous scan did not complete
+func GetFilesForRescan(ctx context.Context, db *mongo.Database, scannerVersion string) ([]model.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	filter := bson.M{
+		"deleted_at":  nil,
+		"scan_status": bson.M{"$ne": model.ScanStatusQuarantined},
+		"$or": []bson.M{
+			{"scan_status": model.ScanStatusPending},
+			{"scanner_version": bson.M{"$ne": scannerVersion}},
+		},
+	}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []model.File
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// GetFileUsageByOwner returns file count and total bytes per category for a user or alumni
+func GetFileUsageByOwner(ctx context.Context, db *mongo.Database, ownerType, ownerID string) ([]model.CategoryUsage, error) {
+	filter := ownerFilter(ownerType, ownerID)
+	filter["deleted_at"] = nil
+	return aggregateFileUsage(ctx, db, filter)
+}
+
+// GetFileUsageForCategory returns file count and total bytes of one category for a user or alumni.
+// Versi lama tidak dihitung sebagai file, tetapi ukurannya tetap masuk total
+// byte karena masih memakai storage.
+func GetFileUsageForCategory(ctx context.Context, db *mongo.Database, ownerType, ownerID, category string) (*model.CategoryUsage, error) {
+	filter := ownerFilter(ownerType, ownerID)
+	filter["category"] = category
+	filter["deleted_at"] = nil
+	usage, err := aggregateFileUsage(ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(usage) == 0 {
+		return &model.CategoryUsage{Category: category}, nil
+	}
+	return &usage[0], nil
+}
+
+// GetFileUsageAllOwners returns usage per owner and category for every user
+// and alumni with files
+func GetFileUsageAllOwners(ctx context.Context, db *mongo.Database) ([]model.UserFileUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	cursor, err := collection.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"deleted_at": nil}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"user_id":    "$user_id",
+				"owner_type": bson.M{"$ifNull": bson.A{"$owner_type", model.OwnerTypeUser}},
+				"category":   "$category",
+			},
+			"file_count":  currentVersionCount,
+			"total_bytes": bson.M{"$sum": "$file_size"},
+		}},
+		{"$sort": bson.D{{Key: "_id.owner_type", Value: 1}, {Key: "_id.user_id", Value: 1}, {Key: "_id.category", Value: 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID struct {
+			UserID    string `bson:"user_id"`
+			OwnerType string `bson:"owner_type"`
+			Category  string `bson:"category"`
+		} `bson:"_id"`
+		FileCount  int   `bson:"file_count"`
+		TotalBytes int64 `bson:"total_bytes"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	var usage []model.UserFileUsage
+	for _, r := range results {
+		n := len(usage)
+		if n == 0 || usage[n-1].UserID != r.ID.UserID || usage[n-1].OwnerType != r.ID.OwnerType {
+			usage = append(usage, model.UserFileUsage{UserID: r.ID.UserID, OwnerType: r.ID.OwnerType})
+			n++
+		}
+		usage[n-1].Categories = append(usage[n-1].Categories, model.CategoryUsage{
+			Category:   r.ID.Category,
+			FileCount:  r.FileCount,
+			TotalBytes: r.TotalBytes,
+		})
+	}
+
+	return usage, nil
+}
+
+// currentVersionCount counts only the latest versions. Versi lama tetap
+// dihitung dalam total_bytes karena masih memakai storage, tetapi tidak
+// dihitung terhadap batas jumlah file.
+var currentVersionCount = bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$ifNull": bson.A{"$superseded_at", false}}, 0, 1}}}
+
+func aggregateFileUsage(ctx context.Context, db *mongo.Database, match bson.M) ([]model.CategoryUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	cursor, err := collection.Aggregate(ctx, []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id":         "$category",
+			"file_count":  currentVersionCount,
+			"total_bytes": bson.M{"$sum": "$file_size"},
+		}},
+		{"$project": bson.M{"_id": 0, "category": "$_id", "file_count": 1, "total_bytes": 1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var usage []model.CategoryUsage
+	if err = cursor.All(ctx, &usage); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+// GetFilesDeletedBefore retrieves files soft-deleted before the cutoff
+func GetFilesDeletedBefore(ctx context.Context, db *mongo.Database, cutoff time.Time) ([]model.File, error) {
+	return findFiles(ctx, db, bson.M{"deleted_at": bson.M{"$ne": nil, "$lt": cutoff}})
+}
+
+// GetAllFilesIncludingDeleted retrieves every file record, active or soft-deleted
+func GetAllFilesIncludingDeleted(ctx context.Context, db *mongo.Database) ([]model.File, error) {
+	return findFiles(ctx, db, bson.M{})
+}
+
+// HardDeleteFile permanently removes a file record
+func HardDeleteFile(ctx context.Context, db *mongo.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// GetCertificatesForReview retrieves certificates for the verifier queue.
+// Sertifikat lama tanpa verification_status dianggap pending.
+func GetCertificatesForReview(ctx context.Context, db *mongo.Database, filter model.CertificateReviewFilter, params model.PaginationParams) ([]model.File, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	query := bson.M{
+		"category":    "certificate",
+		"deleted_at":  nil,
+		"scan_status": bson.M{"$ne": model.ScanStatusQuarantined},
+	}
+
+	if filter.Status == model.VerificationPending {
+		query["verification_status"] = bson.M{"$in": bson.A{model.VerificationPending, nil}}
+		// Versi lama yang sudah digantikan tidak perlu direview
+		query["superseded_at"] = nil
+	} else if filter.Status != "" {
+		query["verification_status"] = filter.Status
+	}
+
+	if filter.OwnerType == model.OwnerTypeAlumni {
+		query["owner_type"] = model.OwnerTypeAlumni
+	} else if filter.OwnerType == model.OwnerTypeUser {
+		query["owner_type"] = bson.M{"$in": bson.A{model.OwnerTypeUser, nil}}
+	}
+
+	uploadedAt := bson.M{}
+	if filter.UploadedFrom != nil {
+		uploadedAt["$gte"] = *filter.UploadedFrom
+	}
+	if filter.UploadedTo != nil {
+		uploadedAt["$lte"] = *filter.UploadedTo
+	}
+	if len(uploadedAt) > 0 {
+		query["uploaded_at"] = uploadedAt
+	}
+
+	if params.Search != "" {
+		query["original_name"] = bson.M{"$regex": regexp.QuoteMeta(params.Search), "$options": "i"}
+	}
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	validSortFields := map[string]bool{
+		"uploaded_at": true, "created_at": true, "original_name": true, "file_size": true, "verified_at": true,
+	}
+	sortBy := params.SortBy
+	if !validSortFields[sortBy] {
+		sortBy = "uploaded_at"
+	}
+	sortOrder := 1
+	if strings.ToLower(params.Order) == "desc" {
+		sortOrder = -1
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: sortBy, Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
+		SetSkip(int64((params.Page - 1) * params.Limit)).
+		SetLimit(int64(params.Limit))
+
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []model.File
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, 0, err
+	}
+
+	return files, int(total), nil
+}
+
+// UpdateFileVerification stores a verifier decision. The update only applies
+// while the certificate is still in expectedStatus, so two reviewers cannot
+// both decide the same certificate.
+func UpdateFileVerification(ctx context.Context, db *mongo.Database, id primitive.ObjectID, expectedStatus, status string, reason *string, verifiedBy string) (*model.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	filter := bson.M{"_id": id, "deleted_at": nil, "verification_status": expectedStatus}
+	if expectedStatus == model.VerificationPending {
+		filter["verification_status"] = bson.M{"$in": bson.A{model.VerificationPending, nil}}
+	}
+
+	now := time.Now()
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var file model.File
+	err := collection.FindOneAndUpdate(ctx, filter, bson.M{
+		"$set": bson.M{
+			"verification_status": status,
+			"rejection_reason":    reason,
+			"verified_by":         verifiedBy,
+			"verified_at":         now,
+			"updated_at":          now,
+		},
+	}, opts).Decode(&file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// CountApprovedCertificates counts approved current certificates of an owner
+func CountApprovedCertificates(ctx context.Context, db *mongo.Database, ownerType, ownerID string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	filter := ownerFilter(ownerType, ownerID)
+	filter["category"] = "certificate"
+	filter["verification_status"] = model.VerificationApproved
+	filter["deleted_at"] = nil
+	filter["superseded_at"] = nil
+
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func findFiles(ctx context.Context, db *mongo.Database, filter bson.M) ([]model.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []model.File
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// adminFileQuery builds the query of the admin file browser
+func adminFileQuery(filter model.FileAdminFilter, search string) bson.M {
+	query := bson.M{}
+
+	switch filter.Deleted {
+	case model.FileDeletedOnly:
+		query["deleted_at"] = bson.M{"$ne": nil}
+	case model.FileDeletedInclude:
+	default:
+		query["deleted_at"] = nil
+	}
+
+	if filter.CurrentOnly {
+		query["superseded_at"] = nil
+	}
+	if filter.Category != "" {
+		query["category"] = filter.Category
+	}
+
+	if filter.OwnerType == model.OwnerTypeAlumni {
+		query["owner_type"] = model.OwnerTypeAlumni
+	} else if filter.OwnerType == model.OwnerTypeUser {
+		query["owner_type"] = bson.M{"$in": bson.A{model.OwnerTypeUser, nil}}
+	}
+	if filter.OwnerID != "" {
+		query["user_id"] = filter.OwnerID
+	}
+
+	if filter.UploaderType == model.OwnerTypeAlumni {
+		query["uploader_type"] = model.OwnerTypeAlumni
+	} else if filter.UploaderType == model.OwnerTypeUser {
+		query["uploader_type"] = bson.M{"$in": bson.A{model.OwnerTypeUser, nil}}
+	}
+	if filter.UploadedBy != "" {
+		query["uploaded_by"] = filter.UploadedBy
+	}
+
+	if prefix, ok := strings.CutSuffix(filter.FileType, "/*"); ok {
+		query["file_type"] = bson.M{"$regex": "^" + regexp.QuoteMeta(prefix+"/"), "$options": "i"}
+	} else if filter.FileType != "" {
+		query["file_type"] = bson.M{"$regex": "^" + regexp.QuoteMeta(filter.FileType) + "$", "$options": "i"}
+	}
+
+	size := bson.M{}
+	if filter.MinSize != nil {
+		size["$gte"] = *filter.MinSize
+	}
+	if filter.MaxSize != nil {
+		size["$lte"] = *filter.MaxSize
+	}
+	if len(size) > 0 {
+		query["file_size"] = size
+	}
+
+	uploadedAt := bson.M{}
+	if filter.UploadedFrom != nil {
+		uploadedAt["$gte"] = *filter.UploadedFrom
+	}
+	if filter.UploadedTo != nil {
+		uploadedAt["$lte"] = *filter.UploadedTo
+	}
+	if len(uploadedAt) > 0 {
+		query["uploaded_at"] = uploadedAt
+	}
+
+	if search != "" {
+		query["original_name"] = bson.M{"$regex": regexp.QuoteMeta(search), "$options": "i"}
+	}
+
+	return query
+}
+
+// GetFilesForAdmin retrieves one page of the admin file browser
+func GetFilesForAdmin(ctx context.Context, db *mongo.Database, filter model.FileAdminFilter, params model.PaginationParams) ([]model.File, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+	query := adminFileQuery(filter, params.Search)
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	validSortFields := map[string]bool{
+		"uploaded_at": true, "created_at": true, "updated_at": true, "deleted_at": true,
+		"original_name": true, "file_size": true, "file_type": true, "category": true,
+	}
+	sortBy := params.SortBy
+	if !validSortFields[sortBy] {
+		sortBy = "uploaded_at"
+	}
+	sortOrder := -1
+	if strings.ToLower(params.Order) == "asc" {
+		sortOrder = 1
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: sortBy, Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
+		SetSkip(int64((params.Page - 1) * params.Limit)).
+		SetLimit(int64(params.Limit))
+
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []model.File
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, 0, err
+	}
+
+	return files, int(total), nil
+}
+
+// GetFileTotalsByCategory returns count and bytes per category of every file
+// matching the admin browser filter, not only the current page
+func GetFileTotalsByCategory(ctx context.Context, db *mongo.Database, filter model.FileAdminFilter, search string) ([]model.CategoryTotal, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: adminFileQuery(filter, search)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$category",
+			"file_count":  bson.M{"$sum": 1},
+			"total_bytes": bson.M{"$sum": "$file_size"},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	totals := []model.CategoryTotal{}
+	if err = cursor.All(ctx, &totals); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// GetExportableFiles retrieves the current, scanned versions of the files of
+// the given owners. Category kosong berarti semua kategori.
+func GetExportableFiles(ctx context.Context, db *mongo.Database, ownerType string, ownerIDs []string, category string) ([]model.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := db.Collection(fileCollection)
+
+	filter := bson.M{
+		"user_id":       bson.M{"$in": ownerIDs},
+		"deleted_at":    nil,
+		"superseded_at": nil,
+		"scan_status":   bson.M{"$nin": bson.A{model.ScanStatusQuarantined, model.ScanStatusPending}},
+	}
+	if ownerType == model.OwnerTypeAlumni {
+		filter["owner_type"] = model.OwnerTypeAlumni
+	} else {
+		filter["owner_type"] = bson.M{"$in": bson.A{model.OwnerTypeUser, nil}}
+	}
+	if category != "" {
+		filter["category"] = category
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "user_id", Value: 1}, {Key: "category", Value: 1}, {Key: "uploaded_at", Value: 1}})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []model.File
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"clean-arch/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNextFileVersion(t *testing.T) {
+	for _, tc := range []struct {
+		maxVersion, count, want int
+	}{
+		{0, 0, 1},
+		{3, 3, 4},
+		{5, 2, 6}, // Versi lama sudah dihapus permanen
+		{0, 2, 3}, // Record sebelum versioning tanpa nomor versi
+	} {
+		if got := nextFileVersion(tc.maxVersion, tc.count); got != tc.want {
+			t.Errorf("nextFileVersion(%d, %d) = %d, want %d", tc.maxVersion, tc.count, got, tc.want)
+		}
+	}
+}
+
+func TestOwnerFilter(t *testing.T) {
+	alumni := ownerFilter(model.OwnerTypeAlumni, "a1")
+	if alumni["user_id"] != "a1" || alumni["owner_type"] != model.OwnerTypeAlumni {
+		t.Errorf("alumni filter = %v", alumni)
+	}
+
+	// File user juga mencakup record lama tanpa owner_type
+	user := ownerFilter(model.OwnerTypeUser, "u1")
+	in, ok := user["owner_type"].(bson.M)["$in"].(bson.A)
+	if user["user_id"] != "u1" || !ok || len(in) != 2 || in[0] != model.OwnerTypeUser || in[1] != nil {
+		t.Errorf("user filter = %v", user)
+	}
+}
+
+func TestSupersedeFilter(t *testing.T) {
+	file := &model.File{
+		ID:         primitive.NewObjectID(),
+		UserID:     "a1",
+		OwnerType:  model.OwnerTypeAlumni,
+		Category:   "certificate",
+		UploadedAt: time.Now(),
+	}
+
+	filter := supersedeFilter(file)
+	if filter["user_id"] != "a1" || filter["owner_type"] != model.OwnerTypeAlumni || filter["category"] != "certificate" {
+		t.Errorf("filter does not match the owner and category: %v", filter)
+	}
+	if filter["_id"].(bson.M)["$ne"] != file.ID {
+		t.Errorf("filter must exclude the new version itself: %v", filter["_id"])
+	}
+	// Upload yang lebih baru tidak boleh ditandai usang oleh upload lama
+	if filter["uploaded_at"].(bson.M)["$lte"] != file.UploadedAt {
+		t.Errorf("filter must skip later uploads: %v", filter["uploaded_at"])
+	}
+	if v, ok := filter["superseded_at"]; !ok || v != nil {
+		t.Errorf("filter must only match current versions: %v", filter["superseded_at"])
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// requiredIndexes lists the indexes created at startup by the Ensure*
+// functions. Mongo tidak punya migrasi, jadi readiness memeriksa index ini
+// sebagai pengganti pengecekan migrasi pada Postgres.
+var requiredIndexes = []struct {
+	collection string
+	name       string
+}{
+	{alumniCollection, "alumni_text"},
+	{pekerjaanCollection, "pekerjaan_alumni_text"},
+	{pekerjaanCollection, "company_id_1"},
+	{companyCollection, companyKeysIndex},
+	{taxonomyCollection, "kind_1_code_1"},
+}
+
+// MissingIndexes returns the required indexes that do not exist yet as
+// "collection.index"
+func MissingIndexes(ctx context.Context, db *mongo.Database) ([]string, error) {
+	existing := map[string]map[string]bool{}
+	var missing []string
+	for _, idx := range requiredIndexes {
+		names, ok := existing[idx.collection]
+		if !ok {
+			specs, err := db.Collection(idx.collection).Indexes().ListSpecifications(ctx)
+			if err != nil {
+				return nil, err
+			}
+			names = make(map[string]bool, len(specs))
+			for _, spec := range specs {
+				names[spec.Name] = true
+			}
+			existing[idx.collection] = names
+		}
+		if !names[idx.name] {
+			missing = append(missing, idx.collection+"."+idx.name)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"clean-arch/app/model/mongo"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const notificationCollection = "notifications"
+
+// CreateNotification saves a notification
+func CreateNotification(ctx context.Context, db *mongo.Database, notification *model.Notification) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(notificationCollection)
+	notification.CreatedAt = time.Now()
+
+	result, err := collection.InsertOne(ctx, notification)
+	if err != nil {
+		return err
+	}
+
+	notification.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetNotificationsByOwner retrieves notifications of a user or alumni, newest first
+func GetNotificationsByOwner(ctx context.Context, db *mongo.Database, ownerType, ownerID string, unreadOnly bool) ([]model.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(notificationCollection)
+
+	filter := bson.M{"owner_type": ownerType, "owner_id": ownerID}
+	if unreadOnly {
+		filter["read_at"] = nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(100)
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []model.Notification{}
+	if err = cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// MarkNotificationRead marks a notification of the given owner as read
+func MarkNotificationRead(ctx context.Context, db *mongo.Database, id, ownerType, ownerID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(notificationCollection)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "owner_type": ownerType, "owner_id": ownerID},
+		bson.M{"$set": bson.M{"read_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
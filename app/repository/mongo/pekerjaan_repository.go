@@ -14,43 +14,78 @@ import (
 
 const pekerjaanCollection = "pekerjaan_alumni"
 
-func GetAllPekerjaanWithPagination(db *mongo.Database, params model.PaginationParams) ([]model.PekerjaanAlumni, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// pekerjaanSortColumns lists the sortable job fields
+var pekerjaanSortColumns = map[string]sortKind{
+	"nama_perusahaan": sortString, "posisi_jabatan": sortString, "bidang_industri": sortString,
+	"lokasi_kerja": sortString, "status_pekerjaan": sortString, "tanggal_mulai_kerja": sortTime, "created_at": sortTime,
+}
 
-	collection := db.Collection(pekerjaanCollection)
+// pekerjaanSortField maps a sort column to its document path, Date disimpan
+// sebagai subdocument sehingga nilainya ada di field "time"
+func pekerjaanSortField(sortBy string) string {
+	if sortBy == "tanggal_mulai_kerja" {
+		return "tanggal_mulai_kerja.time"
+	}
+	return sortBy
+}
 
-	// Build filter
-	filter := bson.M{"deleted_at": nil}
+func pekerjaanSortValue(p model.PekerjaanAlumni, sortBy string) interface{} {
+	switch sortBy {
+	case "nama_perusahaan":
+		return p.NamaPerusahaan
+	case "posisi_jabatan":
+		return p.PosisiJabatan
+	case "bidang_industri":
+		return p.BidangIndustri
+	case "lokasi_kerja":
+		return p.LokasiKerja
+	case "status_pekerjaan":
+		return p.StatusPekerjaan
+	case "tanggal_mulai_kerja":
+		return p.TanggalMulaiKerja.Time
+	default:
+		return p.CreatedAt
+	}
+}
+
+// pekerjaanListConditions builds the search filter of the job list
+func pekerjaanListConditions(params model.PaginationParams) []bson.M {
+	conditions := []bson.M{{"deleted_at": nil}}
 	if params.Search != "" {
-		filter = bson.M{
-			"$and": []bson.M{
-				{"deleted_at": nil},
-				{
-					"$or": []bson.M{
-						{"nama_perusahaan": bson.M{"$regex": params.Search, "$options": "i"}},
-						{"posisi_jabatan": bson.M{"$regex": params.Search, "$options": "i"}},
-						{"bidang_industri": bson.M{"$regex": params.Search, "$options": "i"}},
-						{"lokasi_kerja": bson.M{"$regex": params.Search, "$options": "i"}},
-						{"status_pekerjaan": bson.M{"$regex": params.Search, "$options": "i"}},
-					},
-				},
+		conditions = append(conditions, bson.M{
+			"$or": []bson.M{
+				{"nama_perusahaan": containsRegex(params.Search)},
+				{"posisi_jabatan": containsRegex(params.Search)},
+				{"bidang_industri": containsRegex(params.Search)},
+				{"lokasi_kerja": containsRegex(params.Search)},
+				{"status_pekerjaan": containsRegex(params.Search)},
 			},
-		}
+		})
 	}
+	return conditions
+}
+
+func GetAllPekerjaanWithPagination(ctx context.Context, db *mongo.Database, params model.PaginationParams) ([]model.PekerjaanAlumni, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(pekerjaanCollection)
+
+	// Build filter
+	filter := andFilter(pekerjaanListConditions(params))
 
 	// Get total count
-	total, err := collection.CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, 0, err
+	total := int64(-1)
+	if !params.SkipCount {
+		var err error
+		total, err = collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
 	}
 
 	// Validate sort column
-	validSortColumns := map[string]bool{
-		"nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true,
-		"lokasi_kerja": true, "status_pekerjaan": true, "tanggal_mulai_kerja": true, "created_at": true,
-	}
-	if !validSortColumns[params.SortBy] {
+	if _, ok := pekerjaanSortColumns[params.SortBy]; !ok {
 		params.SortBy = "created_at"
 	}
 
@@ -60,12 +95,15 @@ func GetAllPekerjaanWithPagination(db *mongo.Database, params model.PaginationPa
 		sortOrder = -1
 	}
 
-	// Query with pagination
+	// Query with pagination, _id sebagai tie-breaker agar urutan stabil
 	offset := int64((params.Page - 1) * params.Limit)
 	opts := options.Find().
-		SetSort(bson.M{params.SortBy: sortOrder}).
+		SetSort(bson.D{{Key: params.SortBy, Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
 		SetSkip(offset).
 		SetLimit(int64(params.Limit))
+	if len(params.Fields) > 0 {
+		opts.SetProjection(fieldProjection(params.Fields, params.SortBy))
+	}
 
 	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -81,8 +119,94 @@ func GetAllPekerjaanWithPagination(db *mongo.Database, params model.PaginationPa
 	return pekerjaanList, int(total), nil
 }
 
-func GetAllPekerjaan(db *mongo.Database) ([]model.PekerjaanAlumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// GetAllPekerjaanByCursor retrieves one page of jobs with keyset pagination.
+// Total bernilai -1 bila params.SkipCount.
+func GetAllPekerjaanByCursor(ctx context.Context, db *mongo.Database, params model.PaginationParams) ([]model.PekerjaanAlumni, int, model.CursorPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(pekerjaanCollection)
+
+	kind, ok := pekerjaanSortColumns[params.SortBy]
+	if !ok {
+		params.SortBy, kind = "created_at", sortTime
+	}
+	params.Order = strings.ToLower(params.Order)
+	if params.Order != "desc" {
+		params.Order = "asc"
+	}
+	field := pekerjaanSortField(params.SortBy)
+
+	var token *cursorToken
+	var err error
+	if params.Cursor != "" {
+		if token, err = decodeCursor(params.Cursor, params.SortBy, params.Order); err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+	}
+
+	conditions := pekerjaanListConditions(params)
+
+	total := int64(-1)
+	if !params.SkipCount {
+		total, err = collection.CountDocuments(ctx, andFilter(conditions))
+		if err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+	}
+
+	// Cursor prev dibaca dengan arah sort terbalik lalu hasilnya dibalik lagi
+	backward := token != nil && token.Prev
+	ascending := (params.Order == "asc") != backward
+	if token != nil {
+		value, err := token.sortValue(kind)
+		if err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+		id, err := primitive.ObjectIDFromHex(token.ID)
+		if err != nil {
+			return nil, 0, model.CursorPage{}, ErrInvalidCursor
+		}
+		conditions = append(conditions, keysetCondition(field, value, id, ascending))
+	}
+
+	sortOrder := 1
+	if !ascending {
+		sortOrder = -1
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: field, Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
+		SetLimit(int64(params.Limit + 1))
+	if len(params.Fields) > 0 {
+		opts.SetProjection(fieldProjection(params.Fields, params.SortBy))
+	}
+
+	cursor, err := collection.Find(ctx, andFilter(conditions), opts)
+	if err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+	defer cursor.Close(ctx)
+
+	pekerjaanList := []model.PekerjaanAlumni{}
+	if err = cursor.All(ctx, &pekerjaanList); err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+
+	pekerjaanList, page := keysetPage(pekerjaanList, params.Limit, token != nil, backward, func(p model.PekerjaanAlumni, prev bool) string {
+		return encodeCursor(cursorToken{
+			SortBy: params.SortBy,
+			Order:  params.Order,
+			Value:  formatSortValue(pekerjaanSortValue(p, params.SortBy)),
+			ID:     p.ID.Hex(),
+			Prev:   prev,
+		})
+	})
+
+	return pekerjaanList, int(total), page, nil
+}
+
+func GetAllPekerjaan(ctx context.Context, db *mongo.Database) ([]model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -103,8 +227,8 @@ func GetAllPekerjaan(db *mongo.Database) ([]model.PekerjaanAlumni, error) {
 	return pekerjaanList, nil
 }
 
-func GetPekerjaanByID(db *mongo.Database, id string) (*model.PekerjaanAlumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetPekerjaanByID(ctx context.Context, db *mongo.Database, id string) (*model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -123,8 +247,8 @@ func GetPekerjaanByID(db *mongo.Database, id string) (*model.PekerjaanAlumni, er
 	return &pekerjaan, nil
 }
 
-func GetPekerjaanByAlumniID(db *mongo.Database, alumniID string) ([]model.PekerjaanAlumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetPekerjaanByAlumniID(ctx context.Context, db *mongo.Database, alumniID string) ([]model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -151,8 +275,8 @@ func GetPekerjaanByAlumniID(db *mongo.Database, alumniID string) ([]model.Pekerj
 	return pekerjaanList, nil
 }
 
-func CreatePekerjaan(db *mongo.Database, req model.CreatePekerjaanRequest, alumniID string) (*model.PekerjaanAlumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func CreatePekerjaan(ctx context.Context, db *mongo.Database, req model.CreatePekerjaanRequest, alumniID string) (*model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -167,11 +291,13 @@ func CreatePekerjaan(db *mongo.Database, req model.CreatePekerjaanRequest, alumn
 	pekerjaan := model.PekerjaanAlumni{
 		ID:                  primitive.NewObjectID(),
 		AlumniID:            objAlumniID,
+		CompanyID:           req.CompanyID,
 		NamaPerusahaan:      req.NamaPerusahaan,
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
 		LokasiKerja:         req.LokasiKerja,
 		GajiRange:           req.GajiRange,
+		Gaji:                req.Gaji,
 		TanggalMulaiKerja:   req.TanggalMulaiKerja,
 		TanggalSelesaiKerja: req.TanggalSelesaiKerja,
 		StatusPekerjaan:     req.StatusPekerjaan,
@@ -189,8 +315,8 @@ func CreatePekerjaan(db *mongo.Database, req model.CreatePekerjaanRequest, alumn
 	return &pekerjaan, nil
 }
 
-func UpdatePekerjaan(db *mongo.Database, id string, req model.UpdatePekerjaanRequest) (*model.PekerjaanAlumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func UpdatePekerjaan(ctx context.Context, db *mongo.Database, id string, req model.UpdatePekerjaanRequest) (*model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -202,11 +328,13 @@ func UpdatePekerjaan(db *mongo.Database, id string, req model.UpdatePekerjaanReq
 
 	update := bson.M{
 		"$set": bson.M{
+			"company_id":            req.CompanyID,
 			"nama_perusahaan":       req.NamaPerusahaan,
 			"posisi_jabatan":        req.PosisiJabatan,
 			"bidang_industri":       req.BidangIndustri,
 			"lokasi_kerja":          req.LokasiKerja,
 			"gaji_range":            req.GajiRange,
+			"gaji":                  req.Gaji,
 			"tanggal_mulai_kerja":   req.TanggalMulaiKerja,
 			"tanggal_selesai_kerja": req.TanggalSelesaiKerja,
 			"status_pekerjaan":      req.StatusPekerjaan,
@@ -226,8 +354,8 @@ func UpdatePekerjaan(db *mongo.Database, id string, req model.UpdatePekerjaanReq
 	return &updatedPekerjaan, nil
 }
 
-func DeletePekerjaan(db *mongo.Database, id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func DeletePekerjaan(ctx context.Context, db *mongo.Database, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -249,8 +377,17 @@ func DeletePekerjaan(db *mongo.Database, id string) error {
 	return nil
 }
 
-func SoftDeletePekerjaan(db *mongo.Database, id string, deletedBy string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// CountTrashedPekerjaan returns the number of soft-deleted pekerjaan
+func CountTrashedPekerjaan(ctx context.Context, db *mongo.Database) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	count, err := db.Collection(pekerjaanCollection).CountDocuments(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}})
+	return int(count), err
+}
+
+func SoftDeletePekerjaan(ctx context.Context, db *mongo.Database, id string, deletedBy string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -280,8 +417,8 @@ func SoftDeletePekerjaan(db *mongo.Database, id string, deletedBy string) error
 	return nil
 }
 
-func GetAlumniIDByPekerjaanID(db *mongo.Database, pekerjaanID string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetAlumniIDByPekerjaanID(ctx context.Context, db *mongo.Database, pekerjaanID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -300,8 +437,8 @@ func GetAlumniIDByPekerjaanID(db *mongo.Database, pekerjaanID string) (string, e
 	return pekerjaan.AlumniID.Hex(), nil
 }
 
-func RestorePekerjaan(db *mongo.Database, id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func RestorePekerjaan(ctx context.Context, db *mongo.Database, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -331,8 +468,8 @@ func RestorePekerjaan(db *mongo.Database, id string) error {
 	return nil
 }
 
-func HardDeletePekerjaan(db *mongo.Database, id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func HardDeletePekerjaan(ctx context.Context, db *mongo.Database, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -354,8 +491,8 @@ func HardDeletePekerjaan(db *mongo.Database, id string) error {
 	return nil
 }
 
-func SoftDeletePekerjaanByAlumniID(db *mongo.Database, alumniID string, deletedBy string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func SoftDeletePekerjaanByAlumniID(ctx context.Context, db *mongo.Database, alumniID string, deletedBy string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -385,8 +522,8 @@ func SoftDeletePekerjaanByAlumniID(db *mongo.Database, alumniID string, deletedB
 	return nil
 }
 
-func HardDeletePekerjaanByAlumniID(db *mongo.Database, alumniID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func HardDeletePekerjaanByAlumniID(ctx context.Context, db *mongo.Database, alumniID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	collection := db.Collection(pekerjaanCollection)
@@ -407,3 +544,91 @@ func HardDeletePekerjaanByAlumniID(db *mongo.Database, alumniID string) error {
 
 	return nil
 }
+
+// SearchPekerjaanFullText searches jobs through the text index, ranked by
+// relevance. Jika tidak ada hasil, dicoba lagi dengan toleransi satu typo per term.
+func SearchPekerjaanFullText(ctx context.Context, db *mongo.Database, params model.PaginationParams) ([]model.PekerjaanSearchHit, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(pekerjaanCollection)
+
+	terms := searchTerms(params.Search)
+	if len(terms) == 0 {
+		return []model.PekerjaanSearchHit{}, 0, nil
+	}
+
+	conditions := []bson.M{{"deleted_at": nil}}
+
+	textFilter := andFilter(append(conditions, bson.M{"$text": bson.M{"$search": strings.Join(terms, " ")}}))
+	total, err := collection.CountDocuments(ctx, textFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := []model.PekerjaanSearchHit{}
+	if total > 0 {
+		cursor, err := collection.Find(ctx, textFilter, textSearchOptions(params.Page, params.Limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		defer cursor.Close(ctx)
+
+		var docs []struct {
+			model.PekerjaanAlumni `bson:",inline"`
+			Score                 float64 `bson:"score"`
+		}
+		if err := cursor.All(ctx, &docs); err != nil {
+			return nil, 0, err
+		}
+		for _, doc := range docs {
+			hits = append(hits, model.PekerjaanSearchHit{
+				PekerjaanAlumni: doc.PekerjaanAlumni,
+				Score:           doc.Score,
+				Highlights:      searchHighlights(pekerjaanSearchFields(doc.PekerjaanAlumni), terms),
+			})
+		}
+		return hits, int(total), nil
+	}
+
+	// Tidak ada kata yang cocok persis, coba toleransi typo
+	fuzzyFilter := andFilter(append(conditions, fuzzySearchFilter(terms, pekerjaanSearchWeights)...))
+	cursor, err := collection.Find(ctx, fuzzyFilter, options.Find().SetLimit(maxFuzzyCandidates))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var candidates []model.PekerjaanAlumni
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, 0, err
+	}
+
+	scores := make([]float64, len(candidates))
+	for i, p := range candidates {
+		scores[i] = fuzzyScore(pekerjaanSearchFields(p), pekerjaanSearchWeights, terms)
+	}
+	page, pageScores := rankFuzzy(candidates, scores, params.Page, params.Limit)
+	for i, p := range page {
+		hits = append(hits, model.PekerjaanSearchHit{
+			PekerjaanAlumni: p,
+			Score:           pageScores[i],
+			Highlights:      searchHighlights(pekerjaanSearchFields(p), terms),
+		})
+	}
+
+	return hits, len(candidates), nil
+}
+
+func pekerjaanSearchFields(p model.PekerjaanAlumni) map[string]string {
+	fields := map[string]string{
+		"nama_perusahaan": p.NamaPerusahaan,
+		"posisi_jabatan":  p.PosisiJabatan,
+		"bidang_industri": p.BidangIndustri,
+		"lokasi_kerja":    p.LokasiKerja,
+	}
+	if p.DeskripsiPekerjaan != nil {
+		fields["deskripsi_pekerjaan"] = *p.DeskripsiPekerjaan
+	}
+	return fields
+}
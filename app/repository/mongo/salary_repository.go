@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"clean-arch/app/model/mongo"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetPekerjaanWithoutGaji returns the jobs whose free-text gaji_range has not
+// been converted to structured salary yet, termasuk yang sudah di-soft delete
+func GetPekerjaanWithoutGaji(ctx context.Context, db *mongo.Database) ([]model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"gaji_range": bson.M{"$nin": bson.A{nil, ""}}, "gaji": nil}
+	cursor, err := db.Collection(pekerjaanCollection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []model.PekerjaanAlumni
+	if err = cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// SetPekerjaanGaji stores the structured salary parsed from gaji_range
+func SetPekerjaanGaji(ctx context.Context, db *mongo.Database, id primitive.ObjectID, gaji model.Gaji) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection(pekerjaanCollection).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"gaji": gaji}})
+	return err
+}
+
+type salaryGroupRow struct {
+	Key     interface{} `bson:"_id"`
+	Amounts []float64   `bson:"amounts"`
+}
+
+// GetSalaryStatistics computes monthly salary percentiles of the current jobs
+// in currency, per jurusan, bidang industri dan tahun lulus. Alumni disaring
+// dengan alumniFilter, lalu pekerjaan aktifnya di-join dengan $lookup.
+func GetSalaryStatistics(ctx context.Context, db *mongo.Database, alumniFilter model.AlumniFilter, currency string) (*model.SalaryStatistics, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	conditions, err := alumniFilterConditions(ctx, db, alumniFilter)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append([]bson.M{{"deleted_at": nil}}, conditions...)
+
+	jobMatch := currentJobFilter()
+	jobMatch["$expr"] = bson.M{"$eq": bson.A{"$alumni_id", "$$alumni_id"}}
+	jobMatch["deleted_at"] = nil
+	jobMatch["gaji.currency"] = currency
+
+	group := func(key interface{}) []bson.M {
+		return []bson.M{
+			{"$group": bson.M{"_id": key, "amounts": bson.M{"$push": "$amount"}}},
+			{"$sort": bson.M{"_id": 1}},
+		}
+	}
+
+	pipeline := []bson.M{
+		{"$match": andFilter(conditions)},
+		{"$lookup": bson.M{
+			"from":     pekerjaanCollection,
+			"let":      bson.M{"alumni_id": "$_id"},
+			"pipeline": []bson.M{{"$match": jobMatch}},
+			"as":       "jobs",
+		}},
+		{"$unwind": "$jobs"},
+		// Titik tengah rentang, gaji tahunan dibagi 12
+		{"$project": bson.M{
+			"jurusan":         1,
+			"tahun_lulus":     1,
+			"bidang_industri": "$jobs.bidang_industri",
+			"amount": bson.M{"$divide": bson.A{
+				bson.M{"$add": bson.A{"$jobs.gaji.min", "$jobs.gaji.max"}},
+				bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$jobs.gaji.period", model.GajiPeriodYearly}}, 24, 2}},
+			}},
+		}},
+		{"$facet": bson.M{
+			"overall":        group(nil),
+			"by_jurusan":     group("$jurusan"),
+			"by_industri":    group("$bidang_industri"),
+			"by_tahun_lulus": group("$tahun_lulus"),
+		}},
+	}
+
+	cursor, err := db.Collection(alumniCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Overall      []salaryGroupRow `bson:"overall"`
+		ByJurusan    []salaryGroupRow `bson:"by_jurusan"`
+		ByIndustri   []salaryGroupRow `bson:"by_industri"`
+		ByTahunLulus []salaryGroupRow `bson:"by_tahun_lulus"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	stats := &model.SalaryStatistics{
+		Currency:         currency,
+		Period:           model.GajiPeriodMonthly,
+		ByJurusan:        []model.SalaryGroup{},
+		ByBidangIndustri: []model.SalaryGroup{},
+		ByTahunLulus:     []model.SalaryGroup{},
+	}
+	if len(results) == 0 {
+		return stats, nil
+	}
+
+	r := results[0]
+	if len(r.Overall) > 0 {
+		stats.Overall = r.Overall[0].salaryGroup()
+	}
+	for _, row := range r.ByJurusan {
+		stats.ByJurusan = append(stats.ByJurusan, row.salaryGroup())
+	}
+	for _, row := range r.ByIndustri {
+		stats.ByBidangIndustri = append(stats.ByBidangIndustri, row.salaryGroup())
+	}
+	for _, row := range r.ByTahunLulus {
+		stats.ByTahunLulus = append(stats.ByTahunLulus, row.salaryGroup())
+	}
+
+	return stats, nil
+}
+
+func (row salaryGroupRow) salaryGroup() model.SalaryGroup {
+	g := model.SalaryGroup{Count: len(row.Amounts)}
+	if row.Key != nil {
+		g.Value = fmt.Sprint(row.Key)
+	}
+	if len(row.Amounts) == 0 {
+		return g
+	}
+
+	sort.Float64s(row.Amounts)
+	g.P25 = percentileCont(row.Amounts, 0.25)
+	g.P50 = percentileCont(row.Amounts, 0.5)
+	g.P75 = percentileCont(row.Amounts, 0.75)
+	g.P90 = percentileCont(row.Amounts, 0.9)
+	return g
+}
+
+// percentileCont interpolates the p-th percentile of sorted values, sama
+// dengan percentile_cont di PostgreSQL
+func percentileCont(sorted []float64, p float64) *float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	v := sorted[lower]
+	if lower+1 < len(sorted) {
+		v += (rank - float64(lower)) * (sorted[lower+1] - sorted[lower])
+	}
+	v = math.Round(v)
+	return &v
+}
@@ -0,0 +1,37 @@
+package repository
+
+import "testing"
+
+func TestPercentileCont(t *testing.T) {
+	sorted := []float64{1000, 2000, 3000, 4000}
+	for _, tc := range []struct {
+		p    float64
+		want float64
+	}{
+		{0.25, 1750},
+		{0.5, 2500},
+		{0.9, 3700},
+	} {
+		if got := *percentileCont(sorted, tc.p); got != tc.want {
+			t.Errorf("percentileCont(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+	if got := *percentileCont([]float64{5000}, 0.9); got != 5000 {
+		t.Errorf("percentileCont of a single value = %v, want 5000", got)
+	}
+}
+
+func TestSalaryGroupRow(t *testing.T) {
+	g := salaryGroupRow{Key: int32(2022), Amounts: []float64{3000, 1000, 2000}}.salaryGroup()
+	if g.Value != "2022" || g.Count != 3 {
+		t.Errorf("group = %q (%d), want 2022 (3)", g.Value, g.Count)
+	}
+	if g.P50 == nil || *g.P50 != 2000 {
+		t.Errorf("p50 = %v, want 2000", g.P50)
+	}
+
+	empty := salaryGroupRow{}.salaryGroup()
+	if empty.Value != "" || empty.P50 != nil {
+		t.Errorf("empty group = %+v", empty)
+	}
+}
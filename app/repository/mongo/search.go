@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	maxSearchTerms      = 8   // Term selebihnya diabaikan
+	maxSearchTermLength = 40  // Term lebih panjang dipotong
+	minFuzzyTermLength  = 4   // Term lebih pendek harus cocok persis
+	maxFuzzyCandidates  = 200 // Batas dokumen yang diranking di memori saat fallback typo
+)
+
+// Bobot field pada text index, nama paling relevan
+var (
+	alumniSearchWeights = bson.D{
+		{Key: "nama", Value: 10},
+		{Key: "nim", Value: 5},
+		{Key: "jurusan", Value: 3},
+		{Key: "email", Value: 2},
+	}
+	pekerjaanSearchWeights = bson.D{
+		{Key: "nama_perusahaan", Value: 10},
+		{Key: "posisi_jabatan", Value: 8},
+		{Key: "bidang_industri", Value: 4},
+		{Key: "lokasi_kerja", Value: 3},
+		{Key: "deskripsi_pekerjaan", Value: 1},
+	}
+)
+
+// EnsureSearchIndexes creates the text indexes used by full-text search.
+// Bahasa Indonesia tidak didukung stemmer Mongo, jadi dipakai "none".
+func EnsureSearchIndexes(ctx context.Context, db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	indexes := []struct {
+		collection string
+		name       string
+		weights    bson.D
+	}{
+		{alumniCollection, "alumni_text", alumniSearchWeights},
+		{pekerjaanCollection, "pekerjaan_alumni_text", pekerjaanSearchWeights},
+	}
+
+	for _, idx := range indexes {
+		keys := bson.D{}
+		weights := bson.M{}
+		for _, field := range idx.weights {
+			keys = append(keys, bson.E{Key: field.Key, Value: "text"})
+			weights[field.Key] = field.Value
+		}
+
+		model := mongo.IndexModel{
+			Keys: keys,
+			Options: options.Index().
+				SetName(idx.name).
+				SetWeights(weights).
+				SetDefaultLanguage("none"),
+		}
+		if _, err := db.Collection(idx.collection).Indexes().CreateOne(ctx, model); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// containsRegex matches search as a literal, case-insensitive substring.
+// Input di-escape agar pola seperti "(a+)+$" tidak dieksekusi sebagai regex.
+func containsRegex(search string) bson.M {
+	return bson.M{"$regex": regexp.QuoteMeta(search), "$options": "i"}
+}
+
+// searchTerms splits a search query into lowercase words
+func searchTerms(search string) []string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := map[string]bool{}
+	var terms []string
+	for _, w := range words {
+		if r := []rune(w); len(r) > maxSearchTermLength {
+			w = string(r[:maxSearchTermLength])
+		}
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// fuzzyTermPattern builds a regex matching term with at most one typo
+// (substitusi, huruf hilang, huruf lebih, atau dua huruf tertukar). Polanya
+// hanya alternasi literal tanpa quantifier bersarang, sehingga aman dari
+// catastrophic backtracking.
+func fuzzyTermPattern(term string) string {
+	r := []rune(term)
+	if len(r) < minFuzzyTermLength {
+		return regexp.QuoteMeta(term)
+	}
+
+	quote := func(rs []rune) string { return regexp.QuoteMeta(string(rs)) }
+
+	seen := map[string]bool{}
+	var variants []string
+	add := func(v string) {
+		if !seen[v] {
+			seen[v] = true
+			variants = append(variants, v)
+		}
+	}
+
+	for i := 0; i <= len(r); i++ {
+		prefix := quote(r[:i])
+		add(prefix + "." + quote(r[i:])) // Huruf lebih
+		if i < len(r) {
+			add(prefix + "." + quote(r[i+1:])) // Substitusi
+			add(prefix + quote(r[i+1:]))       // Huruf hilang
+		}
+		if i < len(r)-1 {
+			add(prefix + quote([]rune{r[i+1], r[i]}) + quote(r[i+2:])) // Tertukar
+		}
+	}
+
+	return "(?:" + strings.Join(variants, "|") + ")"
+}
+
+// fuzzySearchFilter requires every term to match one of fields with at most one typo
+func fuzzySearchFilter(terms []string, fields bson.D) []bson.M {
+	var conditions []bson.M
+	for _, term := range terms {
+		pattern := fuzzyTermPattern(term)
+		var or []bson.M
+		for _, field := range fields {
+			or = append(or, bson.M{field.Key: bson.M{"$regex": pattern, "$options": "i"}})
+		}
+		conditions = append(conditions, bson.M{"$or": or})
+	}
+	return conditions
+}
+
+// editDistance is the Damerau-Levenshtein (optimal string alignment) distance
+func editDistance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(b)]
+}
+
+// termSimilarity scores how well word matches term, 1 berarti sama persis
+func termSimilarity(word, term string) float64 {
+	w, t := []rune(strings.ToLower(word)), []rune(term)
+	if string(w) == term {
+		return 1
+	}
+	if len(t) < minFuzzyTermLength {
+		return 0
+	}
+	d := editDistance(w, t)
+	if d > 1 {
+		return 0
+	}
+	return 1 - float64(d)/float64(max(len(w), len(t)))
+}
+
+// splitWords splits text into alternating word and separator segments
+func splitWords(text string) []string {
+	var segments []string
+	var current []rune
+	inWord := false
+	for _, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if len(current) > 0 && isWord != inWord {
+			segments = append(segments, string(current))
+			current = current[:0]
+		}
+		inWord = isWord
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		segments = append(segments, string(current))
+	}
+	return segments
+}
+
+func isWordSegment(s string) bool {
+	for _, r := range s {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return false
+}
+
+// highlight wraps the words of text that match a term in <mark>. Teks
+// di-escape sebagai HTML agar aman ditampilkan langsung oleh frontend.
+func highlight(text string, terms []string) (string, bool) {
+	var b strings.Builder
+	matched := false
+	for _, segment := range splitWords(text) {
+		hit := false
+		if isWordSegment(segment) {
+			for _, term := range terms {
+				if termSimilarity(segment, term) > 0 {
+					hit = true
+					break
+				}
+			}
+		}
+		if hit {
+			matched = true
+			b.WriteString("<mark>" + html.EscapeString(segment) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(segment))
+		}
+	}
+	return b.String(), matched
+}
+
+// searchHighlights returns the highlighted value of every field matching a term
+func searchHighlights(fields map[string]string, terms []string) map[string]string {
+	highlights := map[string]string{}
+	for field, value := range fields {
+		if marked, ok := highlight(value, terms); ok {
+			highlights[field] = marked
+		}
+	}
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
+
+// fuzzyScore ranks a document found by the typo tolerant fallback. Setiap term
+// dinilai dari kata paling mirip, dikali bobot field-nya.
+func fuzzyScore(fields map[string]string, weights bson.D, terms []string) float64 {
+	score := 0.0
+	for _, term := range terms {
+		best := 0.0
+		for _, field := range weights {
+			weight := float64(field.Value.(int))
+			for _, word := range splitWords(fields[field.Key]) {
+				if s := termSimilarity(word, term) * weight; s > best {
+					best = s
+				}
+			}
+		}
+		score += best
+	}
+	return score
+}
+
+// rankFuzzy sorts candidates by score and returns the requested page
+func rankFuzzy[T any](items []T, scores []float64, page, limit int) ([]T, []float64) {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	start := (page - 1) * limit
+	if start > len(order) {
+		start = len(order)
+	}
+	end := min(start+limit, len(order))
+
+	pageItems := make([]T, 0, end-start)
+	pageScores := make([]float64, 0, end-start)
+	for _, i := range order[start:end] {
+		pageItems = append(pageItems, items[i])
+		pageScores = append(pageScores, scores[i])
+	}
+	return pageItems, pageScores
+}
+
+// textSearchOptions sorts text search results by relevance, lalu _id agar stabil
+func textSearchOptions(page, limit int) *options.FindOptions {
+	score := bson.M{"$meta": "textScore"}
+	return options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+}
+
+func andFilter(conditions []bson.M) bson.M {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return bson.M{"$and": conditions}
+}
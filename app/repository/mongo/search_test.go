@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestContainsRegexEscapesInput(t *testing.T) {
+	pattern := containsRegex("(a+)+$")["$regex"].(string)
+	re := regexp.MustCompile(pattern)
+	if !re.MatchString("x(a+)+$y") {
+		t.Errorf("pattern %q should match the literal input", pattern)
+	}
+	if re.MatchString("aaaa") {
+		t.Errorf("pattern %q should not be interpreted as a regex", pattern)
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	got := searchTerms("  Budi, budi SANTOSO (a+)+$ ")
+	want := []string{"budi", "santoso", "a"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("searchTerms = %v, want %v", got, want)
+	}
+}
+
+func TestFuzzyTermPatternToleratesOneTypo(t *testing.T) {
+	re := regexp.MustCompile("(?i)" + fuzzyTermPattern("santoso"))
+	for _, s := range []string{"Santoso", "Santosa", "Sanotso", "Santtoso", "Santso"} {
+		if !re.MatchString(s) {
+			t.Errorf("fuzzy pattern should match %q", s)
+		}
+	}
+	if re.MatchString("Sentosa") {
+		t.Error("fuzzy pattern should not match two typos")
+	}
+
+	// Term pendek harus cocok persis
+	if fuzzyTermPattern("a.b") != regexp.QuoteMeta("a.b") {
+		t.Error("short terms should be matched literally")
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	got, ok := highlight("Budi <Santosa>", []string{"santoso"})
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if want := "Budi &lt;<mark>Santosa</mark>&gt;"; got != want {
+		t.Errorf("highlight = %q, want %q", got, want)
+	}
+
+	if _, ok := highlight("Teknik Informatika", []string{"budi"}); ok {
+		t.Error("expected no match")
+	}
+}
+
+func TestRankFuzzy(t *testing.T) {
+	items := []string{"a", "b", "c"}
+	page, scores := rankFuzzy(items, []float64{1, 3, 2}, 1, 2)
+	if strings.Join(page, "") != "bc" || scores[0] != 3 {
+		t.Errorf("rankFuzzy = %v %v", page, scores)
+	}
+
+	page, _ = rankFuzzy(items, []float64{1, 3, 2}, 3, 2)
+	if len(page) != 0 {
+		t.Errorf("page past the end should be empty, got %v", page)
+	}
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"clean-arch/app/model/mongo"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const taxonomyCollection = "taxonomy_terms"
+
+// EnsureTaxonomyIndexes makes the code of a term unique within its kind
+func EnsureTaxonomyIndexes(ctx context.Context, db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := db.Collection(taxonomyCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "kind", Value: 1}, {Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// GetTaxonomyTerms returns the terms of a kind sorted by label. Kind kosong
+// mengembalikan semua jenis.
+func GetTaxonomyTerms(ctx context.Context, db *mongo.Database, kind string, activeOnly bool) ([]model.TaxonomyTerm, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if kind != "" {
+		filter["kind"] = kind
+	}
+	if activeOnly {
+		filter["active"] = true
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "kind", Value: 1}, {Key: "label", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := db.Collection(taxonomyCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	terms := []model.TaxonomyTerm{}
+	if err = cursor.All(ctx, &terms); err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+// GetTaxonomyTermByID returns mongo.ErrNoDocuments when the term does not exist
+func GetTaxonomyTermByID(ctx context.Context, db *mongo.Database, id primitive.ObjectID) (*model.TaxonomyTerm, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var term model.TaxonomyTerm
+	if err := db.Collection(taxonomyCollection).FindOne(ctx, bson.M{"_id": id}).Decode(&term); err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+// CreateTaxonomyTerm saves a new term
+func CreateTaxonomyTerm(ctx context.Context, db *mongo.Database, term *model.TaxonomyTerm) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	term.CreatedAt = now
+	term.UpdatedAt = now
+
+	result, err := db.Collection(taxonomyCollection).InsertOne(ctx, term)
+	if err != nil {
+		return err
+	}
+
+	term.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// UpdateTaxonomyTerm saves the label, aliases and active flag of a term
+func UpdateTaxonomyTerm(ctx context.Context, db *mongo.Database, term *model.TaxonomyTerm) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	term.UpdatedAt = time.Now()
+	result, err := db.Collection(taxonomyCollection).UpdateOne(ctx, bson.M{"_id": term.ID}, bson.M{"$set": bson.M{
+		"label":      term.Label,
+		"aliases":    term.Aliases,
+		"active":     term.Active,
+		"updated_at": term.UpdatedAt,
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteTaxonomyTerm removes a term
+func DeleteTaxonomyTerm(ctx context.Context, db *mongo.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := db.Collection(taxonomyCollection).DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SeedTaxonomyTerms inserts the terms whose kind and code do not exist yet.
+// Term yang sudah ada tidak diubah agar suntingan admin tetap terjaga.
+// Mengembalikan jumlah term yang ditambahkan.
+func SeedTaxonomyTerms(ctx context.Context, db *mongo.Database, terms []model.TaxonomyTerm) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	inserted := 0
+	for _, term := range terms {
+		term.CreatedAt = now
+		term.UpdatedAt = now
+		result, err := db.Collection(taxonomyCollection).UpdateOne(ctx,
+			bson.M{"kind": term.Kind, "code": term.Code},
+			bson.M{"$setOnInsert": term},
+			options.Update().SetUpsert(true))
+		if err != nil {
+			return inserted, err
+		}
+		if result.UpsertedCount > 0 {
+			inserted++
+		}
+	}
+	return inserted, nil
+}
+
+// GetTaxonomyValueCounts counts the raw stored values of a taxonomy: alumni
+// per jurusan, alumni yang sedang bekerja per bidang industri, dan pekerjaan
+// per status. Data yang di-soft delete tidak dihitung.
+func GetTaxonomyValueCounts(ctx context.Context, db *mongo.Database, kind string) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var collection string
+	var pipeline []bson.M
+	switch kind {
+	case model.TaxonomyJurusan:
+		collection = alumniCollection
+		pipeline = []bson.M{
+			{"$match": bson.M{"deleted_at": nil}},
+			{"$group": bson.M{"_id": "$jurusan", "count": bson.M{"$sum": 1}}},
+		}
+	case model.TaxonomyBidangIndustri:
+		match := currentJobFilter()
+		match["deleted_at"] = nil
+		collection = pekerjaanCollection
+		pipeline = []bson.M{
+			{"$match": match},
+			{"$group": bson.M{"_id": "$bidang_industri", "alumni": bson.M{"$addToSet": "$alumni_id"}}},
+			{"$project": bson.M{"count": bson.M{"$size": "$alumni"}}},
+		}
+	case model.TaxonomyStatusPekerjaan:
+		collection = pekerjaanCollection
+		pipeline = []bson.M{
+			{"$match": bson.M{"deleted_at": nil}},
+			{"$group": bson.M{"_id": "$status_pekerjaan", "count": bson.M{"$sum": 1}}},
+		}
+	default:
+		return nil, fmt.Errorf("jenis taksonomi tidak dikenal: %s", kind)
+	}
+
+	cursor, err := db.Collection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Value string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Value] += row.Count
+	}
+	return counts, nil
+}
+
+// GetDistinctTaxonomyValues returns every distinct stored value of jurusan or
+// bidang industri, termasuk milik data yang sudah di-soft delete
+func GetDistinctTaxonomyValues(ctx context.Context, db *mongo.Database, kind string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var collection, field string
+	switch kind {
+	case model.TaxonomyJurusan:
+		collection, field = alumniCollection, "jurusan"
+	case model.TaxonomyBidangIndustri:
+		collection, field = pekerjaanCollection, "bidang_industri"
+	default:
+		return nil, fmt.Errorf("jenis taksonomi tidak dikenal: %s", kind)
+	}
+
+	raw, err := db.Collection(collection).Distinct(ctx, field, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok && s != "" {
+			values = append(values, s)
+		}
+	}
+	sort.Strings(values)
+	return values, nil
+}
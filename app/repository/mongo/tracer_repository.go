@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"clean-arch/app/model/mongo"
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// tracerGroupRow is one $group result of the tracer pipeline
+type tracerGroupRow struct {
+	ID struct {
+		TahunLulus *int    `bson:"tahun_lulus"`
+		Jurusan    *string `bson:"jurusan"`
+	} `bson:"_id"`
+	Total             int    `bson:"total"`
+	Employed          int    `bson:"employed"`
+	CurrentlyEmployed int    `bson:"currently_employed"`
+	Within6           int    `bson:"within_6"`
+	Within12          int    `bson:"within_12"`
+	Months            []*int `bson:"months"`
+}
+
+type tracerShareRow struct {
+	Value string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// GetTracerStatistics computes the tracer study metrics of the alumni matching
+// alumniFilter in a single aggregation. Pekerjaan pertama dan status bekerja
+// di-join dengan $lookup, lalu dikelompokkan per grup dengan $facet.
+func GetTracerStatistics(ctx context.Context, db *mongo.Database, alumniFilter model.AlumniFilter, graduationMonth int) (*model.TracerStatistics, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	conditions, err := alumniFilterConditions(ctx, db, alumniFilter)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append([]bson.M{{"deleted_at": nil}}, conditions...)
+
+	now := time.Now()
+	months := "$months"
+	hasMonths := bson.M{"$ne": bson.A{months, nil}}
+	withinMonths := func(n int) bson.M {
+		return bson.M{"$cond": bson.A{
+			bson.M{"$and": bson.A{hasMonths, bson.M{"$lte": bson.A{months, n}}}}, 1, 0,
+		}}
+	}
+	group := func(id interface{}) []bson.M {
+		return []bson.M{
+			{"$group": bson.M{
+				"_id":                id,
+				"total":              bson.M{"$sum": 1},
+				"employed":           bson.M{"$sum": bson.M{"$cond": bson.A{hasMonths, 1, 0}}},
+				"currently_employed": bson.M{"$sum": bson.M{"$cond": bson.A{"$currently_employed", 1, 0}}},
+				"within_6":           bson.M{"$sum": withinMonths(6)},
+				"within_12":          bson.M{"$sum": withinMonths(12)},
+				"months":             bson.M{"$push": months},
+			}},
+			{"$sort": bson.D{{Key: "_id.tahun_lulus", Value: 1}, {Key: "_id.jurusan", Value: 1}}},
+		}
+	}
+	share := func(field string) []bson.M {
+		return []bson.M{
+			{"$match": bson.M{"first_job": bson.M{"$ne": nil}}},
+			{"$group": bson.M{"_id": "$first_job." + field, "count": bson.M{"$sum": 1}}},
+			{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		}
+	}
+
+	pipeline := []bson.M{
+		{"$match": andFilter(conditions)},
+		{"$lookup": bson.M{
+			"from": pekerjaanCollection,
+			"let":  bson.M{"alumni_id": "$_id"},
+			"pipeline": []bson.M{
+				{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$alumni_id", "$$alumni_id"}}, "deleted_at": nil}},
+				{"$sort": bson.D{{Key: "tanggal_mulai_kerja.time", Value: 1}, {Key: "_id", Value: 1}}},
+				{"$project": bson.M{
+					"tanggal_mulai_kerja":   1,
+					"tanggal_selesai_kerja": 1,
+					"status_pekerjaan":      1,
+					"bidang_industri":       1,
+					"lokasi_kerja":          1,
+				}},
+			},
+			"as": "jobs",
+		}},
+		{"$addFields": bson.M{
+			"first_job": bson.M{"$arrayElemAt": bson.A{"$jobs", 0}},
+			// Sama dengan currentJobFilter: aktif dan belum lewat tanggal selesai
+			"currently_employed": bson.M{"$gt": bson.A{bson.M{"$size": bson.M{"$filter": bson.M{
+				"input": "$jobs",
+				"cond": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$$this.status_pekerjaan", model.StatusPekerjaanAktif}},
+					bson.M{"$or": bson.A{
+						bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$$this.tanggal_selesai_kerja", nil}}, nil}},
+						bson.M{"$gte": bson.A{"$$this.tanggal_selesai_kerja.time", now}},
+					}},
+				}},
+			}}}, 0}},
+		}},
+		// Bulan penuh sejak tanggal 1 bulan kelulusan, minimal 0
+		{"$addFields": bson.M{
+			"months": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$first_job", nil}}, nil}},
+				nil,
+				bson.M{"$max": bson.A{0, bson.M{"$add": bson.A{
+					bson.M{"$multiply": bson.A{bson.M{"$subtract": bson.A{bson.M{"$year": "$first_job.tanggal_mulai_kerja.time"}, "$tahun_lulus"}}, 12}},
+					bson.M{"$subtract": bson.A{bson.M{"$month": "$first_job.tanggal_mulai_kerja.time"}, graduationMonth}},
+				}}}},
+			}},
+		}},
+		{"$facet": bson.M{
+			"overall":         group(nil),
+			"by_tahun_lulus":  group(bson.M{"tahun_lulus": "$tahun_lulus"}),
+			"by_jurusan":      group(bson.M{"jurusan": "$jurusan"}),
+			"by_cohort":       group(bson.M{"tahun_lulus": "$tahun_lulus", "jurusan": "$jurusan"}),
+			"by_industri":     share("bidang_industri"),
+			"by_lokasi_kerja": share("lokasi_kerja"),
+		}},
+	}
+
+	cursor, err := db.Collection(alumniCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Overall      []tracerGroupRow `bson:"overall"`
+		ByTahunLulus []tracerGroupRow `bson:"by_tahun_lulus"`
+		ByJurusan    []tracerGroupRow `bson:"by_jurusan"`
+		ByCohort     []tracerGroupRow `bson:"by_cohort"`
+		ByIndustri   []tracerShareRow `bson:"by_industri"`
+		ByLokasi     []tracerShareRow `bson:"by_lokasi_kerja"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	stats := &model.TracerStatistics{
+		GraduationMonth:  graduationMonth,
+		ByTahunLulus:     []model.TracerGroup{},
+		ByJurusan:        []model.TracerGroup{},
+		ByCohort:         []model.TracerGroup{},
+		ByBidangIndustri: []model.TracerShare{},
+		ByLokasiKerja:    []model.TracerShare{},
+	}
+	if len(results) == 0 {
+		return stats, nil
+	}
+
+	r := results[0]
+	if len(r.Overall) > 0 {
+		stats.Overall = r.Overall[0].tracerGroup()
+	}
+	for _, rows := range []struct {
+		src []tracerGroupRow
+		dst *[]model.TracerGroup
+	}{
+		{r.ByTahunLulus, &stats.ByTahunLulus},
+		{r.ByJurusan, &stats.ByJurusan},
+		{r.ByCohort, &stats.ByCohort},
+	} {
+		for _, row := range rows.src {
+			*rows.dst = append(*rows.dst, row.tracerGroup())
+		}
+	}
+	stats.ByBidangIndustri = tracerShares(r.ByIndustri, stats.Overall.Employed)
+	stats.ByLokasiKerja = tracerShares(r.ByLokasi, stats.Overall.Employed)
+
+	return stats, nil
+}
+
+func (row tracerGroupRow) tracerGroup() model.TracerGroup {
+	var months []int
+	for _, m := range row.Months {
+		if m != nil {
+			months = append(months, *m)
+		}
+	}
+
+	return model.TracerGroup{
+		TahunLulus:             row.ID.TahunLulus,
+		Jurusan:                row.ID.Jurusan,
+		TotalAlumni:            row.Total,
+		Employed:               row.Employed,
+		CurrentlyEmployed:      row.CurrentlyEmployed,
+		EmploymentRate:         percentage(row.Employed, row.Total),
+		CurrentEmploymentRate:  percentage(row.CurrentlyEmployed, row.Total),
+		MedianMonthsToFirstJob: median(months),
+		EmployedWithin6Months:  percentage(row.Within6, row.Total),
+		EmployedWithin12Months: percentage(row.Within12, row.Total),
+	}
+}
+
+func tracerShares(rows []tracerShareRow, employed int) []model.TracerShare {
+	shares := make([]model.TracerShare, 0, len(rows))
+	for _, row := range rows {
+		shares = append(shares, model.TracerShare{
+			Value:      row.Value,
+			Count:      row.Count,
+			Percentage: percentage(row.Count, employed),
+		})
+	}
+	return shares
+}
+
+// percentage returns n/total in percent, dibulatkan dua desimal
+func percentage(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)*10000/float64(total)) / 100
+}
+
+// median returns the median of values, nil for an empty slice
+func median(values []int) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	mid := len(sorted) / 2
+	m := float64(sorted[mid])
+	if len(sorted)%2 == 0 {
+		m = float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+	return &m
+}
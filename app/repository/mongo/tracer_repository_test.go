@@ -0,0 +1,31 @@
+package repository
+
+import "testing"
+
+func TestMedian(t *testing.T) {
+	if median(nil) != nil {
+		t.Error("median of an empty slice should be nil")
+	}
+	if m := median([]int{7, 1, 3}); *m != 3 {
+		t.Errorf("median = %v, want 3", *m)
+	}
+	if m := median([]int{12, 0, 4, 6}); *m != 5 {
+		t.Errorf("median = %v, want 5", *m)
+	}
+}
+
+func TestTracerGroupRow(t *testing.T) {
+	three, six := 3, 6
+	row := tracerGroupRow{Total: 3, Employed: 2, CurrentlyEmployed: 1, Within6: 2, Within12: 2, Months: []*int{&three, nil, &six}}
+
+	g := row.tracerGroup()
+	if g.EmploymentRate != 66.67 || g.CurrentEmploymentRate != 33.33 {
+		t.Errorf("rates = %v, %v", g.EmploymentRate, g.CurrentEmploymentRate)
+	}
+	if g.MedianMonthsToFirstJob == nil || *g.MedianMonthsToFirstJob != 4.5 {
+		t.Errorf("median months = %v, want 4.5", g.MedianMonthsToFirstJob)
+	}
+	if percentage(1, 0) != 0 {
+		t.Error("percentage of an empty group should be 0")
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"clean-arch/app/model/mongo"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const uploadPolicyCollection = "upload_policies"
+
+// GetUploadPolicy retrieves the stored policy for a category
+func GetUploadPolicy(ctx context.Context, db *mongo.Database, category string) (*model.UploadPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadPolicyCollection)
+
+	var policy model.UploadPolicy
+	err := collection.FindOne(ctx, bson.M{"category": category}).Decode(&policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// GetAllUploadPolicies retrieves every stored policy
+func GetAllUploadPolicies(ctx context.Context, db *mongo.Database) ([]model.UploadPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadPolicyCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var policies []model.UploadPolicy
+	if err = cursor.All(ctx, &policies); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// UpsertUploadPolicy creates or replaces the policy for a category
+func UpsertUploadPolicy(ctx context.Context, db *mongo.Database, policy *model.UploadPolicy) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadPolicyCollection)
+	policy.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := collection.ReplaceOne(ctx, bson.M{"category": policy.Category}, policy, opts)
+	return err
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"clean-arch/app/model/mongo"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const uploadSessionCollection = "upload_sessions"
+
+// CreateUploadSession saves a new resumable upload session
+func CreateUploadSession(ctx context.Context, db *mongo.Database, session *model.UploadSession) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadSessionCollection)
+	session.Status = model.UploadSessionUploading
+	session.CreatedAt = time.Now()
+	session.UpdatedAt = time.Now()
+
+	result, err := collection.InsertOne(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	session.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetUploadSessionByID retrieves an upload session
+func GetUploadSessionByID(ctx context.Context, db *mongo.Database, id string) (*model.UploadSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadSessionCollection)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var session model.UploadSession
+	err = collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// AdvanceUploadSessionOffset moves the offset forward only if it still equals
+// expectedOffset, so concurrent PATCH requests cannot both succeed. Sesi yang
+// sedang di-complete tidak diubah.
+func AdvanceUploadSessionOffset(ctx context.Context, db *mongo.Database, id primitive.ObjectID, expectedOffset, newOffset int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadSessionCollection)
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id, "offset": expectedOffset, "status": bson.M{"$ne": model.UploadSessionCompleting}}, bson.M{
+		"$set": bson.M{
+			"offset":     newOffset,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.ModifiedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// ClaimUploadSession moves the session from uploading to completing. Hanya
+// satu request complete yang berhasil; yang lain mendapat mongo.ErrNoDocuments.
+// Sesi lama tanpa status dianggap uploading.
+func ClaimUploadSession(ctx context.Context, db *mongo.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadSessionCollection)
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id, "status": bson.M{"$ne": model.UploadSessionCompleting}}, bson.M{
+		"$set": bson.M{
+			"status":     model.UploadSessionCompleting,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.ModifiedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// ReleaseUploadSession returns a claimed session to uploading after a failed
+// completion so the client can retry
+func ReleaseUploadSession(ctx context.Context, db *mongo.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadSessionCollection)
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{
+			"status":     model.UploadSessionUploading,
+			"updated_at": time.Now(),
+		},
+	})
+	return err
+}
+
+// DeleteUploadSession removes an upload session
+func DeleteUploadSession(ctx context.Context, db *mongo.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadSessionCollection)
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// GetExpiredUploadSessions retrieves sessions whose expiry has passed
+func GetExpiredUploadSessions(ctx context.Context, db *mongo.Database, now time.Time) ([]model.UploadSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadSessionCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{"expires_at": bson.M{"$lt": now}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []model.UploadSession
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetAllUploadSessions retrieves every upload session, expired or not
+func GetAllUploadSessions(ctx context.Context, db *mongo.Database) ([]model.UploadSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := db.Collection(uploadSessionCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []model.UploadSession
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
@@ -2,13 +2,45 @@ package repository
 
 import (
 	"clean-arch/app/model/postgre"
+	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
-func GetAllAlumniWithPagination(db *sql.DB, params model.PaginationParams) ([]model.Alumni, int, error) {
+// alumniSortColumns lists the sortable alumni columns
+var alumniSortColumns = map[string]sortKind{
+	"id": sortInt, "nim": sortString, "nama": sortString, "jurusan": sortString,
+	"angkatan": sortInt, "tahun_lulus": sortInt, "email": sortString, "created_at": sortTime,
+}
+
+func alumniSortValue(a model.Alumni, sortBy string) interface{} {
+	switch sortBy {
+	case "id":
+		return a.ID
+	case "nim":
+		return a.NIM
+	case "nama":
+		return a.Nama
+	case "jurusan":
+		return a.Jurusan
+	case "angkatan":
+		return a.Angkatan
+	case "tahun_lulus":
+		return a.TahunLulus
+	case "email":
+		return a.Email
+	default:
+		return a.CreatedAt
+	}
+}
+
+// alumniListWhere builds the search and structured filter of the alumni list
+func alumniListWhere(params model.PaginationParams, alumniFilter model.AlumniFilter) (string, []interface{}, int) {
 	// Build WHERE clause for search
 	whereClause := "WHERE deleted_at IS NULL"
 	args := []interface{}{}
@@ -16,16 +48,37 @@ func GetAllAlumniWithPagination(db *sql.DB, params model.PaginationParams) ([]mo
 
 	if params.Search != "" {
 		whereClause += fmt.Sprintf(" AND (nama ILIKE $%d OR nim ILIKE $%d OR jurusan ILIKE $%d OR email ILIKE $%d)", argIndex, argIndex, argIndex, argIndex)
-		args = append(args, "%"+params.Search+"%")
+		args = append(args, "%"+escapeLike(params.Search)+"%")
 		argIndex++
 	}
 
-	// Validate and set sort column
-	validSortColumns := map[string]bool{
-		"id": true, "nim": true, "nama": true, "jurusan": true,
-		"angkatan": true, "tahun_lulus": true, "email": true, "created_at": true,
+	return appendAlumniFilter(whereClause, args, argIndex, alumniFilter)
+}
+
+const alumniListColumns = "id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, is_verified, created_at, updated_at"
+
+func scanAlumniRows(rows *sql.Rows) ([]model.Alumni, error) {
+	alumniList := []model.Alumni{}
+	for rows.Next() {
+		var alumni model.Alumni
+		err := rows.Scan(
+			&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
+			&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
+			&alumni.NoTelepon, &alumni.Alamat, &alumni.IsVerified, &alumni.CreatedAt, &alumni.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		alumniList = append(alumniList, alumni)
 	}
-	if !validSortColumns[params.SortBy] {
+	return alumniList, rows.Err()
+}
+
+func GetAllAlumniWithPagination(ctx context.Context, db *sql.DB, params model.PaginationParams, alumniFilter model.AlumniFilter) ([]model.Alumni, int, error) {
+	whereClause, args, argIndex := alumniListWhere(params, alumniFilter)
+
+	// Validate and set sort column
+	if _, ok := alumniSortColumns[params.SortBy]; !ok {
 		params.SortBy = "created_at"
 	}
 
@@ -35,52 +88,122 @@ func GetAllAlumniWithPagination(db *sql.DB, params model.PaginationParams) ([]mo
 	}
 
 	// Get total count for pagination
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM alumni %s", whereClause)
-	var total int
-	err := db.QueryRow(countQuery, args...).Scan(&total)
-	if err != nil {
-		return nil, 0, err
+	total := -1
+	if !params.SkipCount {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM alumni %s", whereClause)
+		if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
 	}
 
-	// Build main query with pagination
+	// Build main query with pagination, id sebagai tie-breaker agar urutan stabil
 	offset := (params.Page - 1) * params.Limit
 	query := fmt.Sprintf(`
-		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at 
+		SELECT %s 
 		FROM alumni %s 
-		ORDER BY %s %s 
+		ORDER BY %s %s, id %s 
 		LIMIT $%d OFFSET $%d`,
-		whereClause, params.SortBy, params.Order, argIndex, argIndex+1)
+		alumniListColumns, whereClause, params.SortBy, params.Order, params.Order, argIndex, argIndex+1)
 
 	args = append(args, params.Limit, offset)
 
-	rows, err := db.Query(query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer rows.Close()
 
-	var alumniList []model.Alumni
-	for rows.Next() {
-		var alumni model.Alumni
-		err := rows.Scan(
-			&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
-			&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-			&alumni.NoTelepon, &alumni.Alamat, &alumni.CreatedAt, &alumni.UpdatedAt,
-		)
+	alumniList, err := scanAlumniRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return alumniList, total, nil
+}
+
+// GetAllAlumniByCursor retrieves one page of alumni with keyset pagination.
+// Total bernilai -1 bila params.SkipCount.
+func GetAllAlumniByCursor(ctx context.Context, db *sql.DB, params model.PaginationParams, alumniFilter model.AlumniFilter) ([]model.Alumni, int, model.CursorPage, error) {
+	kind, ok := alumniSortColumns[params.SortBy]
+	if !ok {
+		params.SortBy, kind = "created_at", sortTime
+	}
+	params.Order = strings.ToLower(params.Order)
+	if params.Order != "desc" {
+		params.Order = "asc"
+	}
+
+	var token *cursorToken
+	var err error
+	if params.Cursor != "" {
+		if token, err = decodeCursor(params.Cursor, params.SortBy, params.Order); err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+	}
+
+	whereClause, args, argIndex := alumniListWhere(params, alumniFilter)
+
+	total := -1
+	if !params.SkipCount {
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM alumni "+whereClause, args...).Scan(&total); err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+	}
+
+	// Cursor prev dibaca dengan arah sort terbalik lalu hasilnya dibalik lagi
+	backward := token != nil && token.Prev
+	ascending := (params.Order == "asc") != backward
+	if token != nil {
+		value, err := token.sortValue(kind)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, model.CursorPage{}, err
 		}
-		alumniList = append(alumniList, alumni)
+		id, err := token.tokenID()
+		if err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+		whereClause += " AND " + keysetCondition(params.SortBy, argIndex, ascending)
+		args = append(args, value, id)
+		argIndex += 2
 	}
 
-	return alumniList, total, nil
+	direction := "ASC"
+	if !ascending {
+		direction = "DESC"
+	}
+	query := fmt.Sprintf("SELECT %s FROM alumni %s ORDER BY %s %s, id %s LIMIT $%d",
+		alumniListColumns, whereClause, params.SortBy, direction, direction, argIndex)
+	args = append(args, params.Limit+1)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+	defer rows.Close()
+
+	alumniList, err := scanAlumniRows(rows)
+	if err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+
+	alumniList, page := keysetPage(alumniList, params.Limit, token != nil, backward, func(a model.Alumni, prev bool) string {
+		return encodeCursor(cursorToken{
+			SortBy: params.SortBy,
+			Order:  params.Order,
+			Value:  formatSortValue(alumniSortValue(a, params.SortBy)),
+			ID:     strconv.Itoa(a.ID),
+			Prev:   prev,
+		})
+	})
+
+	return alumniList, total, page, nil
 }
 
-func GetAllAlumni(db *sql.DB) ([]model.Alumni, error) {
-	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at 
+func GetAllAlumni(ctx context.Context, db *sql.DB) ([]model.Alumni, error) {
+	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, is_verified, created_at, updated_at 
 	          FROM alumni WHERE deleted_at IS NULL ORDER BY created_at DESC`
 
-	rows, err := db.Query(query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +215,7 @@ func GetAllAlumni(db *sql.DB) ([]model.Alumni, error) {
 		err := rows.Scan(
 			&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 			&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-			&alumni.NoTelepon, &alumni.Alamat, &alumni.CreatedAt, &alumni.UpdatedAt,
+			&alumni.NoTelepon, &alumni.Alamat, &alumni.IsVerified, &alumni.CreatedAt, &alumni.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -102,15 +225,15 @@ func GetAllAlumni(db *sql.DB) ([]model.Alumni, error) {
 	return alumniList, nil
 }
 
-func GetAlumniByID(db *sql.DB, id int) (*model.Alumni, error) {
+func GetAlumniByID(ctx context.Context, db *sql.DB, id int) (*model.Alumni, error) {
 	alumni := new(model.Alumni)
-	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at 
+	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, is_verified, created_at, updated_at 
 	          FROM alumni WHERE id = $1 AND deleted_at IS NULL`
 
-	err := db.QueryRow(query, id).Scan(
+	err := db.QueryRowContext(ctx, query, id).Scan(
 		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-		&alumni.NoTelepon, &alumni.Alamat, &alumni.CreatedAt, &alumni.UpdatedAt,
+		&alumni.NoTelepon, &alumni.Alamat, &alumni.IsVerified, &alumni.CreatedAt, &alumni.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -118,28 +241,28 @@ func GetAlumniByID(db *sql.DB, id int) (*model.Alumni, error) {
 	return alumni, nil
 }
 
-func CreateAlumni(db *sql.DB, req model.CreateAlumniRequest) (*model.Alumni, error) {
+func CreateAlumni(ctx context.Context, db *sql.DB, req model.CreateAlumniRequest) (*model.Alumni, error) {
 	now := time.Now()
 	var id int
 	query := `INSERT INTO alumni (nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at)
 	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
 
-	err := db.QueryRow(query, req.NIM, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus,
+	err := db.QueryRowContext(ctx, query, req.NIM, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus,
 		req.Email, req.NoTelepon, req.Alamat, now, now).Scan(&id)
 	if err != nil {
 		return nil, err
 	}
 
-	return GetAlumniByID(db, id)
+	return GetAlumniByID(ctx, db, id)
 }
 
-func UpdateAlumni(db *sql.DB, id int, req model.UpdateAlumniRequest) (*model.Alumni, error) {
+func UpdateAlumni(ctx context.Context, db *sql.DB, id int, req model.UpdateAlumniRequest) (*model.Alumni, error) {
 	now := time.Now()
 	query := `UPDATE alumni SET nama = $1, jurusan = $2, angkatan = $3, tahun_lulus = $4, 
 	          email = $5, no_telepon = $6, alamat = $7, updated_at = $8 
 			  WHERE id = $9 AND deleted_at IS NULL`
 
-	result, err := db.Exec(query, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus,
+	result, err := db.ExecContext(ctx, query, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus,
 		req.Email, req.NoTelepon, req.Alamat, now, id)
 	if err != nil {
 		return nil, err
@@ -150,12 +273,12 @@ func UpdateAlumni(db *sql.DB, id int, req model.UpdateAlumniRequest) (*model.Alu
 		return nil, sql.ErrNoRows
 	}
 
-	return GetAlumniByID(db, id)
+	return GetAlumniByID(ctx, db, id)
 }
 
-func DeleteAlumni(db *sql.DB, id int) error {
+func DeleteAlumni(ctx context.Context, db *sql.DB, id int) error {
 	query := `DELETE FROM alumni WHERE id = $1`
-	result, err := db.Exec(query, id)
+	result, err := db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -168,15 +291,15 @@ func DeleteAlumni(db *sql.DB, id int) error {
 	return nil
 }
 
-func CheckAlumniByNim(db *sql.DB, nim string) (*model.Alumni, error) {
+func CheckAlumniByNim(ctx context.Context, db *sql.DB, nim string) (*model.Alumni, error) {
 	alumni := new(model.Alumni)
-	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at 
+	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, is_verified, created_at, updated_at 
 	          FROM alumni WHERE nim = $1 AND deleted_at IS NULL`
 
-	err := db.QueryRow(query, nim).Scan(
+	err := db.QueryRowContext(ctx, query, nim).Scan(
 		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-		&alumni.NoTelepon, &alumni.Alamat, &alumni.CreatedAt, &alumni.UpdatedAt,
+		&alumni.NoTelepon, &alumni.Alamat, &alumni.IsVerified, &alumni.CreatedAt, &alumni.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -184,7 +307,7 @@ func CheckAlumniByNim(db *sql.DB, nim string) (*model.Alumni, error) {
 	return alumni, nil
 }
 
-func GetAlumniStatistics(db *sql.DB) (*model.AlumniStatistics, error) {
+func GetAlumniStatistics(ctx context.Context, db *sql.DB) (*model.AlumniStatistics, error) {
 	stats := &model.AlumniStatistics{
 		AlumniByJurusan:    make(map[string]int),
 		AlumniByAngkatan:   make(map[string]int),
@@ -193,14 +316,14 @@ func GetAlumniStatistics(db *sql.DB) (*model.AlumniStatistics, error) {
 
 	// Get total alumni count
 	var totalCount int
-	err := db.QueryRow("SELECT COUNT(*) FROM alumni WHERE deleted_at IS NULL").Scan(&totalCount)
+	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM alumni WHERE deleted_at IS NULL").Scan(&totalCount)
 	if err != nil {
 		return nil, err
 	}
 	stats.TotalAlumni = totalCount
 
 	// Get alumni count by jurusan
-	rows, err := db.Query("SELECT jurusan, COUNT(*) FROM alumni WHERE deleted_at IS NULL GROUP BY jurusan ORDER BY jurusan")
+	rows, err := db.QueryContext(ctx, "SELECT jurusan, COUNT(*) FROM alumni WHERE deleted_at IS NULL GROUP BY jurusan ORDER BY jurusan")
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +339,7 @@ func GetAlumniStatistics(db *sql.DB) (*model.AlumniStatistics, error) {
 	}
 
 	// Get alumni count by angkatan
-	rows, err = db.Query("SELECT angkatan, COUNT(*) FROM alumni WHERE deleted_at IS NULL GROUP BY angkatan ORDER BY angkatan")
+	rows, err = db.QueryContext(ctx, "SELECT angkatan, COUNT(*) FROM alumni WHERE deleted_at IS NULL GROUP BY angkatan ORDER BY angkatan")
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +355,7 @@ func GetAlumniStatistics(db *sql.DB) (*model.AlumniStatistics, error) {
 	}
 
 	// Get alumni count by tahun lulus
-	rows, err = db.Query("SELECT tahun_lulus, COUNT(*) FROM alumni WHERE deleted_at IS NULL GROUP BY tahun_lulus ORDER BY tahun_lulus")
+	rows, err = db.QueryContext(ctx, "SELECT tahun_lulus, COUNT(*) FROM alumni WHERE deleted_at IS NULL GROUP BY tahun_lulus ORDER BY tahun_lulus")
 	if err != nil {
 		return nil, err
 	}
@@ -250,9 +373,9 @@ func GetAlumniStatistics(db *sql.DB) (*model.AlumniStatistics, error) {
 	return stats, nil
 }
 
-func GetTrashedAlumni(db *sql.DB) ([]model.Alumni, error) {
-	rows, err := db.Query(`
-		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, deleted_at, deleted_by
+func GetTrashedAlumni(ctx context.Context, db *sql.DB) ([]model.Alumni, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, is_verified, created_at, updated_at, deleted_at, deleted_by
 		FROM alumni
 		WHERE deleted_at IS NOT NULL
 		ORDER BY deleted_at DESC`)
@@ -266,7 +389,7 @@ func GetTrashedAlumni(db *sql.DB) ([]model.Alumni, error) {
 		var a model.Alumni
 		if err := rows.Scan(
 			&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email,
-			&a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt, &a.DeletedAt, &a.DeletedBy,
+			&a.NoTelepon, &a.Alamat, &a.IsVerified, &a.CreatedAt, &a.UpdatedAt, &a.DeletedAt, &a.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -275,8 +398,17 @@ func GetTrashedAlumni(db *sql.DB) ([]model.Alumni, error) {
 	return list, nil
 }
 
-func SoftDeleteAlumni(db *sql.DB, id int, userID *int) error {
-	_, err := db.Exec(`
+// CountAlumniRecords returns the number of active and trashed alumni
+func CountAlumniRecords(ctx context.Context, db *sql.DB) (active, trashed int, err error) {
+	err = db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE deleted_at IS NULL),
+		       COUNT(*) FILTER (WHERE deleted_at IS NOT NULL)
+		FROM alumni`).Scan(&active, &trashed)
+	return active, trashed, err
+}
+
+func SoftDeleteAlumni(ctx context.Context, db *sql.DB, id int, userID *int) error {
+	_, err := db.ExecContext(ctx, `
 		UPDATE alumni 
 		SET deleted_at = $1, deleted_by = $2, updated_at = $1
 		WHERE id = $3 AND deleted_at IS NULL`,
@@ -284,8 +416,8 @@ func SoftDeleteAlumni(db *sql.DB, id int, userID *int) error {
 	return err
 }
 
-func RestoreAlumni(db *sql.DB, id int) error {
-	result, err := db.Exec(`
+func RestoreAlumni(ctx context.Context, db *sql.DB, id int) error {
+	result, err := db.ExecContext(ctx, `
 		UPDATE alumni 
 		SET deleted_at = NULL, deleted_by = NULL, updated_at = $1
 		WHERE id = $2 AND deleted_at IS NOT NULL`,
@@ -300,9 +432,9 @@ func RestoreAlumni(db *sql.DB, id int) error {
 	return nil
 }
 
-func HardDeleteAlumni(db *sql.DB, id int) error {
+func HardDeleteAlumni(ctx context.Context, db *sql.DB, id int) error {
 	// hanya boleh hard delete jika sudah di-trash
-	result, err := db.Exec(`DELETE FROM alumni WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	result, err := db.ExecContext(ctx, `DELETE FROM alumni WHERE id = $1 AND deleted_at IS NOT NULL`, id)
 	if err != nil {
 		return err
 	}
@@ -312,3 +444,310 @@ func HardDeleteAlumni(db *sql.DB, id int) error {
 	}
 	return nil
 }
+
+// SetAlumniVerified sets the verified badge of an alumni
+func SetAlumniVerified(ctx context.Context, db *sql.DB, id int, verified bool) error {
+	_, err := db.ExecContext(ctx, `UPDATE alumni SET is_verified = $1, updated_at = $2 WHERE id = $3`, verified, time.Now(), id)
+	return err
+}
+
+// GetAlumniForExport retrieves active alumni matching any combination of ids,
+// angkatan and jurusan, ordered by NIM. Filter kosong tidak membatasi.
+func GetAlumniForExport(ctx context.Context, db *sql.DB, ids []int, angkatan []int, jurusan []string) ([]model.Alumni, error) {
+	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, is_verified, created_at, updated_at
+	          FROM alumni WHERE deleted_at IS NULL
+	          AND (COALESCE(cardinality($1::int[]), 0) = 0 OR id = ANY($1))
+	          AND (COALESCE(cardinality($2::int[]), 0) = 0 OR angkatan = ANY($2))
+	          AND (COALESCE(cardinality($3::text[]), 0) = 0 OR jurusan = ANY($3))
+	          ORDER BY nim`
+
+	rows, err := db.QueryContext(ctx, query, pq.Array(ids), pq.Array(angkatan), pq.Array(jurusan))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alumniList []model.Alumni
+	for rows.Next() {
+		var alumni model.Alumni
+		err := rows.Scan(
+			&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
+			&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
+			&alumni.NoTelepon, &alumni.Alamat, &alumni.IsVerified, &alumni.CreatedAt, &alumni.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		alumniList = append(alumniList, alumni)
+	}
+	return alumniList, rows.Err()
+}
+
+// currentJobCondition matches an active job without an end date in the past
+const currentJobCondition = "p.status_pekerjaan = 'aktif' AND (p.tanggal_selesai_kerja IS NULL OR p.tanggal_selesai_kerja >= CURRENT_DATE)"
+
+// appendAlumniFilter adds the structured alumni filters to a WHERE clause on
+// the alumni table. Filter pekerjaan memakai subquery EXISTS ke pekerjaan_alumni.
+func appendAlumniFilter(whereClause string, args []interface{}, argIndex int, f model.AlumniFilter) (string, []interface{}, int) {
+	if len(f.Jurusan) > 0 {
+		whereClause += fmt.Sprintf(" AND LOWER(jurusan) = ANY($%d)", argIndex)
+		args = append(args, pq.Array(lowerAll(f.Jurusan)))
+		argIndex++
+	}
+
+	ranges := []struct {
+		column string
+		value  *int
+		op     string
+	}{
+		{"angkatan", f.AngkatanMin, ">="},
+		{"angkatan", f.AngkatanMax, "<="},
+		{"tahun_lulus", f.TahunLulusMin, ">="},
+		{"tahun_lulus", f.TahunLulusMax, "<="},
+	}
+	for _, r := range ranges {
+		if r.value == nil {
+			continue
+		}
+		whereClause += fmt.Sprintf(" AND %s %s $%d", r.column, r.op, argIndex)
+		args = append(args, *r.value)
+		argIndex++
+	}
+
+	if f.CreatedFrom != nil {
+		whereClause += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+		args = append(args, *f.CreatedFrom)
+		argIndex++
+	}
+	if f.CreatedTo != nil {
+		whereClause += fmt.Sprintf(" AND created_at <= $%d", argIndex)
+		args = append(args, *f.CreatedTo)
+		argIndex++
+	}
+
+	jobExists := "EXISTS (SELECT 1 FROM pekerjaan_alumni p WHERE p.alumni_id = alumni.id AND p.deleted_at IS NULL%s)"
+	if f.HasJob != nil {
+		clause := fmt.Sprintf(jobExists, "")
+		if !*f.HasJob {
+			clause = "NOT " + clause
+		}
+		whereClause += " AND " + clause
+	}
+	if f.Employed != nil {
+		clause := fmt.Sprintf(jobExists, " AND "+currentJobCondition)
+		if !*f.Employed {
+			clause = "NOT " + clause
+		}
+		whereClause += " AND " + clause
+	}
+	if len(f.BidangIndustri) > 0 {
+		whereClause += " AND " + fmt.Sprintf(jobExists, fmt.Sprintf(" AND %s AND LOWER(p.bidang_industri) = ANY($%d)", currentJobCondition, argIndex))
+		args = append(args, pq.Array(lowerAll(f.BidangIndustri)))
+		argIndex++
+	}
+
+	return whereClause, args, argIndex
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+	return lowered
+}
+
+// SearchAlumniFullText searches alumni through search_vector, ranked by
+// ts_rank. Jika tidak ada hasil, dicoba lagi dengan kemiripan trigram nama
+// untuk menoleransi typo.
+func SearchAlumniFullText(ctx context.Context, db *sql.DB, params model.PaginationParams, alumniFilter model.AlumniFilter) ([]model.AlumniSearchHit, int, error) {
+	terms := searchTerms(params.Search)
+	if len(terms) == 0 {
+		return []model.AlumniSearchHit{}, 0, nil
+	}
+
+	whereClause, args, argIndex := appendAlumniFilter("WHERE deleted_at IS NULL", []interface{}{}, 1, alumniFilter)
+
+	// Pertama cari kata yang cocok persis, fallback ke trigram bila kosong
+	searches := []struct {
+		match string
+		rank  string
+		value string
+	}{
+		{
+			match: "search_vector @@ to_tsquery('simple', $%[1]d)",
+			rank:  "ts_rank(search_vector, to_tsquery('simple', $%[1]d))",
+			value: tsQuery(terms),
+		},
+		{
+			match: "($%[1]d <%% nama OR $%[1]d <%% jurusan)",
+			rank:  "GREATEST(word_similarity($%[1]d, nama), word_similarity($%[1]d, jurusan))",
+			value: strings.Join(terms, " "),
+		},
+	}
+
+	for _, search := range searches {
+		where := whereClause + " AND " + fmt.Sprintf(search.match, argIndex)
+		searchArgs := append(append([]interface{}{}, args...), search.value)
+
+		var total int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM alumni "+where, searchArgs...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+		if total == 0 {
+			continue
+		}
+
+		query := fmt.Sprintf(`
+			SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, is_verified, created_at, updated_at,
+			       %s AS score
+			FROM alumni %s
+			ORDER BY score DESC, id ASC
+			LIMIT $%d OFFSET $%d`,
+			fmt.Sprintf(search.rank, argIndex), where, argIndex+1, argIndex+2)
+		searchArgs = append(searchArgs, params.Limit, (params.Page-1)*params.Limit)
+
+		rows, err := db.QueryContext(ctx, query, searchArgs...)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer rows.Close()
+
+		hits := []model.AlumniSearchHit{}
+		for rows.Next() {
+			var hit model.AlumniSearchHit
+			if err := rows.Scan(
+				&hit.ID, &hit.NIM, &hit.Nama, &hit.Jurusan,
+				&hit.Angkatan, &hit.TahunLulus, &hit.Email,
+				&hit.NoTelepon, &hit.Alamat, &hit.IsVerified, &hit.CreatedAt, &hit.UpdatedAt,
+				&hit.Score,
+			); err != nil {
+				return nil, 0, err
+			}
+			hit.Highlights = searchHighlights(map[string]string{
+				"nama":    hit.Nama,
+				"nim":     hit.NIM,
+				"jurusan": hit.Jurusan,
+				"email":   hit.Email,
+			}, terms)
+			hits = append(hits, hit)
+		}
+
+		return hits, total, rows.Err()
+	}
+
+	return []model.AlumniSearchHit{}, 0, nil
+}
+
+// GetAlumniRelations loads the requested relations of several alumni with one
+// query per relation untuk semua alumni sekaligus, uploader file di-join
+// langsung agar tidak ada query per baris.
+func GetAlumniRelations(ctx context.Context, db *sql.DB, ids []int, include []string) (map[int]model.AlumniRelations, error) {
+	relations := map[int]model.AlumniRelations{}
+	if len(ids) == 0 || len(include) == 0 {
+		return relations, nil
+	}
+
+	alumniIDs := make([]int64, len(ids))
+	for i, id := range ids {
+		alumniIDs[i] = int64(id)
+	}
+
+	for _, name := range include {
+		switch name {
+		case model.IncludePekerjaan:
+			jobs, err := queryPekerjaan(ctx, db, `SELECT `+pekerjaanListColumns+` FROM pekerjaan_alumni
+				WHERE alumni_id = ANY($1) AND deleted_at IS NULL
+				ORDER BY tanggal_mulai_kerja DESC, id DESC`, pq.Array(alumniIDs))
+			if err != nil {
+				return nil, err
+			}
+			for _, job := range jobs {
+				rel := relations[job.AlumniID]
+				rel.Pekerjaan = append(rel.Pekerjaan, job)
+				relations[job.AlumniID] = rel
+			}
+
+		case model.IncludeCurrentJob:
+			jobs, err := queryPekerjaan(ctx, db, `SELECT DISTINCT ON (alumni_id) `+pekerjaanListColumns+` FROM pekerjaan_alumni p
+				WHERE alumni_id = ANY($1) AND deleted_at IS NULL AND `+currentJobCondition+`
+				ORDER BY alumni_id, tanggal_mulai_kerja DESC, id DESC`, pq.Array(alumniIDs))
+			if err != nil {
+				return nil, err
+			}
+			for i := range jobs {
+				rel := relations[jobs[i].AlumniID]
+				rel.CurrentJob = &jobs[i]
+				relations[jobs[i].AlumniID] = rel
+			}
+
+		case model.IncludeFiles:
+			if err := loadAlumniFiles(ctx, db, alumniIDs, relations); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return relations, nil
+}
+
+func queryPekerjaan(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]model.PekerjaanAlumni, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPekerjaanRows(rows)
+}
+
+// loadAlumniFiles adds the current, non quarantined photo and certificates of
+// the alumni to relations, terbaru lebih dulu
+func loadAlumniFiles(ctx context.Context, db *sql.DB, alumniIDs []int64, relations map[int]model.AlumniRelations) error {
+	columns := strings.Split(fileColumns, ",")
+	for i, col := range columns {
+		columns[i] = "f." + strings.TrimSpace(col)
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+strings.Join(columns, ", ")+`,
+		       CASE WHEN f.uploader_type = 'alumni' THEN ua.nim ELSE u.username END,
+		       CASE WHEN f.uploader_type = 'alumni' THEN ua.email ELSE u.email END,
+		       CASE WHEN f.uploader_type = 'alumni' THEN 'alumni' ELSE u.role END
+		FROM files f
+		LEFT JOIN users u ON f.uploader_type <> 'alumni' AND u.id = f.uploaded_by
+		LEFT JOIN alumni ua ON f.uploader_type = 'alumni' AND ua.id = f.uploaded_by
+		WHERE f.owner_type = 'alumni' AND f.user_id = ANY($1) AND f.category IN ('photo', 'certificate')
+		  AND f.deleted_at IS NULL AND f.superseded_at IS NULL AND f.scan_status <> 'quarantined'
+		ORDER BY f.uploaded_at DESC`, pq.Array(alumniIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var username, email, role sql.NullString
+		file, err := scanFile(extraScanner{row: rows, extra: []interface{}{&username, &email, &role}})
+		if err != nil {
+			return err
+		}
+
+		item := model.FileWithUploader{File: *file}
+		if username.Valid {
+			item.Uploader = &model.UserInfo{Username: username.String, Email: email.String, Role: role.String}
+		}
+
+		rel := relations[file.UserID]
+		rel.Files = append(rel.Files, item)
+		relations[file.UserID] = rel
+	}
+	return rows.Err()
+}
+
+// extraScanner scans additional trailing columns after the ones read by a scan helper
+type extraScanner struct {
+	row   rowScanner
+	extra []interface{}
+}
+
+func (s extraScanner) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
@@ -2,17 +2,18 @@ package repository
 
 import (
 	"clean-arch/app/model/postgre"
+	"context"
 	"database/sql"
 )
 
-func GetUserByUsernameOrEmail(db *sql.DB, identifier string) (*model.User, string, error) {
+func GetUserByUsernameOrEmail(ctx context.Context, db *sql.DB, identifier string) (*model.User, string, error) {
 	var user model.User
 	var passwordHash string
 
 	query := `SELECT id, username, email, password_hash, role, created_at 
 	          FROM users WHERE username = $1 OR email = $1`
 
-	err := db.QueryRow(query, identifier).Scan(
+	err := db.QueryRowContext(ctx, query, identifier).Scan(
 		&user.ID, &user.Username, &user.Email, &passwordHash,
 		&user.Role, &user.CreatedAt,
 	)
@@ -24,18 +25,18 @@ func GetUserByUsernameOrEmail(db *sql.DB, identifier string) (*model.User, strin
 	return &user, passwordHash, nil
 }
 
-func GetAlumniByNIM(db *sql.DB, nim string) (*model.Alumni, error) {
+func GetAlumniByNIM(ctx context.Context, db *sql.DB, nim string) (*model.Alumni, error) {
 	var alumni model.Alumni
 
 	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, 
-	          password_hash, role, no_telepon, alamat, created_at, updated_at 
+	          password_hash, role, no_telepon, alamat, is_verified, created_at, updated_at 
 	          FROM alumni WHERE nim = $1`
 
-	err := db.QueryRow(query, nim).Scan(
+	err := db.QueryRowContext(ctx, query, nim).Scan(
 		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
 		&alumni.Password, &alumni.Role, &alumni.NoTelepon,
-		&alumni.Alamat, &alumni.CreatedAt, &alumni.UpdatedAt,
+		&alumni.Alamat, &alumni.IsVerified, &alumni.CreatedAt, &alumni.UpdatedAt,
 	)
 
 	if err != nil {
@@ -45,15 +46,15 @@ func GetAlumniByNIM(db *sql.DB, nim string) (*model.Alumni, error) {
 	return &alumni, nil
 }
 
-func GetAlumniWithJobs(db *sql.DB, alumniID int) (*model.AlumniWithJobs, error) {
+func GetAlumniWithJobs(ctx context.Context, db *sql.DB, alumniID int) (*model.AlumniWithJobs, error) {
 	// Get alumni data
-	alumni, err := GetAlumniByID(db, alumniID)
+	alumni, err := GetAlumniByID(ctx, db, alumniID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get job history
-	jobs, err := GetPekerjaanByAlumniID(db, alumniID)
+	jobs, err := GetPekerjaanByAlumniID(ctx, db, alumniID)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +65,7 @@ func GetAlumniWithJobs(db *sql.DB, alumniID int) (*model.AlumniWithJobs, error)
 	}, nil
 }
 
-func CreateAlumniWithAuth(db *sql.DB, req model.CreateAlumniRequest, hashedPassword string) (*model.Alumni, error) {
+func CreateAlumniWithAuth(ctx context.Context, db *sql.DB, req model.CreateAlumniRequest, hashedPassword string) (*model.Alumni, error) {
 	var alumni model.Alumni
 
 	query := `INSERT INTO alumni (nim, nama, jurusan, angkatan, tahun_lulus, email, 
@@ -78,7 +79,7 @@ func CreateAlumniWithAuth(db *sql.DB, req model.CreateAlumniRequest, hashedPassw
 		role = "admin" // If user_id is provided, make them admin
 	}
 
-	err := db.QueryRow(query,
+	err := db.QueryRowContext(ctx, query,
 		req.NIM, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus,
 		req.Email, hashedPassword, role, req.NoTelepon, req.Alamat,
 	).Scan(
@@ -94,3 +95,18 @@ func CreateAlumniWithAuth(db *sql.DB, req model.CreateAlumniRequest, hashedPassw
 
 	return &alumni, nil
 }
+
+func GetUserByID(ctx context.Context, db *sql.DB, userID int) (*model.User, error) {
+	var user model.User
+
+	query := `SELECT id, username, email, role, created_at FROM users WHERE id = $1`
+
+	err := db.QueryRowContext(ctx, query, userID).Scan(
+		&user.ID, &user.Username, &user.Email, &user.Role, &user.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrCompanyInUse is returned when deleting a company that still has jobs.
+// Tautan yang dilepas akan dibuat ulang oleh InitCompanyDirectory saat
+// restart, sehingga company harus di-merge, bukan dihapus.
+var ErrCompanyInUse = errors.New("company still has linked jobs")
+
+// ErrCompanyKeyTaken is returned when a name or alias of the company is
+// already used by another company
+var ErrCompanyKeyTaken = errors.New("company name or alias already used")
+
+const companyColumns = `id, nama, aliases, bidang_industri, lokasi_kerja, website, keys, created_at, updated_at`
+
+func scanCompany(row rowScanner) (*model.Company, error) {
+	var company model.Company
+	if err := row.Scan(&company.ID, &company.Nama, pq.Array(&company.Aliases),
+		&company.BidangIndustri, &company.LokasiKerja, &company.Website, pq.Array(&company.Keys),
+		&company.CreatedAt, &company.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if company.Aliases == nil {
+		company.Aliases = []string{}
+	}
+	return &company, nil
+}
+
+// CreateCompany saves a new company
+func CreateCompany(ctx context.Context, db *sql.DB, company *model.Company) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCompanyKeys(ctx, tx, company); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	company.CreatedAt = now
+	company.UpdatedAt = now
+
+	err = tx.QueryRowContext(ctx, `INSERT INTO companies (nama, aliases, bidang_industri, lokasi_kerja, website, keys, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		company.Nama, pq.Array(company.Aliases), company.BidangIndustri, company.LokasiKerja,
+		company.Website, pq.Array(company.Keys), now, now).Scan(&company.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockCompanyKeys serializes company writes until tx ends and returns
+// ErrCompanyKeyTaken when another company already has one of the keys.
+// Keys berupa array sehingga tidak bisa dijaga dengan unique index biasa.
+func lockCompanyKeys(ctx context.Context, tx *sql.Tx, company *model.Company) error {
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE companies IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+	var taken bool
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM companies WHERE keys && $1 AND id <> $2)`,
+		pq.Array(company.Keys), company.ID).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrCompanyKeyTaken
+	}
+	return nil
+}
+
+// GetAllCompanies returns every company sorted by name
+func GetAllCompanies(ctx context.Context, db *sql.DB) ([]model.Company, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+companyColumns+` FROM companies ORDER BY nama, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	companies := []model.Company{}
+	for rows.Next() {
+		company, err := scanCompany(rows)
+		if err != nil {
+			return nil, err
+		}
+		companies = append(companies, *company)
+	}
+	return companies, rows.Err()
+}
+
+// GetCompanyByID returns sql.ErrNoRows when the company does not exist
+func GetCompanyByID(ctx context.Context, db *sql.DB, id int) (*model.Company, error) {
+	return scanCompany(db.QueryRowContext(ctx, `SELECT `+companyColumns+` FROM companies WHERE id = $1`, id))
+}
+
+// GetCompanyByKey finds the company whose normalized name or alias is key
+func GetCompanyByKey(ctx context.Context, db *sql.DB, key string) (*model.Company, error) {
+	return scanCompany(db.QueryRowContext(ctx, `SELECT `+companyColumns+` FROM companies WHERE keys @> ARRAY[$1]::text[] ORDER BY id LIMIT 1`, key))
+}
+
+// updateCompany saves the editable fields of a company dan menyalin namanya
+// ke pekerjaan yang tertaut
+func updateCompany(ctx context.Context, tx *sql.Tx, company *model.Company) error {
+	if err := lockCompanyKeys(ctx, tx, company); err != nil {
+		return err
+	}
+
+	company.UpdatedAt = time.Now()
+	result, err := tx.ExecContext(ctx, `UPDATE companies SET nama = $1, aliases = $2, bidang_industri = $3, lokasi_kerja = $4,
+		website = $5, keys = $6, updated_at = $7 WHERE id = $8`,
+		company.Nama, pq.Array(company.Aliases), company.BidangIndustri, company.LokasiKerja,
+		company.Website, pq.Array(company.Keys), company.UpdatedAt, company.ID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE pekerjaan_alumni SET nama_perusahaan = $1 WHERE company_id = $2`, company.Nama, company.ID)
+	return err
+}
+
+// UpdateCompany saves the editable fields of a company. Nama perusahaan pada
+// pekerjaan yang tertaut ikut diperbarui.
+func UpdateCompany(ctx context.Context, db *sql.DB, company *model.Company) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateCompany(ctx, tx, company); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteCompany removes a company without linked jobs. Pekerjaan di trash
+// ikut dihitung karena bisa direstore.
+func DeleteCompany(ctx context.Context, db *sql.DB, id int) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM companies c WHERE c.id = $1
+		AND NOT EXISTS (SELECT 1 FROM pekerjaan_alumni p WHERE p.company_id = c.id)`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	if _, err := GetCompanyByID(ctx, db, id); err != nil {
+		return err
+	}
+	return ErrCompanyInUse
+}
+
+// CountCompanyPekerjaan returns the number of jobs linked to a company,
+// termasuk yang ada di trash
+func CountCompanyPekerjaan(ctx context.Context, db *sql.DB, id int) (int, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM pekerjaan_alumni WHERE company_id = $1`, id).Scan(&count)
+	return count, err
+}
+
+// CountCompanyAlumni returns per company the number of distinct alumni whose
+// current job is there
+func CountCompanyAlumni(ctx context.Context, db *sql.DB) (map[int]int, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`SELECT p.company_id, COUNT(DISTINCT p.alumni_id)
+		FROM pekerjaan_alumni p
+		WHERE p.company_id IS NOT NULL AND p.deleted_at IS NULL AND %s
+		GROUP BY p.company_id`, currentJobCondition))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[int]int{}
+	for rows.Next() {
+		var id, count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		counts[id] = count
+	}
+	return counts, rows.Err()
+}
+
+// GetCompanyAlumni lists the alumni with a job at the company, pekerjaan
+// terbaru lebih dulu. currentOnly membatasi ke pekerjaan yang masih aktif.
+func GetCompanyAlumni(ctx context.Context, db *sql.DB, companyID int, currentOnly bool) ([]model.CompanyAlumni, error) {
+	condition := "TRUE"
+	if currentOnly {
+		condition = currentJobCondition
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`SELECT a.id, a.nama, a.jurusan, a.tahun_lulus, p.id, p.posisi_jabatan,
+		       p.status_pekerjaan, p.tanggal_mulai_kerja, p.tanggal_selesai_kerja
+		FROM pekerjaan_alumni p
+		JOIN alumni a ON a.id = p.alumni_id AND a.deleted_at IS NULL
+		WHERE p.company_id = $1 AND p.deleted_at IS NULL AND %s
+		ORDER BY p.tanggal_mulai_kerja DESC, p.id DESC`, condition), companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.CompanyAlumni{}
+	for rows.Next() {
+		var item model.CompanyAlumni
+		var tanggalMulai time.Time
+		var tanggalSelesai *time.Time
+		if err := rows.Scan(&item.AlumniID, &item.Nama, &item.Jurusan, &item.TahunLulus, &item.PekerjaanID,
+			&item.PosisiJabatan, &item.StatusPekerjaan, &tanggalMulai, &tanggalSelesai); err != nil {
+			return nil, err
+		}
+		item.TanggalMulaiKerja = model.Date{Time: tanggalMulai}
+		if tanggalSelesai != nil {
+			item.TanggalSelesaiKerja = &model.Date{Time: *tanggalSelesai}
+		}
+		list = append(list, item)
+	}
+	return list, rows.Err()
+}
+
+// MergeCompanies re-points the jobs of the source companies to target,
+// menyimpan alias target yang sudah digabung, lalu menghapus company sumber
+// dalam satu transaksi. Mengembalikan jumlah pekerjaan yang dipindahkan.
+func MergeCompanies(ctx context.Context, db *sql.DB, target *model.Company, sourceIDs []int) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE pekerjaan_alumni SET company_id = $1, nama_perusahaan = $2, updated_at = NOW()
+		WHERE company_id = ANY($3)`, target.ID, target.Nama, pq.Array(sourceIDs))
+	if err != nil {
+		return 0, err
+	}
+	moved, _ := result.RowsAffected()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM companies WHERE id = ANY($1)`, pq.Array(sourceIDs)); err != nil {
+		return 0, err
+	}
+	if err := updateCompany(ctx, tx, target); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+// GetPekerjaanWithoutCompany returns the jobs not linked to a company yet,
+// termasuk yang sudah di-soft delete. Hanya ID, nama perusahaan, bidang
+// industri dan lokasi kerja yang diisi.
+func GetPekerjaanWithoutCompany(ctx context.Context, db *sql.DB) ([]model.PekerjaanAlumni, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, nama_perusahaan, bidang_industri, lokasi_kerja
+		FROM pekerjaan_alumni WHERE company_id IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.PekerjaanAlumni
+	for rows.Next() {
+		var p model.PekerjaanAlumni
+		if err := rows.Scan(&p.ID, &p.NamaPerusahaan, &p.BidangIndustri, &p.LokasiKerja); err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+// SetPekerjaanCompany links a job to a company
+func SetPekerjaanCompany(ctx context.Context, db *sql.DB, pekerjaanID, companyID int) error {
+	_, err := db.ExecContext(ctx, `UPDATE pekerjaan_alumni SET company_id = $1 WHERE id = $2`, companyID, pekerjaanID)
+	return err
+}
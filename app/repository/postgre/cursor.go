@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidCursor is returned for a cursor that cannot be decoded or was
+// issued for a different sort
+var ErrInvalidCursor = errors.New("cursor tidak valid")
+
+// sortKind is the type of a sortable column, dipakai untuk decode nilai cursor
+type sortKind int
+
+const (
+	sortString sortKind = iota
+	sortInt
+	sortTime
+)
+
+// cursorToken is the content of an opaque pagination cursor. Token berisi
+// nilai sort dan id baris pertama/terakhir pada halaman.
+type cursorToken struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Value  string `json:"v"`
+	ID     string `json:"id"`
+	Prev   bool   `json:"p,omitempty"` // true untuk cursor ke halaman sebelumnya
+}
+
+func encodeCursor(t cursorToken) string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor decodes a cursor and checks it belongs to the requested sort
+func decodeCursor(s, sortBy, order string) (*cursorToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var t cursorToken
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if t.SortBy != sortBy || t.Order != order {
+		return nil, ErrInvalidCursor
+	}
+	return &t, nil
+}
+
+// sortValue converts the cursor value back to the field type
+func (t cursorToken) sortValue(kind sortKind) (interface{}, error) {
+	switch kind {
+	case sortInt:
+		v, err := strconv.Atoi(t.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return v, nil
+	case sortTime:
+		v, err := time.Parse(time.RFC3339Nano, t.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return v, nil
+	default:
+		return t.Value, nil
+	}
+}
+
+func formatSortValue(v interface{}) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
+
+// keysetCondition selects rows after (column, id) in the scan direction
+func keysetCondition(column string, argIndex int, ascending bool) string {
+	op := ">"
+	if !ascending {
+		op = "<"
+	}
+	return fmt.Sprintf("(%s, id) %s ($%d, $%d)", column, op, argIndex, argIndex+1)
+}
+
+// tokenID parses the id of a cursor
+func (t cursorToken) tokenID() (int, error) {
+	id, err := strconv.Atoi(t.ID)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// keysetPage trims the extra lookahead item, restores display order and
+// builds the next/prev cursors. Query mengambil limit+1 baris untuk tahu
+// apakah masih ada halaman berikutnya.
+func keysetPage[T any](items []T, limit int, hasCursor, backward bool, cursorOf func(item T, prev bool) string) ([]T, model.CursorPage) {
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	var page model.CursorPage
+	if len(items) == 0 {
+		return items, page
+	}
+
+	first, last := items[0], items[len(items)-1]
+	if backward {
+		page.Next = cursorOf(last, false)
+		if hasMore {
+			page.Prev = cursorOf(first, true)
+		}
+	} else {
+		if hasMore {
+			page.Next = cursorOf(last, false)
+		}
+		if hasCursor {
+			page.Prev = cursorOf(first, true)
+		}
+	}
+	return items, page
+}
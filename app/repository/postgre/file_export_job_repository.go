@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const fileExportJobColumns = `id, status, requested_by, filter, file_count, total_bytes, artifact_path, artifact_size,
+	error, started_at, finished_at, expires_at, created_at, updated_at`
+
+func scanFileExportJob(row rowScanner) (*model.FileExportJob, error) {
+	var job model.FileExportJob
+	var filter []byte
+	err := row.Scan(&job.ID, &job.Status, &job.RequestedBy, &filter, &job.FileCount, &job.TotalBytes,
+		&job.ArtifactPath, &job.ArtifactSize, &job.Error, &job.StartedAt, &job.FinishedAt, &job.ExpiresAt,
+		&job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filter, &job.Filter); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CreateFileExportJob saves a new export job
+func CreateFileExportJob(ctx context.Context, db *sql.DB, job *model.FileExportJob) error {
+	filter, err := json.Marshal(job.Filter)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO file_export_jobs (id, status, requested_by, filter, file_count, total_bytes)
+	          VALUES ($1, $2, $3, $4, $5, $6)
+	          RETURNING created_at, updated_at`
+
+	return db.QueryRowContext(ctx, query, job.ID, job.Status, job.RequestedBy, filter, job.FileCount, job.TotalBytes).
+		Scan(&job.CreatedAt, &job.UpdatedAt)
+}
+
+// GetFileExportJobByID retrieves an export job
+func GetFileExportJobByID(ctx context.Context, db *sql.DB, id string) (*model.FileExportJob, error) {
+	query := `SELECT ` + fileExportJobColumns + ` FROM file_export_jobs WHERE id::text = $1`
+	return scanFileExportJob(db.QueryRowContext(ctx, query, id))
+}
+
+// UpdateFileExportJob stores the progress or outcome of an export job
+func UpdateFileExportJob(ctx context.Context, db *sql.DB, job *model.FileExportJob) error {
+	return db.QueryRowContext(ctx, `UPDATE file_export_jobs SET status = $1, artifact_path = $2, artifact_size = $3, error = $4,
+	                    started_at = $5, finished_at = $6, expires_at = $7, updated_at = NOW()
+	                    WHERE id = $8 RETURNING updated_at`,
+		job.Status, job.ArtifactPath, job.ArtifactSize, job.Error, job.StartedAt, job.FinishedAt, job.ExpiresAt, job.ID,
+	).Scan(&job.UpdatedAt)
+}
+
+// GetExpiredFileExportJobs retrieves finished jobs whose artifact expired
+func GetExpiredFileExportJobs(ctx context.Context, db *sql.DB, now time.Time) ([]model.FileExportJob, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+fileExportJobColumns+` FROM file_export_jobs WHERE expires_at < $1`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []model.FileExportJob
+	for rows.Next() {
+		job, err := scanFileExportJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+// DeleteFileExportJob removes an export job record
+func DeleteFileExportJob(ctx context.Context, db *sql.DB, id string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM file_export_jobs WHERE id = $1`, id)
+	return err
+}
+
+// FailUnfinishedFileExportJobs marks queued or running jobs as failed. Dipakai
+// saat start, karena job yang terputus oleh restart tidak akan dilanjutkan.
+func FailUnfinishedFileExportJobs(ctx context.Context, db *sql.DB, reason string, expiresAt time.Time) (int, error) {
+	result, err := db.ExecContext(ctx, `UPDATE file_export_jobs SET status = $1, error = $2, finished_at = NOW(),
+	                        expires_at = $3, updated_at = NOW() WHERE status IN ($4, $5)`,
+		model.ExportJobFailed, reason, expiresAt, model.ExportJobQueued, model.ExportJobRunning)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,514 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const fileColumns = `id, user_id, owner_type, file_name, original_name, file_path, file_size, file_type, category,
+	uploaded_at, uploaded_by, uploader_type, scan_status, scan_signature, scanner_version, scanned_at,
+	verification_status, rejection_reason, verified_by, verified_at, version, superseded_at, superseded_by,
+	created_at, updated_at, deleted_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanFile(row rowScanner) (*model.File, error) {
+	var file model.File
+	var verificationStatus sql.NullString
+	err := row.Scan(
+		&file.ID, &file.UserID, &file.OwnerType, &file.FileName, &file.OriginalName, &file.FilePath,
+		&file.FileSize, &file.FileType, &file.Category, &file.UploadedAt, &file.UploadedBy,
+		&file.UploaderType, &file.ScanStatus, &file.ScanSignature, &file.ScannerVersion, &file.ScannedAt,
+		&verificationStatus, &file.RejectionReason, &file.VerifiedBy, &file.VerifiedAt,
+		&file.Version, &file.SupersededAt, &file.SupersededBy,
+		&file.CreatedAt, &file.UpdatedAt, &file.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	file.VerificationStatus = verificationStatus.String
+	return &file, nil
+}
+
+func queryFiles(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]model.File, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []model.File
+	for rows.Next() {
+		file, err := scanFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, *file)
+	}
+	return files, rows.Err()
+}
+
+// CreateFile saves file metadata to database
+func CreateFile(ctx context.Context, db *sql.DB, file *model.File) error {
+	query := `INSERT INTO files (user_id, owner_type, file_name, original_name, file_path, file_size, file_type,
+	          category, uploaded_at, uploaded_by, uploader_type, scan_status, scan_signature, scanner_version, scanned_at,
+	          verification_status, version, superseded_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, ''), $17, $18)
+	          RETURNING id, created_at, updated_at`
+
+	return db.QueryRowContext(ctx, query,
+		file.UserID, file.OwnerType, file.FileName, file.OriginalName, file.FilePath, file.FileSize, file.FileType,
+		file.Category, file.UploadedAt, file.UploadedBy, file.UploaderType, file.ScanStatus, file.ScanSignature,
+		file.ScannerVersion, file.ScannedAt, file.VerificationStatus, file.Version, file.SupersededAt,
+	).Scan(&file.ID, &file.CreatedAt, &file.UpdatedAt)
+}
+
+// GetFilesByOwner retrieves the current versions of a user's or alumni's
+// files, newest first
+func GetFilesByOwner(ctx context.Context, db *sql.DB, ownerType string, ownerID int, category string) ([]model.File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files
+	          WHERE owner_type = $1 AND user_id = $2 AND category = $3 AND deleted_at IS NULL
+	          AND superseded_at IS NULL
+	          ORDER BY uploaded_at DESC`
+	return queryFiles(ctx, db, query, ownerType, ownerID, category)
+}
+
+// GetFileVersions retrieves every version of an owner's category, current
+// and superseded, highest version first
+func GetFileVersions(ctx context.Context, db *sql.DB, ownerType string, ownerID int, category string) ([]model.File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files
+	          WHERE owner_type = $1 AND user_id = $2 AND category = $3 AND deleted_at IS NULL
+	          ORDER BY version DESC, uploaded_at DESC`
+	return queryFiles(ctx, db, query, ownerType, ownerID, category)
+}
+
+// NextFileVersion returns the version number for a new upload. Versi yang
+// sudah dihapus ikut dihitung agar nomor tidak dipakai ulang.
+func NextFileVersion(ctx context.Context, db *sql.DB, ownerType string, ownerID int, category string) (int, error) {
+	var version int
+	err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) + 1 FROM files
+	                    WHERE owner_type = $1 AND user_id = $2 AND category = $3`, ownerType, ownerID, category).
+		Scan(&version)
+	return version, err
+}
+
+// SupersedeFileVersions marks the other current versions of file's owner and
+// category as superseded by file. Versi yang di-upload setelah file tidak
+// disentuh, sehingga dua upload bersamaan tetap menyisakan satu versi aktif.
+func SupersedeFileVersions(ctx context.Context, db *sql.DB, file *model.File) error {
+	_, err := db.ExecContext(ctx, `UPDATE files SET superseded_at = NOW(), superseded_by = $1, updated_at = NOW()
+	                   WHERE owner_type = $2 AND user_id = $3 AND category = $4 AND id <> $1
+	                   AND uploaded_at <= $5 AND superseded_at IS NULL`,
+		file.ID, file.OwnerType, file.UserID, file.Category, file.UploadedAt)
+	return err
+}
+
+// RestoreFileVersion makes file the current version again and supersedes
+// every other current version of the same owner and category
+func RestoreFileVersion(ctx context.Context, db *sql.DB, file *model.File) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `UPDATE files SET superseded_at = NOW(), superseded_by = $1, updated_at = NOW()
+	                  WHERE owner_type = $2 AND user_id = $3 AND category = $4 AND id <> $1
+	                  AND superseded_at IS NULL`,
+		file.ID, file.OwnerType, file.UserID, file.Category)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE files SET superseded_at = NULL, superseded_by = NULL, updated_at = NOW()
+	                        WHERE id = $1 AND deleted_at IS NULL`, file.ID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	file.SupersededAt = nil
+	file.SupersededBy = nil
+	return nil
+}
+
+// GetFileByID retrieves a specific file by ID
+func GetFileByID(ctx context.Context, db *sql.DB, id int) (*model.File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files WHERE id = $1 AND deleted_at IS NULL`
+	return scanFile(db.QueryRowContext(ctx, query, id))
+}
+
+// DeleteFile performs soft delete on file
+func DeleteFile(ctx context.Context, db *sql.DB, id int) error {
+	result, err := db.ExecContext(ctx, `UPDATE files SET deleted_at = NOW(), updated_at = NOW()
+	                        WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetAllFilesByCategory retrieves all files of a specific category (admin only)
+func GetAllFilesByCategory(ctx context.Context, db *sql.DB, category string) ([]model.File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files
+	          WHERE category = $1 AND deleted_at IS NULL ORDER BY uploaded_at DESC`
+	return queryFiles(ctx, db, query, category)
+}
+
+// UpdateFileScanResult stores the outcome of a malware scan
+func UpdateFileScanResult(ctx context.Context, db *sql.DB, id int, status string, signature *string, scannerVersion string, filePath string) error {
+	_, err := db.ExecContext(ctx, `UPDATE files SET scan_status = $1, scan_signature = $2, scanner_version = $3,
+	                   scanned_at = NOW(), file_path = $4, updated_at = NOW() WHERE id = $5`,
+		status, signature, scannerVersion, filePath, id)
+	return err
+}
+
+// GetFilesForRescan retrieves active files that were not scanned with the
+// given signature version or whose previous scan did not complete
+func GetFilesForRescan(ctx context.Context, db *sql.DB, scannerVersion string) ([]model.File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files
+	          WHERE deleted_at IS NULL AND scan_status <> $1
+	          AND (scan_status = $2 OR scanner_version <> $3)`
+	return queryFiles(ctx, db, query, model.ScanStatusQuarantined, model.ScanStatusPending, scannerVersion)
+}
+
+// GetFileUsageByOwner returns file count and total bytes per category for a user or alumni
+func GetFileUsageByOwner(ctx context.Context, db *sql.DB, ownerType string, ownerID int) ([]model.CategoryUsage, error) {
+	rows, err := db.QueryContext(ctx, `SELECT category, COUNT(*) FILTER (WHERE superseded_at IS NULL), COALESCE(SUM(file_size), 0) FROM files
+	                       WHERE owner_type = $1 AND user_id = $2 AND deleted_at IS NULL GROUP BY category`, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []model.CategoryUsage
+	for rows.Next() {
+		var u model.CategoryUsage
+		if err := rows.Scan(&u.Category, &u.FileCount, &u.TotalBytes); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
+
+// GetFileUsageForCategory returns file count and total bytes of one category for a user or alumni.
+// Versi lama tidak dihitung sebagai file, tetapi ukurannya tetap masuk total
+// byte karena masih memakai storage.
+func GetFileUsageForCategory(ctx context.Context, db *sql.DB, ownerType string, ownerID int, category string) (*model.CategoryUsage, error) {
+	usage := model.CategoryUsage{Category: category}
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FILTER (WHERE superseded_at IS NULL), COALESCE(SUM(file_size), 0) FROM files
+	                    WHERE owner_type = $1 AND user_id = $2 AND category = $3 AND deleted_at IS NULL`, ownerType, ownerID, category).
+		Scan(&usage.FileCount, &usage.TotalBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// GetFileUsageAllOwners returns usage per owner and category for every user
+// and alumni with files
+func GetFileUsageAllOwners(ctx context.Context, db *sql.DB) ([]model.UserFileUsage, error) {
+	rows, err := db.QueryContext(ctx, `SELECT owner_type, user_id, category, COUNT(*) FILTER (WHERE superseded_at IS NULL), COALESCE(SUM(file_size), 0) FROM files
+	                       WHERE deleted_at IS NULL GROUP BY owner_type, user_id, category
+	                       ORDER BY owner_type, user_id, category`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []model.UserFileUsage
+	for rows.Next() {
+		var ownerType string
+		var ownerID int
+		var u model.CategoryUsage
+		if err := rows.Scan(&ownerType, &ownerID, &u.Category, &u.FileCount, &u.TotalBytes); err != nil {
+			return nil, err
+		}
+		n := len(usage)
+		if n == 0 || usage[n-1].UserID != ownerID || usage[n-1].OwnerType != ownerType {
+			usage = append(usage, model.UserFileUsage{UserID: ownerID, OwnerType: ownerType})
+			n++
+		}
+		usage[n-1].Categories = append(usage[n-1].Categories, u)
+	}
+	return usage, rows.Err()
+}
+
+// GetFilesDeletedBefore retrieves files soft-deleted before the cutoff
+func GetFilesDeletedBefore(ctx context.Context, db *sql.DB, cutoff time.Time) ([]model.File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+	return queryFiles(ctx, db, query, cutoff)
+}
+
+// GetAllFilesIncludingDeleted retrieves every file record, active or soft-deleted
+func GetAllFilesIncludingDeleted(ctx context.Context, db *sql.DB) ([]model.File, error) {
+	return queryFiles(ctx, db, `SELECT `+fileColumns+` FROM files`)
+}
+
+// HardDeleteFile permanently removes a file record
+func HardDeleteFile(ctx context.Context, db *sql.DB, id int) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM files WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetCertificatesForReview retrieves certificates for the verifier queue
+func GetCertificatesForReview(ctx context.Context, db *sql.DB, filter model.CertificateReviewFilter, params model.PaginationParams) ([]model.File, int, error) {
+	whereClause := "WHERE category = 'certificate' AND deleted_at IS NULL AND scan_status <> $1"
+	args := []interface{}{model.ScanStatusQuarantined}
+	argIndex := 2
+
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND verification_status = $%d", argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.Status == model.VerificationPending {
+		// Versi lama yang sudah digantikan tidak perlu direview
+		whereClause += " AND superseded_at IS NULL"
+	}
+	if filter.OwnerType != "" {
+		whereClause += fmt.Sprintf(" AND owner_type = $%d", argIndex)
+		args = append(args, filter.OwnerType)
+		argIndex++
+	}
+	if filter.UploadedFrom != nil {
+		whereClause += fmt.Sprintf(" AND uploaded_at >= $%d", argIndex)
+		args = append(args, *filter.UploadedFrom)
+		argIndex++
+	}
+	if filter.UploadedTo != nil {
+		whereClause += fmt.Sprintf(" AND uploaded_at <= $%d", argIndex)
+		args = append(args, *filter.UploadedTo)
+		argIndex++
+	}
+	if params.Search != "" {
+		whereClause += fmt.Sprintf(" AND original_name ILIKE $%d", argIndex)
+		args = append(args, "%"+escapeLike(params.Search)+"%")
+		argIndex++
+	}
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM files "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	validSortColumns := map[string]bool{
+		"uploaded_at": true, "created_at": true, "original_name": true, "file_size": true, "verified_at": true,
+	}
+	sortBy := params.SortBy
+	if !validSortColumns[sortBy] {
+		sortBy = "uploaded_at"
+	}
+	order := "ASC"
+	if strings.ToLower(params.Order) == "desc" {
+		order = "DESC"
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM files %s ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d`,
+		fileColumns, whereClause, sortBy, order, order, argIndex, argIndex+1)
+	args = append(args, params.Limit, (params.Page-1)*params.Limit)
+
+	files, err := queryFiles(ctx, db, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return files, total, nil
+}
+
+// UpdateFileVerification stores a verifier decision. The update only applies
+// while the certificate is still in expectedStatus, so two reviewers cannot
+// both decide the same certificate.
+func UpdateFileVerification(ctx context.Context, db *sql.DB, id int, expectedStatus, status string, reason *string, verifiedBy int) (*model.File, error) {
+	query := `UPDATE files SET verification_status = $1, rejection_reason = $2, verified_by = $3,
+	          verified_at = NOW(), updated_at = NOW()
+	          WHERE id = $4 AND deleted_at IS NULL AND verification_status = $5
+	          RETURNING ` + fileColumns
+	return scanFile(db.QueryRowContext(ctx, query, status, reason, verifiedBy, id, expectedStatus))
+}
+
+// CountApprovedCertificates counts approved current certificates of an owner
+func CountApprovedCertificates(ctx context.Context, db *sql.DB, ownerType string, ownerID int) (int, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM files WHERE owner_type = $1 AND user_id = $2
+	                    AND category = 'certificate' AND verification_status = $3 AND deleted_at IS NULL
+	                    AND superseded_at IS NULL`,
+		ownerType, ownerID, model.VerificationApproved).Scan(&count)
+	return count, err
+}
+
+// adminFileWhere builds the WHERE clause of the admin file browser and
+// returns it with its arguments
+func adminFileWhere(filter model.FileAdminFilter, search string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	switch filter.Deleted {
+	case model.FileDeletedOnly:
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	case model.FileDeletedInclude:
+	default:
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	if filter.CurrentOnly {
+		conditions = append(conditions, "superseded_at IS NULL")
+	}
+	if filter.Category != "" {
+		add("category = $%d", filter.Category)
+	}
+	if filter.OwnerType != "" {
+		add("owner_type = $%d", filter.OwnerType)
+	}
+	if filter.OwnerID != 0 {
+		add("user_id = $%d", filter.OwnerID)
+	}
+	if filter.UploaderType != "" {
+		add("uploader_type = $%d", filter.UploaderType)
+	}
+	if filter.UploadedBy != 0 {
+		add("uploaded_by = $%d", filter.UploadedBy)
+	}
+
+	if prefix, ok := strings.CutSuffix(filter.FileType, "/*"); ok {
+		add("file_type ILIKE $%d", escapeLike(prefix+"/")+"%")
+	} else if filter.FileType != "" {
+		add("LOWER(file_type) = LOWER($%d)", filter.FileType)
+	}
+
+	if filter.MinSize != nil {
+		add("file_size >= $%d", *filter.MinSize)
+	}
+	if filter.MaxSize != nil {
+		add("file_size <= $%d", *filter.MaxSize)
+	}
+	if filter.UploadedFrom != nil {
+		add("uploaded_at >= $%d", *filter.UploadedFrom)
+	}
+	if filter.UploadedTo != nil {
+		add("uploaded_at <= $%d", *filter.UploadedTo)
+	}
+	if search != "" {
+		add("original_name ILIKE $%d", "%"+escapeLike(search)+"%")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// escapeLike escapes the LIKE wildcards in s
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// GetFilesForAdmin retrieves one page of the admin file browser
+func GetFilesForAdmin(ctx context.Context, db *sql.DB, filter model.FileAdminFilter, params model.PaginationParams) ([]model.File, int, error) {
+	whereClause, args := adminFileWhere(filter, params.Search)
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM files "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	validSortColumns := map[string]bool{
+		"uploaded_at": true, "created_at": true, "updated_at": true, "deleted_at": true,
+		"original_name": true, "file_size": true, "file_type": true, "category": true,
+	}
+	sortBy := params.SortBy
+	if !validSortColumns[sortBy] {
+		sortBy = "uploaded_at"
+	}
+	order := "DESC"
+	if strings.ToLower(params.Order) == "asc" {
+		order = "ASC"
+	}
+
+	argIndex := len(args) + 1
+	query := fmt.Sprintf(`SELECT %s FROM files %s ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d`,
+		fileColumns, whereClause, sortBy, order, order, argIndex, argIndex+1)
+	args = append(args, params.Limit, (params.Page-1)*params.Limit)
+
+	files, err := queryFiles(ctx, db, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return files, total, nil
+}
+
+// GetFileTotalsByCategory returns count and bytes per category of every file
+// matching the admin browser filter, not only the current page
+func GetFileTotalsByCategory(ctx context.Context, db *sql.DB, filter model.FileAdminFilter, search string) ([]model.CategoryTotal, error) {
+	whereClause, args := adminFileWhere(filter, search)
+
+	rows, err := db.QueryContext(ctx, `SELECT category, COUNT(*), COALESCE(SUM(file_size), 0) FROM files `+
+		whereClause+` GROUP BY category ORDER BY category`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []model.CategoryTotal{}
+	for rows.Next() {
+		var t model.CategoryTotal
+		if err := rows.Scan(&t.Category, &t.FileCount, &t.TotalBytes); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// GetExportableFiles retrieves the current, scanned versions of the files of
+// the given owners. Category kosong berarti semua kategori.
+func GetExportableFiles(ctx context.Context, db *sql.DB, ownerType string, ownerIDs []int, category string) ([]model.File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files
+	          WHERE owner_type = $1 AND user_id = ANY($2) AND deleted_at IS NULL AND superseded_at IS NULL
+	          AND scan_status NOT IN ($3, $4) AND ($5 = '' OR category = $5)
+	          ORDER BY user_id, category, uploaded_at`
+	return queryFiles(ctx, db, query, ownerType, pq.Array(ownerIDs), model.ScanStatusQuarantined, model.ScanStatusPending, category)
+}
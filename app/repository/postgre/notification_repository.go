@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+)
+
+// CreateNotification saves a notification
+func CreateNotification(ctx context.Context, db *sql.DB, notification *model.Notification) error {
+	query := `INSERT INTO notifications (owner_type, owner_id, type, title, message, file_id)
+	          VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		notification.OwnerType, notification.OwnerID, notification.Type,
+		notification.Title, notification.Message, notification.FileID,
+	).Scan(&notification.ID, &notification.CreatedAt)
+}
+
+// GetNotificationsByOwner retrieves notifications of a user or alumni, newest first
+func GetNotificationsByOwner(ctx context.Context, db *sql.DB, ownerType string, ownerID int, unreadOnly bool) ([]model.Notification, error) {
+	query := `SELECT id, owner_type, owner_id, type, title, message, file_id, read_at, created_at
+	          FROM notifications WHERE owner_type = $1 AND owner_id = $2`
+	if unreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC LIMIT 100`
+
+	rows, err := db.QueryContext(ctx, query, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []model.Notification{}
+	for rows.Next() {
+		var n model.Notification
+		if err := rows.Scan(&n.ID, &n.OwnerType, &n.OwnerID, &n.Type, &n.Title, &n.Message,
+			&n.FileID, &n.ReadAt, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+// MarkNotificationRead marks a notification of the given owner as read
+func MarkNotificationRead(ctx context.Context, db *sql.DB, id int, ownerType string, ownerID int) error {
+	result, err := db.ExecContext(ctx, `UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+	                        WHERE id = $1 AND owner_type = $2 AND owner_id = $3`, id, ownerType, ownerID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
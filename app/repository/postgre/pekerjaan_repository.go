@@ -2,30 +2,120 @@ package repository
 
 import (
 	"clean-arch/app/model/postgre"
+	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
 
-func GetAllPekerjaanWithPagination(db *sql.DB, params model.PaginationParams) ([]model.PekerjaanAlumni, int, error) {
-	// Build WHERE clause for search
+// pekerjaanSortColumns lists the sortable job columns
+var pekerjaanSortColumns = map[string]sortKind{
+	"id": sortInt, "nama_perusahaan": sortString, "posisi_jabatan": sortString, "bidang_industri": sortString,
+	"lokasi_kerja": sortString, "status_pekerjaan": sortString, "tanggal_mulai_kerja": sortTime, "created_at": sortTime,
+}
+
+func pekerjaanSortValue(p model.PekerjaanAlumni, sortBy string) interface{} {
+	switch sortBy {
+	case "id":
+		return p.ID
+	case "nama_perusahaan":
+		return p.NamaPerusahaan
+	case "posisi_jabatan":
+		return p.PosisiJabatan
+	case "bidang_industri":
+		return p.BidangIndustri
+	case "lokasi_kerja":
+		return p.LokasiKerja
+	case "status_pekerjaan":
+		return p.StatusPekerjaan
+	case "tanggal_mulai_kerja":
+		return p.TanggalMulaiKerja.Time
+	default:
+		return p.CreatedAt
+	}
+}
+
+// pekerjaanListWhere builds the search filter of the job list
+func pekerjaanListWhere(params model.PaginationParams) (string, []interface{}, int) {
 	whereClause := "WHERE deleted_at IS NULL"
 	args := []interface{}{}
 	argIndex := 1
 
 	if params.Search != "" {
 		whereClause += " AND (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1 OR bidang_industri ILIKE $1 OR lokasi_kerja ILIKE $1 OR status_pekerjaan ILIKE $1)"
-		args = append(args, "%"+params.Search+"%")
+		args = append(args, "%"+escapeLike(params.Search)+"%")
 		argIndex++
 	}
 
-	// Validate and set sort column
-	validSortColumns := map[string]bool{
-		"id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true,
-		"lokasi_kerja": true, "status_pekerjaan": true, "tanggal_mulai_kerja": true, "created_at": true,
+	return whereClause, args, argIndex
+}
+
+const pekerjaanListColumns = `id, alumni_id, company_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
+		       gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
+		       deskripsi_pekerjaan, deleted_at, deleted_by, created_at, updated_at`
+
+func scanPekerjaanRows(rows *sql.Rows) ([]model.PekerjaanAlumni, error) {
+	pekerjaanList := []model.PekerjaanAlumni{}
+	for rows.Next() {
+		var pekerjaan model.PekerjaanAlumni
+		var tanggalMulai time.Time
+		var tanggalSelesai *time.Time
+		var gaji nullGaji
+
+		err := rows.Scan(
+			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.CompanyID, &pekerjaan.NamaPerusahaan,
+			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
+			&pekerjaan.GajiRange, &gaji.Min, &gaji.Max, &gaji.Currency, &gaji.Period,
+			&tanggalMulai, &tanggalSelesai,
+			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
+			&pekerjaan.DeletedAt, &pekerjaan.DeletedBy,
+			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		pekerjaan.TanggalMulaiKerja = model.Date{Time: tanggalMulai}
+		pekerjaan.Gaji = gaji.value()
+		if tanggalSelesai != nil {
+			pekerjaan.TanggalSelesaiKerja = &model.Date{Time: *tanggalSelesai}
+		}
+
+		pekerjaanList = append(pekerjaanList, pekerjaan)
+	}
+	return pekerjaanList, rows.Err()
+}
+
+// nullGaji scans the structured salary columns, semua NULL berarti belum diisi
+type nullGaji struct {
+	Min, Max         sql.NullInt64
+	Currency, Period sql.NullString
+}
+
+func (g nullGaji) value() *model.Gaji {
+	if !g.Max.Valid {
+		return nil
+	}
+	return &model.Gaji{Min: g.Min.Int64, Max: g.Max.Int64, Currency: g.Currency.String, Period: g.Period.String}
+}
+
+// gajiArgs returns the salary column values of an insert or update
+func gajiArgs(g *model.Gaji) (interface{}, interface{}, interface{}, interface{}) {
+	if g == nil {
+		return nil, nil, nil, nil
 	}
-	if !validSortColumns[params.SortBy] {
+	return g.Min, g.Max, g.Currency, g.Period
+}
+
+func GetAllPekerjaanWithPagination(ctx context.Context, db *sql.DB, params model.PaginationParams) ([]model.PekerjaanAlumni, int, error) {
+	// Build WHERE clause for search
+	whereClause, args, argIndex := pekerjaanListWhere(params)
+
+	// Validate and set sort column
+	if _, ok := pekerjaanSortColumns[params.SortBy]; !ok {
 		params.SortBy = "created_at"
 	}
 
@@ -35,68 +125,125 @@ func GetAllPekerjaanWithPagination(db *sql.DB, params model.PaginationParams) ([
 	}
 
 	// Get total count for pagination
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM pekerjaan_alumni %s", whereClause)
-	var total int
-	err := db.QueryRow(countQuery, args...).Scan(&total)
-	if err != nil {
-		return nil, 0, err
+	total := -1
+	if !params.SkipCount {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM pekerjaan_alumni %s", whereClause)
+		if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
 	}
 
-	// Build main query with pagination
+	// Build main query with pagination, id sebagai tie-breaker agar urutan stabil
 	offset := (params.Page - 1) * params.Limit
 	query := fmt.Sprintf(`
-		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
-		       gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
-		       deskripsi_pekerjaan, deleted_at, deleted_by, created_at, updated_at 
+		SELECT %s 
 		FROM pekerjaan_alumni %s 
-		ORDER BY %s %s 
+		ORDER BY %s %s, id %s 
 		LIMIT $%d OFFSET $%d`,
-		whereClause, params.SortBy, params.Order, argIndex, argIndex+1)
+		pekerjaanListColumns, whereClause, params.SortBy, params.Order, params.Order, argIndex, argIndex+1)
 
 	args = append(args, params.Limit, offset)
 
-	rows, err := db.Query(query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer rows.Close()
 
-	var pekerjaanList []model.PekerjaanAlumni
-	for rows.Next() {
-		var pekerjaan model.PekerjaanAlumni
-		var tanggalMulai time.Time
-		var tanggalSelesai *time.Time
+	pekerjaanList, err := scanPekerjaanRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		err := rows.Scan(
-			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
-			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-			&pekerjaan.GajiRange, &tanggalMulai, &tanggalSelesai,
-			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
-			&pekerjaan.DeletedAt, &pekerjaan.DeletedBy,
-			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-		)
-		if err != nil {
-			return nil, 0, err
+	return pekerjaanList, total, nil
+}
+
+// GetAllPekerjaanByCursor retrieves one page of jobs with keyset pagination.
+// Total bernilai -1 bila params.SkipCount.
+func GetAllPekerjaanByCursor(ctx context.Context, db *sql.DB, params model.PaginationParams) ([]model.PekerjaanAlumni, int, model.CursorPage, error) {
+	kind, ok := pekerjaanSortColumns[params.SortBy]
+	if !ok {
+		params.SortBy, kind = "created_at", sortTime
+	}
+	params.Order = strings.ToLower(params.Order)
+	if params.Order != "desc" {
+		params.Order = "asc"
+	}
+
+	var token *cursorToken
+	var err error
+	if params.Cursor != "" {
+		if token, err = decodeCursor(params.Cursor, params.SortBy, params.Order); err != nil {
+			return nil, 0, model.CursorPage{}, err
 		}
+	}
 
-		pekerjaan.TanggalMulaiKerja = model.Date{Time: tanggalMulai}
-		if tanggalSelesai != nil {
-			pekerjaan.TanggalSelesaiKerja = &model.Date{Time: *tanggalSelesai}
+	whereClause, args, argIndex := pekerjaanListWhere(params)
+
+	total := -1
+	if !params.SkipCount {
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pekerjaan_alumni "+whereClause, args...).Scan(&total); err != nil {
+			return nil, 0, model.CursorPage{}, err
 		}
+	}
 
-		pekerjaanList = append(pekerjaanList, pekerjaan)
+	// Cursor prev dibaca dengan arah sort terbalik lalu hasilnya dibalik lagi
+	backward := token != nil && token.Prev
+	ascending := (params.Order == "asc") != backward
+	if token != nil {
+		value, err := token.sortValue(kind)
+		if err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+		id, err := token.tokenID()
+		if err != nil {
+			return nil, 0, model.CursorPage{}, err
+		}
+		whereClause += " AND " + keysetCondition(params.SortBy, argIndex, ascending)
+		args = append(args, value, id)
+		argIndex += 2
 	}
 
-	return pekerjaanList, total, nil
+	direction := "ASC"
+	if !ascending {
+		direction = "DESC"
+	}
+	query := fmt.Sprintf("SELECT %s FROM pekerjaan_alumni %s ORDER BY %s %s, id %s LIMIT $%d",
+		pekerjaanListColumns, whereClause, params.SortBy, direction, direction, argIndex)
+	args = append(args, params.Limit+1)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+	defer rows.Close()
+
+	pekerjaanList, err := scanPekerjaanRows(rows)
+	if err != nil {
+		return nil, 0, model.CursorPage{}, err
+	}
+
+	pekerjaanList, page := keysetPage(pekerjaanList, params.Limit, token != nil, backward, func(p model.PekerjaanAlumni, prev bool) string {
+		return encodeCursor(cursorToken{
+			SortBy: params.SortBy,
+			Order:  params.Order,
+			Value:  formatSortValue(pekerjaanSortValue(p, params.SortBy)),
+			ID:     strconv.Itoa(p.ID),
+			Prev:   prev,
+		})
+	})
+
+	return pekerjaanList, total, page, nil
 }
 
-func GetAllPekerjaan(db *sql.DB) ([]model.PekerjaanAlumni, error) {
-	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
-	          gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
+func GetAllPekerjaan(ctx context.Context, db *sql.DB) ([]model.PekerjaanAlumni, error) {
+	query := `SELECT id, alumni_id, company_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
+	          gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+	          tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
 	          deskripsi_pekerjaan, deleted_at, deleted_by, created_at, updated_at 
 	          FROM pekerjaan_alumni WHERE deleted_at IS NULL ORDER BY created_at DESC`
 
-	rows, err := db.Query(query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -107,11 +254,13 @@ func GetAllPekerjaan(db *sql.DB) ([]model.PekerjaanAlumni, error) {
 		var pekerjaan model.PekerjaanAlumni
 		var tanggalMulai time.Time
 		var tanggalSelesai *time.Time
+		var gaji nullGaji
 
 		err := rows.Scan(
-			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.CompanyID, &pekerjaan.NamaPerusahaan,
 			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-			&pekerjaan.GajiRange, &tanggalMulai, &tanggalSelesai,
+			&pekerjaan.GajiRange, &gaji.Min, &gaji.Max, &gaji.Currency, &gaji.Period,
+			&tanggalMulai, &tanggalSelesai,
 			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
 			&pekerjaan.DeletedAt, &pekerjaan.DeletedBy,
 			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
@@ -121,6 +270,7 @@ func GetAllPekerjaan(db *sql.DB) ([]model.PekerjaanAlumni, error) {
 		}
 
 		pekerjaan.TanggalMulaiKerja = model.Date{Time: tanggalMulai}
+		pekerjaan.Gaji = gaji.value()
 		if tanggalSelesai != nil {
 			pekerjaan.TanggalSelesaiKerja = &model.Date{Time: *tanggalSelesai}
 		}
@@ -130,20 +280,23 @@ func GetAllPekerjaan(db *sql.DB) ([]model.PekerjaanAlumni, error) {
 	return pekerjaanList, nil
 }
 
-func GetPekerjaanByID(db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
+func GetPekerjaanByID(ctx context.Context, db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
 	pekerjaan := new(model.PekerjaanAlumni)
-	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
-	          gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
+	query := `SELECT id, alumni_id, company_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
+	          gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+	          tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
 	          deskripsi_pekerjaan, deleted_at, deleted_by, created_at, updated_at 
 	          FROM pekerjaan_alumni WHERE id = $1 AND deleted_at IS NULL`
 
 	var tanggalMulai time.Time
 	var tanggalSelesai *time.Time
+	var gaji nullGaji
 
-	err := db.QueryRow(query, id).Scan(
-		&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+	err := db.QueryRowContext(ctx, query, id).Scan(
+		&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.CompanyID, &pekerjaan.NamaPerusahaan,
 		&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-		&pekerjaan.GajiRange, &tanggalMulai, &tanggalSelesai,
+		&pekerjaan.GajiRange, &gaji.Min, &gaji.Max, &gaji.Currency, &gaji.Period,
+		&tanggalMulai, &tanggalSelesai,
 		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
 		&pekerjaan.DeletedAt, &pekerjaan.DeletedBy,
 		&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
@@ -153,6 +306,7 @@ func GetPekerjaanByID(db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
 	}
 
 	pekerjaan.TanggalMulaiKerja = model.Date{Time: tanggalMulai}
+	pekerjaan.Gaji = gaji.value()
 	if tanggalSelesai != nil {
 		pekerjaan.TanggalSelesaiKerja = &model.Date{Time: *tanggalSelesai}
 	}
@@ -160,13 +314,14 @@ func GetPekerjaanByID(db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
 	return pekerjaan, nil
 }
 
-func GetPekerjaanByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni, error) {
-	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
-	          gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
+func GetPekerjaanByAlumniID(ctx context.Context, db *sql.DB, alumniID int) ([]model.PekerjaanAlumni, error) {
+	query := `SELECT id, alumni_id, company_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
+	          gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+	          tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
 	          deskripsi_pekerjaan, deleted_at, deleted_by, created_at, updated_at 
 	          FROM pekerjaan_alumni WHERE alumni_id = $1 AND deleted_at IS NULL ORDER BY tanggal_mulai_kerja DESC`
 
-	rows, err := db.Query(query, alumniID)
+	rows, err := db.QueryContext(ctx, query, alumniID)
 	if err != nil {
 		return nil, err
 	}
@@ -177,11 +332,13 @@ func GetPekerjaanByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni,
 		var pekerjaan model.PekerjaanAlumni
 		var tanggalMulai time.Time
 		var tanggalSelesai *time.Time
+		var gaji nullGaji
 
 		err := rows.Scan(
-			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.CompanyID, &pekerjaan.NamaPerusahaan,
 			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-			&pekerjaan.GajiRange, &tanggalMulai, &tanggalSelesai,
+			&pekerjaan.GajiRange, &gaji.Min, &gaji.Max, &gaji.Currency, &gaji.Period,
+			&tanggalMulai, &tanggalSelesai,
 			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
 			&pekerjaan.DeletedAt, &pekerjaan.DeletedBy,
 			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
@@ -191,6 +348,7 @@ func GetPekerjaanByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni,
 		}
 
 		pekerjaan.TanggalMulaiKerja = model.Date{Time: tanggalMulai}
+		pekerjaan.Gaji = gaji.value()
 		if tanggalSelesai != nil {
 			pekerjaan.TanggalSelesaiKerja = &model.Date{Time: *tanggalSelesai}
 		}
@@ -200,43 +358,49 @@ func GetPekerjaanByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni,
 	return pekerjaanList, nil
 }
 
-func CreatePekerjaan(db *sql.DB, req model.CreatePekerjaanRequest) (*model.PekerjaanAlumni, error) {
+func CreatePekerjaan(ctx context.Context, db *sql.DB, req model.CreatePekerjaanRequest) (*model.PekerjaanAlumni, error) {
 	now := time.Now()
 	var id int
 	query := `INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-	          lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
-	          deskripsi_pekerjaan, created_at, updated_at)
-	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
+	          lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+	          tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
+	          deskripsi_pekerjaan, created_at, updated_at, company_id)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING id`
 
 	var tanggalSelesai *time.Time
 	if req.TanggalSelesaiKerja != nil {
 		tanggalSelesai = &req.TanggalSelesaiKerja.Time
 	}
 
-	err := db.QueryRow(query, req.AlumniID, req.NamaPerusahaan, req.PosisiJabatan,
-		req.BidangIndustri, req.LokasiKerja, req.GajiRange, req.TanggalMulaiKerja.Time,
-		tanggalSelesai, req.StatusPekerjaan, req.DeskripsiPekerjaan, now, now).Scan(&id)
+	gajiMin, gajiMax, gajiCurrency, gajiPeriod := gajiArgs(req.Gaji)
+
+	err := db.QueryRowContext(ctx, query, req.AlumniID, req.NamaPerusahaan, req.PosisiJabatan,
+		req.BidangIndustri, req.LokasiKerja, req.GajiRange, gajiMin, gajiMax, gajiCurrency, gajiPeriod,
+		req.TanggalMulaiKerja.Time, tanggalSelesai, req.StatusPekerjaan, req.DeskripsiPekerjaan, now, now, req.CompanyID).Scan(&id)
 	if err != nil {
 		return nil, err
 	}
 
-	return GetPekerjaanByID(db, id)
+	return GetPekerjaanByID(ctx, db, id)
 }
 
-func UpdatePekerjaan(db *sql.DB, id int, req model.UpdatePekerjaanRequest) (*model.PekerjaanAlumni, error) {
+func UpdatePekerjaan(ctx context.Context, db *sql.DB, id int, req model.UpdatePekerjaanRequest) (*model.PekerjaanAlumni, error) {
 	now := time.Now()
 	query := `UPDATE pekerjaan_alumni SET nama_perusahaan = $1, posisi_jabatan = $2, bidang_industri = $3,
-	          lokasi_kerja = $4, gaji_range = $5, tanggal_mulai_kerja = $6, tanggal_selesai_kerja = $7,
-	          status_pekerjaan = $8, deskripsi_pekerjaan = $9, updated_at = $10 WHERE id = $11`
+	          lokasi_kerja = $4, gaji_range = $5, gaji_min = $6, gaji_max = $7, gaji_currency = $8, gaji_period = $9,
+	          tanggal_mulai_kerja = $10, tanggal_selesai_kerja = $11,
+	          status_pekerjaan = $12, deskripsi_pekerjaan = $13, updated_at = $14, company_id = $15 WHERE id = $16`
 
 	var tanggalSelesai *time.Time
 	if req.TanggalSelesaiKerja != nil {
 		tanggalSelesai = &req.TanggalSelesaiKerja.Time
 	}
 
-	result, err := db.Exec(query, req.NamaPerusahaan, req.PosisiJabatan, req.BidangIndustri,
-		req.LokasiKerja, req.GajiRange, req.TanggalMulaiKerja.Time, tanggalSelesai,
-		req.StatusPekerjaan, req.DeskripsiPekerjaan, now, id)
+	gajiMin, gajiMax, gajiCurrency, gajiPeriod := gajiArgs(req.Gaji)
+
+	result, err := db.ExecContext(ctx, query, req.NamaPerusahaan, req.PosisiJabatan, req.BidangIndustri,
+		req.LokasiKerja, req.GajiRange, gajiMin, gajiMax, gajiCurrency, gajiPeriod,
+		req.TanggalMulaiKerja.Time, tanggalSelesai, req.StatusPekerjaan, req.DeskripsiPekerjaan, now, req.CompanyID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -246,12 +410,12 @@ func UpdatePekerjaan(db *sql.DB, id int, req model.UpdatePekerjaanRequest) (*mod
 		return nil, sql.ErrNoRows
 	}
 
-	return GetPekerjaanByID(db, id)
+	return GetPekerjaanByID(ctx, db, id)
 }
 
-func DeletePekerjaan(db *sql.DB, id int) error {
+func DeletePekerjaan(ctx context.Context, db *sql.DB, id int) error {
 	query := `DELETE FROM pekerjaan_alumni WHERE id = $1`
-	result, err := db.Exec(query, id)
+	result, err := db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -264,10 +428,17 @@ func DeletePekerjaan(db *sql.DB, id int) error {
 	return nil
 }
 
-func SoftDeletePekerjaan(db *sql.DB, id int, deletedBy int) error {
+// CountTrashedPekerjaan returns the number of soft-deleted pekerjaan
+func CountTrashedPekerjaan(ctx context.Context, db *sql.DB) (int, error) {
+	var count int
+	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pekerjaan_alumni WHERE deleted_at IS NOT NULL").Scan(&count)
+	return count, err
+}
+
+func SoftDeletePekerjaan(ctx context.Context, db *sql.DB, id int, deletedBy int) error {
 	now := time.Now()
 	query := `UPDATE pekerjaan_alumni SET deleted_at = $1, deleted_by = $2, updated_at = $3 WHERE id = $4 AND deleted_at IS NULL`
-	result, err := db.Exec(query, now, deletedBy, now, id)
+	result, err := db.ExecContext(ctx, query, now, deletedBy, now, id)
 	if err != nil {
 		return err
 	}
@@ -280,30 +451,30 @@ func SoftDeletePekerjaan(db *sql.DB, id int, deletedBy int) error {
 	return nil
 }
 
-func GetAlumniIDByPekerjaanID(db *sql.DB, pekerjaanID int) (int, error) {
+func GetAlumniIDByPekerjaanID(ctx context.Context, db *sql.DB, pekerjaanID int) (int, error) {
 	var alumniID int
 	query := `SELECT alumni_id FROM pekerjaan_alumni WHERE id = $1 AND deleted_at IS NULL`
-	err := db.QueryRow(query, pekerjaanID).Scan(&alumniID)
+	err := db.QueryRowContext(ctx, query, pekerjaanID).Scan(&alumniID)
 	if err != nil {
 		return 0, err
 	}
 	return alumniID, nil
 }
 
-func GetUserIDByAlumniID(db *sql.DB, alumniID int) (*int, error) {
+func GetUserIDByAlumniID(ctx context.Context, db *sql.DB, alumniID int) (*int, error) {
 	var userID *int
 	query := `SELECT user_id FROM alumni WHERE id = $1`
-	err := db.QueryRow(query, alumniID).Scan(&userID)
+	err := db.QueryRowContext(ctx, query, alumniID).Scan(&userID)
 	if err != nil {
 		return nil, err
 	}
 	return userID, nil
 }
 
-func RestorePekerjaan(db *sql.DB, id int) error {
+func RestorePekerjaan(ctx context.Context, db *sql.DB, id int) error {
 	now := time.Now()
 	query := `UPDATE pekerjaan_alumni SET deleted_at = NULL, deleted_by = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL`
-	result, err := db.Exec(query, now, id)
+	result, err := db.ExecContext(ctx, query, now, id)
 	if err != nil {
 		return err
 	}
@@ -316,9 +487,9 @@ func RestorePekerjaan(db *sql.DB, id int) error {
 	return nil
 }
 
-func HardDeletePekerjaan(db *sql.DB, id int) error {
+func HardDeletePekerjaan(ctx context.Context, db *sql.DB, id int) error {
 	query := `DELETE FROM pekerjaan_alumni WHERE id = $1`
-	result, err := db.Exec(query, id)
+	result, err := db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -331,7 +502,7 @@ func HardDeletePekerjaan(db *sql.DB, id int) error {
 	return nil
 }
 
-func SoftDeletePekerjaanByAlumniID(db *sql.DB, alumniID int, deletedBy int) error {
+func SoftDeletePekerjaanByAlumniID(ctx context.Context, db *sql.DB, alumniID int, deletedBy int) error {
 	now := time.Now()
 	query := `UPDATE pekerjaan_alumni SET deleted_at = $1, deleted_by = $2, updated_at = $3 WHERE alumni_id = $4 AND deleted_at IS NULL`
 
@@ -342,7 +513,7 @@ func SoftDeletePekerjaanByAlumniID(db *sql.DB, alumniID int, deletedBy int) erro
 		deletedByValue = nil
 	}
 
-	result, err := db.Exec(query, now, deletedByValue, now, alumniID)
+	result, err := db.ExecContext(ctx, query, now, deletedByValue, now, alumniID)
 	if err != nil {
 		return err
 	}
@@ -355,9 +526,9 @@ func SoftDeletePekerjaanByAlumniID(db *sql.DB, alumniID int, deletedBy int) erro
 	return nil
 }
 
-func HardDeletePekerjaanByAlumniID(db *sql.DB, alumniID int) error {
+func HardDeletePekerjaanByAlumniID(ctx context.Context, db *sql.DB, alumniID int) error {
 	query := `DELETE FROM pekerjaan_alumni WHERE alumni_id = $1 AND deleted_at IS NOT NULL`
-	result, err := db.Exec(query, alumniID)
+	result, err := db.ExecContext(ctx, query, alumniID)
 	if err != nil {
 		return err
 	}
@@ -369,3 +540,102 @@ func HardDeletePekerjaanByAlumniID(db *sql.DB, alumniID int) error {
 
 	return nil
 }
+
+// SearchPekerjaanFullText searches jobs through search_vector, ranked by
+// ts_rank. Jika tidak ada hasil, dicoba lagi dengan kemiripan trigram nama
+// perusahaan dan posisi untuk menoleransi typo.
+func SearchPekerjaanFullText(ctx context.Context, db *sql.DB, params model.PaginationParams) ([]model.PekerjaanSearchHit, int, error) {
+	terms := searchTerms(params.Search)
+	if len(terms) == 0 {
+		return []model.PekerjaanSearchHit{}, 0, nil
+	}
+
+	searches := []struct {
+		match string
+		rank  string
+		value string
+	}{
+		{
+			match: "search_vector @@ to_tsquery('simple', $1)",
+			rank:  "ts_rank(search_vector, to_tsquery('simple', $1))",
+			value: tsQuery(terms),
+		},
+		{
+			match: "($1 <% nama_perusahaan OR $1 <% posisi_jabatan)",
+			rank:  "GREATEST(word_similarity($1, nama_perusahaan), word_similarity($1, posisi_jabatan))",
+			value: strings.Join(terms, " "),
+		},
+	}
+
+	for _, search := range searches {
+		where := "WHERE deleted_at IS NULL AND " + search.match
+
+		var total int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pekerjaan_alumni "+where, search.value).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+		if total == 0 {
+			continue
+		}
+
+		query := fmt.Sprintf(`
+			SELECT id, alumni_id, company_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
+			       gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+			       tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
+			       deskripsi_pekerjaan, deleted_at, deleted_by, created_at, updated_at,
+			       %s AS score
+			FROM pekerjaan_alumni %s
+			ORDER BY score DESC, id ASC
+			LIMIT $2 OFFSET $3`, search.rank, where)
+
+		rows, err := db.QueryContext(ctx, query, search.value, params.Limit, (params.Page-1)*params.Limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer rows.Close()
+
+		hits := []model.PekerjaanSearchHit{}
+		for rows.Next() {
+			var hit model.PekerjaanSearchHit
+			var tanggalMulai time.Time
+			var tanggalSelesai *time.Time
+			var gaji nullGaji
+
+			if err := rows.Scan(
+				&hit.ID, &hit.AlumniID, &hit.CompanyID, &hit.NamaPerusahaan,
+				&hit.PosisiJabatan, &hit.BidangIndustri, &hit.LokasiKerja,
+				&hit.GajiRange, &gaji.Min, &gaji.Max, &gaji.Currency, &gaji.Period,
+				&tanggalMulai, &tanggalSelesai,
+				&hit.StatusPekerjaan, &hit.DeskripsiPekerjaan,
+				&hit.DeletedAt, &hit.DeletedBy,
+				&hit.CreatedAt, &hit.UpdatedAt,
+				&hit.Score,
+			); err != nil {
+				return nil, 0, err
+			}
+
+			hit.TanggalMulaiKerja = model.Date{Time: tanggalMulai}
+			hit.Gaji = gaji.value()
+			if tanggalSelesai != nil {
+				hit.TanggalSelesaiKerja = &model.Date{Time: *tanggalSelesai}
+			}
+
+			fields := map[string]string{
+				"nama_perusahaan": hit.NamaPerusahaan,
+				"posisi_jabatan":  hit.PosisiJabatan,
+				"bidang_industri": hit.BidangIndustri,
+				"lokasi_kerja":    hit.LokasiKerja,
+			}
+			if hit.DeskripsiPekerjaan != nil {
+				fields["deskripsi_pekerjaan"] = *hit.DeskripsiPekerjaan
+			}
+			hit.Highlights = searchHighlights(fields, terms)
+
+			hits = append(hits, hit)
+		}
+
+		return hits, total, rows.Err()
+	}
+
+	return []model.PekerjaanSearchHit{}, 0, nil
+}
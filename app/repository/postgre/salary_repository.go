@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/lib/pq"
+)
+
+// GetPekerjaanWithoutGaji returns the jobs whose free-text gaji_range has not
+// been converted to structured salary yet, termasuk yang sudah di-soft delete.
+// Hanya ID dan GajiRange yang diisi.
+func GetPekerjaanWithoutGaji(ctx context.Context, db *sql.DB) ([]model.PekerjaanAlumni, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, gaji_range FROM pekerjaan_alumni
+		WHERE gaji_range IS NOT NULL AND gaji_range <> '' AND gaji_max IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.PekerjaanAlumni
+	for rows.Next() {
+		var p model.PekerjaanAlumni
+		if err := rows.Scan(&p.ID, &p.GajiRange); err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+// SetPekerjaanGaji stores the structured salary parsed from gaji_range
+func SetPekerjaanGaji(ctx context.Context, db *sql.DB, id int, gaji model.Gaji) error {
+	_, err := db.ExecContext(ctx, `UPDATE pekerjaan_alumni SET gaji_min = $1, gaji_max = $2, gaji_currency = $3, gaji_period = $4
+		WHERE id = $5`, gaji.Min, gaji.Max, gaji.Currency, gaji.Period, id)
+	return err
+}
+
+// GetSalaryStatistics computes monthly salary percentiles of the current jobs
+// in currency, per jurusan, bidang industri dan tahun lulus, dalam satu query
+// dengan GROUPING SETS. Nilai tiap pekerjaan adalah titik tengah rentang.
+func GetSalaryStatistics(ctx context.Context, db *sql.DB, alumniFilter model.AlumniFilter, currency string) (*model.SalaryStatistics, error) {
+	whereClause, args, argIndex := appendAlumniFilter("WHERE deleted_at IS NULL", []interface{}{}, 1, alumniFilter)
+	args = append(args, currency)
+
+	query := fmt.Sprintf(`
+		WITH salary AS (
+			SELECT a.jurusan, a.tahun_lulus, p.bidang_industri,
+			       (p.gaji_min + p.gaji_max) / CASE WHEN p.gaji_period = 'yearly' THEN 24.0 ELSE 2.0 END AS amount
+			FROM (SELECT id, jurusan, tahun_lulus FROM alumni %s) a
+			JOIN pekerjaan_alumni p ON p.alumni_id = a.id
+			WHERE p.deleted_at IS NULL AND %s AND p.gaji_currency = $%d
+		)
+		SELECT GROUPING(jurusan), GROUPING(bidang_industri), GROUPING(tahun_lulus),
+		       jurusan, bidang_industri, tahun_lulus, COUNT(*),
+		       percentile_cont(ARRAY[0.25, 0.5, 0.75, 0.9]) WITHIN GROUP (ORDER BY amount)
+		FROM salary
+		GROUP BY GROUPING SETS ((), (jurusan), (bidang_industri), (tahun_lulus))
+		ORDER BY jurusan, bidang_industri, tahun_lulus`, whereClause, currentJobCondition, argIndex)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := &model.SalaryStatistics{
+		Currency:         currency,
+		Period:           model.GajiPeriodMonthly,
+		ByJurusan:        []model.SalaryGroup{},
+		ByBidangIndustri: []model.SalaryGroup{},
+		ByTahunLulus:     []model.SalaryGroup{},
+	}
+
+	for rows.Next() {
+		var (
+			groupJurusan, groupIndustri, groupTahun int
+			jurusan, industri                       sql.NullString
+			tahunLulus                              sql.NullInt64
+			percentiles                             pq.Float64Array
+			g                                       model.SalaryGroup
+		)
+		if err := rows.Scan(&groupJurusan, &groupIndustri, &groupTahun,
+			&jurusan, &industri, &tahunLulus, &g.Count, &percentiles); err != nil {
+			return nil, err
+		}
+
+		if len(percentiles) == 4 {
+			for i, dst := range []**float64{&g.P25, &g.P50, &g.P75, &g.P90} {
+				v := math.Round(percentiles[i])
+				*dst = &v
+			}
+		}
+
+		// GROUPING() bernilai 0 untuk kolom yang menjadi dasar grup
+		switch {
+		case groupJurusan == 0:
+			g.Value = jurusan.String
+			stats.ByJurusan = append(stats.ByJurusan, g)
+		case groupIndustri == 0:
+			g.Value = industri.String
+			stats.ByBidangIndustri = append(stats.ByBidangIndustri, g)
+		case groupTahun == 0:
+			if tahunLulus.Valid {
+				g.Value = strconv.FormatInt(tahunLulus.Int64, 10)
+			}
+			stats.ByTahunLulus = append(stats.ByTahunLulus, g)
+		default:
+			stats.Overall = g
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
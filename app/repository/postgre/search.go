@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxSearchTerms      = 8  // Term selebihnya diabaikan
+	maxSearchTermLength = 40 // Term lebih panjang dipotong
+	minFuzzyTermLength  = 4  // Term lebih pendek harus cocok persis
+)
+
+// Query full-text memakai kolom search_vector (tsvector) dan index pg_trgm,
+// lihat migrasi 0006_search.sql. Konfigurasi "simple" dipakai karena
+// Postgres tidak punya stemmer bahasa Indonesia.
+
+// tsQuery joins search terms into an OR tsquery, term hanya berisi huruf dan angka
+func tsQuery(terms []string) string {
+	return strings.Join(terms, " | ")
+}
+
+// searchTerms splits a search query into lowercase words
+func searchTerms(search string) []string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := map[string]bool{}
+	var terms []string
+	for _, w := range words {
+		if r := []rune(w); len(r) > maxSearchTermLength {
+			w = string(r[:maxSearchTermLength])
+		}
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// editDistance is the Damerau-Levenshtein (optimal string alignment) distance
+func editDistance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(b)]
+}
+
+// termSimilarity scores how well word matches term, 1 berarti sama persis
+func termSimilarity(word, term string) float64 {
+	w, t := []rune(strings.ToLower(word)), []rune(term)
+	if string(w) == term {
+		return 1
+	}
+	if len(t) < minFuzzyTermLength {
+		return 0
+	}
+	d := editDistance(w, t)
+	if d > 1 {
+		return 0
+	}
+	return 1 - float64(d)/float64(max(len(w), len(t)))
+}
+
+// splitWords splits text into alternating word and separator segments
+func splitWords(text string) []string {
+	var segments []string
+	var current []rune
+	inWord := false
+	for _, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if len(current) > 0 && isWord != inWord {
+			segments = append(segments, string(current))
+			current = current[:0]
+		}
+		inWord = isWord
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		segments = append(segments, string(current))
+	}
+	return segments
+}
+
+func isWordSegment(s string) bool {
+	for _, r := range s {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return false
+}
+
+// highlight wraps the words of text that match a term in <mark>. Teks
+// di-escape sebagai HTML agar aman ditampilkan langsung oleh frontend.
+func highlight(text string, terms []string) (string, bool) {
+	var b strings.Builder
+	matched := false
+	for _, segment := range splitWords(text) {
+		hit := false
+		if isWordSegment(segment) {
+			for _, term := range terms {
+				if termSimilarity(segment, term) > 0 {
+					hit = true
+					break
+				}
+			}
+		}
+		if hit {
+			matched = true
+			b.WriteString("<mark>" + html.EscapeString(segment) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(segment))
+		}
+	}
+	return b.String(), matched
+}
+
+// searchHighlights returns the highlighted value of every field matching a term
+func searchHighlights(fields map[string]string, terms []string) map[string]string {
+	highlights := map[string]string{}
+	for field, value := range fields {
+		if marked, ok := highlight(value, terms); ok {
+			highlights[field] = marked
+		}
+	}
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const taxonomyColumns = `id, kind, code, label, aliases, active, source, created_at, updated_at`
+
+func scanTaxonomyTerm(row rowScanner) (*model.TaxonomyTerm, error) {
+	var term model.TaxonomyTerm
+	if err := row.Scan(&term.ID, &term.Kind, &term.Code, &term.Label, pq.Array(&term.Aliases),
+		&term.Active, &term.Source, &term.CreatedAt, &term.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if term.Aliases == nil {
+		term.Aliases = []string{}
+	}
+	return &term, nil
+}
+
+// GetTaxonomyTerms returns the terms of a kind sorted by label. Kind kosong
+// mengembalikan semua jenis.
+func GetTaxonomyTerms(ctx context.Context, db *sql.DB, kind string, activeOnly bool) ([]model.TaxonomyTerm, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+taxonomyColumns+` FROM taxonomy_terms
+		WHERE ($1 = '' OR kind = $1) AND (NOT $2 OR active)
+		ORDER BY kind, label, id`, kind, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	terms := []model.TaxonomyTerm{}
+	for rows.Next() {
+		term, err := scanTaxonomyTerm(rows)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, *term)
+	}
+	return terms, rows.Err()
+}
+
+// GetTaxonomyTermByID returns sql.ErrNoRows when the term does not exist
+func GetTaxonomyTermByID(ctx context.Context, db *sql.DB, id int) (*model.TaxonomyTerm, error) {
+	return scanTaxonomyTerm(db.QueryRowContext(ctx, `SELECT `+taxonomyColumns+` FROM taxonomy_terms WHERE id = $1`, id))
+}
+
+// CreateTaxonomyTerm saves a new term
+func CreateTaxonomyTerm(ctx context.Context, db *sql.DB, term *model.TaxonomyTerm) error {
+	now := time.Now()
+	term.CreatedAt = now
+	term.UpdatedAt = now
+
+	return db.QueryRowContext(ctx, `INSERT INTO taxonomy_terms (kind, code, label, aliases, active, source, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		term.Kind, term.Code, term.Label, pq.Array(term.Aliases), term.Active, term.Source, now, now).Scan(&term.ID)
+}
+
+// UpdateTaxonomyTerm saves the label, aliases and active flag of a term
+func UpdateTaxonomyTerm(ctx context.Context, db *sql.DB, term *model.TaxonomyTerm) error {
+	term.UpdatedAt = time.Now()
+	result, err := db.ExecContext(ctx, `UPDATE taxonomy_terms SET label = $1, aliases = $2, active = $3, updated_at = $4 WHERE id = $5`,
+		term.Label, pq.Array(term.Aliases), term.Active, term.UpdatedAt, term.ID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteTaxonomyTerm removes a term
+func DeleteTaxonomyTerm(ctx context.Context, db *sql.DB, id int) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM taxonomy_terms WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SeedTaxonomyTerms inserts the terms whose kind and code do not exist yet.
+// Term yang sudah ada tidak diubah agar suntingan admin tetap terjaga.
+// Mengembalikan jumlah term yang ditambahkan.
+func SeedTaxonomyTerms(ctx context.Context, db *sql.DB, terms []model.TaxonomyTerm) (int, error) {
+	now := time.Now()
+	inserted := 0
+	for _, term := range terms {
+		result, err := db.ExecContext(ctx, `INSERT INTO taxonomy_terms (kind, code, label, aliases, active, source, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7) ON CONFLICT (kind, code) DO NOTHING`,
+			term.Kind, term.Code, term.Label, pq.Array(term.Aliases), term.Active, term.Source, now)
+		if err != nil {
+			return inserted, err
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			inserted++
+		}
+	}
+	return inserted, nil
+}
+
+// GetTaxonomyValueCounts counts the raw stored values of a taxonomy: alumni
+// per jurusan, alumni yang sedang bekerja per bidang industri, dan pekerjaan
+// per status. Data yang di-soft delete tidak dihitung.
+func GetTaxonomyValueCounts(ctx context.Context, db *sql.DB, kind string) (map[string]int, error) {
+	var query string
+	switch kind {
+	case model.TaxonomyJurusan:
+		query = `SELECT jurusan, COUNT(*) FROM alumni WHERE deleted_at IS NULL GROUP BY jurusan`
+	case model.TaxonomyBidangIndustri:
+		query = fmt.Sprintf(`SELECT p.bidang_industri, COUNT(DISTINCT p.alumni_id)
+			FROM pekerjaan_alumni p
+			WHERE p.deleted_at IS NULL AND %s
+			GROUP BY p.bidang_industri`, currentJobCondition)
+	case model.TaxonomyStatusPekerjaan:
+		query = `SELECT status_pekerjaan, COUNT(*) FROM pekerjaan_alumni WHERE deleted_at IS NULL GROUP BY status_pekerjaan`
+	default:
+		return nil, fmt.Errorf("jenis taksonomi tidak dikenal: %s", kind)
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var value sql.NullString
+		var count int
+		if err := rows.Scan(&value, &count); err != nil {
+			return nil, err
+		}
+		counts[value.String] += count
+	}
+	return counts, rows.Err()
+}
+
+// GetDistinctTaxonomyValues returns every distinct stored value of jurusan or
+// bidang industri, termasuk milik data yang sudah di-soft delete
+func GetDistinctTaxonomyValues(ctx context.Context, db *sql.DB, kind string) ([]string, error) {
+	var query string
+	switch kind {
+	case model.TaxonomyJurusan:
+		query = `SELECT DISTINCT jurusan FROM alumni WHERE jurusan <> '' ORDER BY jurusan`
+	case model.TaxonomyBidangIndustri:
+		query = `SELECT DISTINCT bidang_industri FROM pekerjaan_alumni WHERE bidang_industri <> '' ORDER BY bidang_industri`
+	default:
+		return nil, fmt.Errorf("jenis taksonomi tidak dikenal: %s", kind)
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+)
+
+// tracerAlumniQuery selects one row per alumni with the first job and months
+// to first job. Bulan dihitung penuh sejak tanggal 1 bulan kelulusan, minimal 0.
+const tracerAlumniQuery = `
+	WITH first_job AS (
+		SELECT DISTINCT ON (alumni_id) alumni_id, tanggal_mulai_kerja, bidang_industri, lokasi_kerja
+		FROM pekerjaan_alumni
+		WHERE deleted_at IS NULL
+		ORDER BY alumni_id, tanggal_mulai_kerja, id
+	), tracer AS (
+		SELECT alumni.tahun_lulus, alumni.jurusan, f.bidang_industri, f.lokasi_kerja,
+		       EXISTS (SELECT 1 FROM pekerjaan_alumni p WHERE p.alumni_id = alumni.id AND p.deleted_at IS NULL AND %s) AS currently_employed,
+		       CASE WHEN f.alumni_id IS NULL THEN NULL
+		            ELSE GREATEST(0, (EXTRACT(YEAR FROM f.tanggal_mulai_kerja)::int - alumni.tahun_lulus) * 12
+		                             + EXTRACT(MONTH FROM f.tanggal_mulai_kerja)::int - $%d)
+		       END AS months
+		FROM alumni
+		LEFT JOIN first_job f ON f.alumni_id = alumni.id
+		%s
+	)`
+
+// GetTracerStatistics computes the tracer study metrics of the alumni matching
+// alumniFilter. Semua grup dihitung dalam satu query dengan GROUPING SETS,
+// median memakai percentile_cont.
+func GetTracerStatistics(ctx context.Context, db *sql.DB, alumniFilter model.AlumniFilter, graduationMonth int) (*model.TracerStatistics, error) {
+	whereClause, args, argIndex := appendAlumniFilter("WHERE deleted_at IS NULL", []interface{}{}, 1, alumniFilter)
+	base := fmt.Sprintf(tracerAlumniQuery, currentJobCondition, argIndex, whereClause)
+	args = append(args, graduationMonth)
+
+	stats := &model.TracerStatistics{
+		GraduationMonth:  graduationMonth,
+		ByTahunLulus:     []model.TracerGroup{},
+		ByJurusan:        []model.TracerGroup{},
+		ByCohort:         []model.TracerGroup{},
+		ByBidangIndustri: []model.TracerShare{},
+		ByLokasiKerja:    []model.TracerShare{},
+	}
+
+	rows, err := db.QueryContext(ctx, base+`
+		SELECT tahun_lulus, jurusan, GROUPING(tahun_lulus), GROUPING(jurusan),
+		       COUNT(*),
+		       COUNT(months),
+		       COUNT(*) FILTER (WHERE currently_employed),
+		       COUNT(*) FILTER (WHERE months <= 6),
+		       COUNT(*) FILTER (WHERE months <= 12),
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY months)
+		FROM tracer
+		GROUP BY GROUPING SETS ((), (tahun_lulus), (jurusan), (tahun_lulus, jurusan))
+		ORDER BY tahun_lulus, jurusan`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			tahunLulus           sql.NullInt64
+			jurusan              sql.NullString
+			groupTahun, groupJur int
+			median               sql.NullFloat64
+			g                    model.TracerGroup
+			within6, within12    int
+		)
+		if err := rows.Scan(&tahunLulus, &jurusan, &groupTahun, &groupJur,
+			&g.TotalAlumni, &g.Employed, &g.CurrentlyEmployed, &within6, &within12, &median); err != nil {
+			return nil, err
+		}
+
+		// GROUPING() bernilai 1 jika kolom tidak termasuk grouping set
+		if groupTahun == 0 && tahunLulus.Valid {
+			v := int(tahunLulus.Int64)
+			g.TahunLulus = &v
+		}
+		if groupJur == 0 && jurusan.Valid {
+			v := jurusan.String
+			g.Jurusan = &v
+		}
+		if median.Valid {
+			v := median.Float64
+			g.MedianMonthsToFirstJob = &v
+		}
+		g.EmploymentRate = percentage(g.Employed, g.TotalAlumni)
+		g.CurrentEmploymentRate = percentage(g.CurrentlyEmployed, g.TotalAlumni)
+		g.EmployedWithin6Months = percentage(within6, g.TotalAlumni)
+		g.EmployedWithin12Months = percentage(within12, g.TotalAlumni)
+
+		switch {
+		case groupTahun == 1 && groupJur == 1:
+			stats.Overall = g
+		case groupJur == 1:
+			stats.ByTahunLulus = append(stats.ByTahunLulus, g)
+		case groupTahun == 1:
+			stats.ByJurusan = append(stats.ByJurusan, g)
+		default:
+			stats.ByCohort = append(stats.ByCohort, g)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	shareRows, err := db.QueryContext(ctx, base+`
+		SELECT GROUPING(bidang_industri), COALESCE(bidang_industri, lokasi_kerja, ''), COUNT(*)
+		FROM tracer
+		WHERE months IS NOT NULL
+		GROUP BY GROUPING SETS ((bidang_industri), (lokasi_kerja))
+		ORDER BY 1, 3 DESC, 2`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer shareRows.Close()
+
+	for shareRows.Next() {
+		var groupIndustri int
+		var s model.TracerShare
+		if err := shareRows.Scan(&groupIndustri, &s.Value, &s.Count); err != nil {
+			return nil, err
+		}
+		s.Percentage = percentage(s.Count, stats.Overall.Employed)
+
+		if groupIndustri == 0 {
+			stats.ByBidangIndustri = append(stats.ByBidangIndustri, s)
+		} else {
+			stats.ByLokasiKerja = append(stats.ByLokasiKerja, s)
+		}
+	}
+	if err := shareRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// percentage returns n/total in percent, dibulatkan dua desimal
+func percentage(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)*10000/float64(total)) / 100
+}
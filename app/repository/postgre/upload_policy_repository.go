@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const uploadPolicyColumns = `category, max_file_size, allowed_types, allowed_extensions, max_files, max_total_bytes, updated_at`
+
+func scanUploadPolicy(row rowScanner) (*model.UploadPolicy, error) {
+	var policy model.UploadPolicy
+	err := row.Scan(
+		&policy.Category, &policy.MaxFileSize, pq.Array(&policy.AllowedTypes),
+		pq.Array(&policy.AllowedExtensions), &policy.MaxFiles, &policy.MaxTotalBytes, &policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// GetUploadPolicy retrieves the stored policy for a category
+func GetUploadPolicy(ctx context.Context, db *sql.DB, category string) (*model.UploadPolicy, error) {
+	query := `SELECT ` + uploadPolicyColumns + ` FROM upload_policies WHERE category = $1`
+	return scanUploadPolicy(db.QueryRowContext(ctx, query, category))
+}
+
+// GetAllUploadPolicies retrieves every stored policy
+func GetAllUploadPolicies(ctx context.Context, db *sql.DB) ([]model.UploadPolicy, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+uploadPolicyColumns+` FROM upload_policies ORDER BY category`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []model.UploadPolicy
+	for rows.Next() {
+		policy, err := scanUploadPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *policy)
+	}
+	return policies, rows.Err()
+}
+
+// UpsertUploadPolicy creates or replaces the policy for a category
+func UpsertUploadPolicy(ctx context.Context, db *sql.DB, policy *model.UploadPolicy) error {
+	query := `INSERT INTO upload_policies (category, max_file_size, allowed_types, allowed_extensions, max_files, max_total_bytes, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	          ON CONFLICT (category) DO UPDATE SET
+	              max_file_size = EXCLUDED.max_file_size,
+	              allowed_types = EXCLUDED.allowed_types,
+	              allowed_extensions = EXCLUDED.allowed_extensions,
+	              max_files = EXCLUDED.max_files,
+	              max_total_bytes = EXCLUDED.max_total_bytes,
+	              updated_at = NOW()
+	          RETURNING updated_at`
+
+	return db.QueryRowContext(ctx, query,
+		policy.Category, policy.MaxFileSize, pq.Array(policy.AllowedTypes),
+		pq.Array(policy.AllowedExtensions), policy.MaxFiles, policy.MaxTotalBytes,
+	).Scan(&policy.UpdatedAt)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"clean-arch/app/model/postgre"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const uploadSessionColumns = `id, user_id, owner_type, uploaded_by, uploader_type, category, original_name, file_type, file_size,
+	upload_offset, status, temp_path, expires_at, created_at, updated_at`
+
+func queryUploadSessions(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]model.UploadSession, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []model.UploadSession
+	for rows.Next() {
+		var s model.UploadSession
+		err := rows.Scan(&s.ID, &s.UserID, &s.OwnerType, &s.UploadedBy, &s.UploaderType, &s.Category, &s.OriginalName, &s.FileType,
+			&s.FileSize, &s.Offset, &s.Status, &s.TempPath, &s.ExpiresAt, &s.CreatedAt, &s.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// CreateUploadSession saves a new resumable upload session
+func CreateUploadSession(ctx context.Context, db *sql.DB, session *model.UploadSession) error {
+	query := `INSERT INTO upload_sessions (id, user_id, owner_type, uploaded_by, uploader_type, category,
+	          original_name, file_type, file_size, upload_offset, status, temp_path, expires_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	          RETURNING created_at, updated_at`
+
+	session.Status = model.UploadSessionUploading
+	return db.QueryRowContext(ctx, query,
+		session.ID, session.UserID, session.OwnerType, session.UploadedBy, session.UploaderType, session.Category, session.OriginalName,
+		session.FileType, session.FileSize, session.Offset, session.Status, session.TempPath, session.ExpiresAt,
+	).Scan(&session.CreatedAt, &session.UpdatedAt)
+}
+
+// GetUploadSessionByID retrieves an upload session
+func GetUploadSessionByID(ctx context.Context, db *sql.DB, id string) (*model.UploadSession, error) {
+	sessions, err := queryUploadSessions(ctx, db, `SELECT `+uploadSessionColumns+` FROM upload_sessions WHERE id::text = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &sessions[0], nil
+}
+
+// AdvanceUploadSessionOffset moves the offset forward only if it still equals
+// expectedOffset, so concurrent PATCH requests cannot both succeed. Sesi yang
+// sedang di-complete tidak diubah.
+func AdvanceUploadSessionOffset(ctx context.Context, db *sql.DB, id string, expectedOffset, newOffset int64) error {
+	result, err := db.ExecContext(ctx, `UPDATE upload_sessions SET upload_offset = $1, updated_at = NOW()
+	                        WHERE id = $2 AND upload_offset = $3 AND status = $4`, newOffset, id, expectedOffset, model.UploadSessionUploading)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ClaimUploadSession moves the session from uploading to completing. Hanya
+// satu request complete yang berhasil; yang lain mendapat sql.ErrNoRows.
+func ClaimUploadSession(ctx context.Context, db *sql.DB, id string) error {
+	result, err := db.ExecContext(ctx, `UPDATE upload_sessions SET status = $1, updated_at = NOW()
+	                        WHERE id = $2 AND status = $3`, model.UploadSessionCompleting, id, model.UploadSessionUploading)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ReleaseUploadSession returns a claimed session to uploading after a failed
+// completion so the client can retry
+func ReleaseUploadSession(ctx context.Context, db *sql.DB, id string) error {
+	_, err := db.ExecContext(ctx, `UPDATE upload_sessions SET status = $1, updated_at = NOW() WHERE id = $2`,
+		model.UploadSessionUploading, id)
+	return err
+}
+
+// DeleteUploadSession removes an upload session
+func DeleteUploadSession(ctx context.Context, db *sql.DB, id string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM upload_sessions WHERE id = $1`, id)
+	return err
+}
+
+// GetExpiredUploadSessions retrieves sessions whose expiry has passed
+func GetExpiredUploadSessions(ctx context.Context, db *sql.DB, now time.Time) ([]model.UploadSession, error) {
+	return queryUploadSessions(ctx, db, `SELECT `+uploadSessionColumns+` FROM upload_sessions WHERE expires_at < $1`, now)
+}
+
+// GetAllUploadSessions retrieves every upload session, expired or not
+func GetAllUploadSessions(ctx context.Context, db *sql.DB) ([]model.UploadSession, error) {
+	return queryUploadSessions(ctx, db, `SELECT `+uploadSessionColumns+` FROM upload_sessions`)
+}
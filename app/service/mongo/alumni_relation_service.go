@@ -0,0 +1,105 @@
+package service
+
+import (
+	"clean-arch/app/model/mongo"
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/mongo"
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Field yang bisa dipilih dengan ?fields=, memakai nama field JSON
+var (
+	alumniFields = []string{
+		"id", "nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "role",
+		"no_telepon", "alamat", "is_verified", "created_at", "updated_at",
+	}
+	pekerjaanFields = []string{
+		"id", "alumni_id", "company_id", "nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja",
+		"gaji_range", "gaji", "tanggal_mulai_kerja", "tanggal_selesai_kerja", "status_pekerjaan",
+		"deskripsi_pekerjaan", "created_at", "updated_at",
+	}
+)
+
+// Relasi yang bisa di-embed dengan ?include= pada endpoint alumni
+var alumniIncludes = []string{model.IncludePekerjaan, model.IncludeFiles, model.IncludeCurrentJob}
+
+// shapeAlumni applies the sparse fieldset and embeds the requested relations.
+// Relasi semua alumni dimuat dengan satu aggregation, gaji pada pekerjaan yang
+// di-embed hanya terlihat oleh canView.
+func shapeAlumni(ctx context.Context, db *mongo.Database, list []model.Alumni, sel utils.FieldSelection, canView gajiViewer) ([]fiber.Map, error) {
+	var relations map[primitive.ObjectID]model.AlumniRelations
+	if len(sel.Include) > 0 {
+		ids := make([]primitive.ObjectID, len(list))
+		for i, a := range list {
+			ids[i] = a.ID
+		}
+		var err error
+		if relations, err = repository.GetAlumniRelations(ctx, db, ids, sel.Include); err != nil {
+			return nil, err
+		}
+	}
+
+	shaped := make([]fiber.Map, 0, len(list))
+	for _, a := range list {
+		item, err := utils.SelectFields(a, sel.Fields)
+		if err != nil {
+			return nil, err
+		}
+
+		rel := relations[a.ID]
+		if sel.Includes(model.IncludePekerjaan) {
+			if rel.Pekerjaan == nil {
+				rel.Pekerjaan = []model.PekerjaanAlumni{}
+			}
+			hideGajiEach(canView, rel.Pekerjaan)
+			item[model.IncludePekerjaan] = rel.Pekerjaan
+		}
+		if sel.Includes(model.IncludeCurrentJob) {
+			hideGaji(canView, rel.CurrentJob)
+			item[model.IncludeCurrentJob] = rel.CurrentJob
+		}
+		if sel.Includes(model.IncludeFiles) {
+			item[model.IncludeFiles] = buildAlumniFiles(rel.Files)
+		}
+
+		shaped = append(shaped, item)
+	}
+	return shaped, nil
+}
+
+// shapeAlumniHits is shapeAlumni for full-text results, skor dan highlight selalu ikut
+func shapeAlumniHits(ctx context.Context, db *mongo.Database, hits []model.AlumniSearchHit, sel utils.FieldSelection, canView gajiViewer) ([]fiber.Map, error) {
+	list := make([]model.Alumni, len(hits))
+	for i, hit := range hits {
+		list[i] = hit.Alumni
+	}
+
+	shaped, err := shapeAlumni(ctx, db, list, sel, canView)
+	if err != nil {
+		return nil, err
+	}
+	for i, hit := range hits {
+		shaped[i]["score"] = hit.Score
+		if hit.Highlights != nil {
+			shaped[i]["highlights"] = hit.Highlights
+		}
+	}
+	return shaped, nil
+}
+
+// selectEach applies a sparse fieldset to every item
+func selectEach[T any](items []T, fields []string) ([]fiber.Map, error) {
+	shaped := make([]fiber.Map, 0, len(items))
+	for _, item := range items {
+		m, err := utils.SelectFields(item, fields)
+		if err != nil {
+			return nil, err
+		}
+		shaped = append(shaped, m)
+	}
+	return shaped, nil
+}
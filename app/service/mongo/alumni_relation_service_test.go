@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseAlumniFieldSelection(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantErr   bool
+		wantEmpty bool
+	}{
+		{"", false, true},
+		{"?fields=nama,email", false, false},
+		{"?include=", false, false},
+		{"?include=pekerjaan,current_job", false, false},
+		{"?fields=password", true, false},
+		{"?include=users", true, false},
+	}
+
+	for _, tt := range tests {
+		var got utils.FieldSelection
+		var err error
+
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			got, err = utils.ParseFieldSelection(c, alumniFields, alumniIncludes)
+			return nil
+		})
+		if _, e := app.Test(httptest.NewRequest("GET", "/"+tt.query, nil)); e != nil {
+			t.Fatalf("request %q: %v", tt.query, e)
+		}
+
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %v, got %v", tt.query, tt.wantErr, err)
+			continue
+		}
+		if err == nil && got.IsEmpty() != tt.wantEmpty {
+			t.Errorf("%q: IsEmpty = %v, want %v", tt.query, got.IsEmpty(), tt.wantEmpty)
+		}
+	}
+}
+
+func TestShapeAlumniSelectsFields(t *testing.T) {
+	alumni := model.Alumni{ID: primitive.NewObjectID(), Nama: "Budi", Email: "budi@example.com", Password: "secret"}
+
+	// Tanpa include tidak ada query relasi, sehingga db boleh nil
+	shaped, err := shapeAlumni(context.Background(), nil, []model.Alumni{alumni}, utils.FieldSelection{Fields: []string{"id", "nama"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := shaped[0]
+	if len(item) != 2 || item["nama"] != "Budi" || item["id"] != alumni.ID.Hex() {
+		t.Errorf("unexpected shape %v", item)
+	}
+}
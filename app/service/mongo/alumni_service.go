@@ -1,13 +1,16 @@
 package service
 
 import (
-	"log"
+	"errors"
 	"os"
 	"strconv"
 	"strings"
 
 	"clean-arch/app/model/mongo"
 	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/mongo"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -29,21 +32,15 @@ import (
 func CheckAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	key := c.Params("key")
 	if key != os.Getenv("API_KEY") {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Key tidak valid",
-			"success": false,
-		})
+		return apperror.Unauthorized("alumni.invalid_key")
 	}
 
 	nim := c.FormValue("nim")
 	if nim == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "NIM wajib diisi",
-			"success": false,
-		})
+		return apperror.BadRequest("alumni.nim_required")
 	}
 
-	alumni, err := repository.CheckAlumniByNim(db, nim)
+	alumni, err := repository.CheckAlumniByNim(c.UserContext(), db, nim)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -52,10 +49,7 @@ func CheckAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 				"isAlumni": false,
 			})
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal cek alumni karena " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.check")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -72,63 +66,85 @@ func CheckAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 // @Tags Alumni
 // @Accept json
 // @Produce json
+// @Param fields query string false "Field yang dikembalikan, dipisah koma, contoh id,nama,email"
+// @Param include query string false "Relasi yang di-embed: pekerjaan,files,current_job"
 // @Success 200 {object} map[string]interface{} "Daftar alumni"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /alumni [get]
 func GetAllAlumniService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni", username)
+	sel, err := utils.ParseFieldSelection(c, alumniFields, alumniIncludes)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
 
-	alumni, err := repository.GetAllAlumni(db)
+	alumni, err := repository.GetAllAlumni(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.get")
+	}
+
+	var data interface{} = alumni
+	if !sel.IsEmpty() {
+		if data, err = shapeAlumni(c.UserContext(), db, alumni, sel, gajiViewerOf(c)); err != nil {
+			return apperror.Internal(err, "alumni.get_relations")
+		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data alumni",
 		"success": true,
-		"data":    alumni,
+		"data":    data,
 	})
 }
 
 // GetAlumniByIDService godoc
 // @Summary Dapatkan alumni berdasarkan ID
-// @Description Mengambil data alumni spesifik berdasarkan ID MongoDB
+// @Description Mengambil data alumni spesifik berdasarkan ID MongoDB beserta foto dan daftar sertifikat
 // @Tags Alumni
 // @Accept json
 // @Produce json
 // @Param id path string true "Alumni ID (MongoDB ObjectID)"
-// @Success 200 {object} map[string]interface{} "Data alumni"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma, contoh id,nama,email"
+// @Param include query string false "Relasi yang di-embed: pekerjaan,files,current_job"
+// @Success 200 {object} map[string]interface{} "Data alumni dengan foto dan sertifikat"
 // @Failure 404 {object} map[string]interface{} "Alumni tidak ditemukan"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /alumni/{id} [get]
 func GetAlumniByIDService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
-	log.Printf("User %s mengakses GET /api/alumni/%s", username, id)
+	sel, err := utils.ParseFieldSelection(c, alumniFields, alumniIncludes)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
 
-	alumni, err := repository.GetAlumniByID(db, id)
+	alumni, err := repository.GetAlumniByID(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Alumni tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_found")
+		}
+		return apperror.Internal(err, "alumni.get")
+	}
+
+	// Tanpa fields/include respons tetap AlumniDetail dengan foto dan sertifikat
+	if !sel.IsEmpty() {
+		shaped, err := shapeAlumni(c.UserContext(), db, []model.Alumni{*alumni}, sel, gajiViewerOf(c))
+		if err != nil {
+			return apperror.Internal(err, "alumni.get_relations")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-			"success": false,
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Berhasil mengambil data alumni",
+			"success": true,
+			"data":    shaped[0],
 		})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data alumni",
 		"success": true,
-		"data":    alumni,
+		"data": model.AlumniDetail{
+			Alumni:      *alumni,
+			AlumniFiles: getAlumniFiles(c.UserContext(), db, id),
+		},
 	})
 }
 
@@ -144,32 +160,21 @@ func GetAlumniByIDService(c *fiber.Ctx, db *mongo.Database) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /alumni [post]
 func CreateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
-	log.Printf("Admin %s menambah alumni baru", username)
+	logger.From(c).Info("Admin menambah alumni")
 
 	var req model.CreateAlumniRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Format data tidak valid: " + err.Error(),
-			"success": false,
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
 	}
 
-	// Validasi input
-	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "NIM, nama, jurusan, dan email wajib diisi",
-			"success": false,
-		})
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyJurusan, &req.Jurusan); err != nil {
+		return taxonomyErrorResponse(c, err)
 	}
 
-	alumni, err := repository.CreateAlumni(db, req)
+	alumni, err := repository.CreateAlumni(c.UserContext(), db, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menambah alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.create")
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
@@ -193,40 +198,26 @@ func CreateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /alumni/{id} [put]
 func UpdateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
-	log.Printf("Admin %s mengupdate alumni ID %s", username, id)
+	logger.From(c).Info("Admin mengupdate alumni", "alumni_id", id)
 
 	var req model.UpdateAlumniRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Format data tidak valid: " + err.Error(),
-			"success": false,
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
 	}
 
-	// Validasi input
-	if req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Nama, jurusan, dan email wajib diisi",
-			"success": false,
-		})
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyJurusan, &req.Jurusan); err != nil {
+		return taxonomyErrorResponse(c, err)
 	}
 
-	alumni, err := repository.UpdateAlumni(db, id, req)
+	alumni, err := repository.UpdateAlumni(c.UserContext(), db, id, req)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Alumni tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengupdate alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.update")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -248,23 +239,16 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /alumni/{id} [delete]
 func DeleteAlumniService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
-	log.Printf("Admin %s menghapus alumni ID %s", username, id)
+	logger.From(c).Info("Admin menghapus alumni", "alumni_id", id)
 
-	err := repository.DeleteAlumni(db, id)
+	err := repository.DeleteAlumni(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Alumni tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -283,12 +267,9 @@ func DeleteAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /alumni/statistics [get]
 func GetAlumniStatisticsService(c *fiber.Ctx, db *mongo.Database) error {
-	stats, err := repository.GetAlumniStatistics(db)
+	stats, err := repository.GetAlumniStatistics(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil statistik alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.statistics")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -309,13 +290,23 @@ func GetAlumniStatisticsService(c *fiber.Ctx, db *mongo.Database) error {
 // @Param sortBy query string false "Field untuk sorting (default: created_at)"
 // @Param order query string false "Urutan sorting asc/desc (default: desc)"
 // @Param search query string false "Pencarian berdasarkan nama atau email"
+// @Param search_mode query string false "regex (default, substring) atau fulltext (ranking, toleransi typo, highlight)"
+// @Param cursor query string false "Pagination cursor (keyset), kosongkan untuk halaman pertama lalu pakai meta.next_cursor/prev_cursor"
+// @Param count query bool false "false untuk melewati penghitungan total (meta.total = -1)"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma, contoh id,nama,email"
+// @Param include query string false "Relasi yang di-embed: pekerjaan,files,current_job"
+// @Param jurusan query string false "Daftar jurusan dipisah koma"
+// @Param angkatan query string false "Angkatan atau rentang, contoh 2018..2020"
+// @Param tahun_lulus query string false "Tahun lulus atau rentang, contoh 2022..2024"
+// @Param has_job query bool false "Punya riwayat pekerjaan"
+// @Param employed query bool false "Sedang bekerja"
+// @Param bidang_industri query string false "Bidang industri pekerjaan aktif, dipisah koma"
+// @Param created query string false "Rentang tanggal dibuat, contoh 2024-01-01..2024-06-30"
 // @Success 200 {object} map[string]interface{} "Daftar alumni dengan metadata pagination"
+// @Failure 400 {object} map[string]interface{} "Filter tidak valid"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /cleanarch/alumni [get]
 func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni dengan pagination", username)
-
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
@@ -331,6 +322,11 @@ func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *mongo.Database) error {
 		limit = 10
 	}
 
+	searchMode, err := utils.ParseSearchMode(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
 	// Create pagination params
 	params := model.PaginationParams{
 		Page:   page,
@@ -338,24 +334,64 @@ func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *mongo.Database) error {
 		SortBy: sortBy,
 		Order:  strings.ToLower(order),
 		Search: search,
+
+		SearchMode: searchMode,
+	}
+
+	cursorMode := utils.ParseCursorParams(c, &params)
+	if cursorMode && searchMode == model.SearchModeFullText && search != "" {
+		return apperror.BadRequest("request.cursor_fulltext")
+	}
+
+	sel, err := utils.ParseFieldSelection(c, alumniFields, alumniIncludes)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+	params.Fields = sel.Fields
+
+	alumniFilter, err := utils.ParseAlumniFilter(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_filter").WithDetails(fiber.Map{"reason": err.Error()})
 	}
 
 	// Get data with pagination
-	alumni, total, err := repository.GetAllAlumniWithPagination(db, params)
+	var data interface{}
+	var total int
+	var cursorPage model.CursorPage
+	if searchMode == model.SearchModeFullText && search != "" {
+		data, total, err = repository.SearchAlumniFullText(c.UserContext(), db, params, alumniFilter)
+	} else if cursorMode {
+		data, total, cursorPage, err = repository.GetAllAlumniByCursor(c.UserContext(), db, params, alumniFilter)
+	} else {
+		data, total, err = repository.GetAllAlumniWithPagination(c.UserContext(), db, params, alumniFilter)
+	}
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return apperror.BadRequest("request.invalid_cursor")
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.get")
+	}
+
+	if !sel.IsEmpty() {
+		switch list := data.(type) {
+		case []model.Alumni:
+			data, err = shapeAlumni(c.UserContext(), db, list, sel, gajiViewerOf(c))
+		case []model.AlumniSearchHit:
+			data, err = shapeAlumniHits(c.UserContext(), db, list, sel, gajiViewerOf(c))
+		}
+		if err != nil {
+			return apperror.Internal(err, "alumni.get_relations")
+		}
 	}
 
-	// Calculate total pages
-	totalPages := (total + limit - 1) / limit
+	// Calculate total pages, -1 bila count=false
+	totalPages := utils.CalculateTotalPages(total, limit)
 
-	// Create response with pagination metadata
-	response := model.AlumniResponse{
-		Data: alumni,
-		Meta: model.MetaInfo{
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data alumni",
+		"success": true,
+		"data":    data,
+		"meta": model.MetaInfo{
 			Page:   page,
 			Limit:  limit,
 			Total:  total,
@@ -363,14 +399,11 @@ func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *mongo.Database) error {
 			SortBy: sortBy,
 			Order:  order,
 			Search: search,
-		},
-	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Berhasil mengambil data alumni",
-		"success": true,
-		"data":    response.Data,
-		"meta":    response.Meta,
+			SearchMode: searchMode,
+			NextCursor: cursorPage.Next,
+			PrevCursor: cursorPage.Prev,
+		},
 	})
 }
 
@@ -386,12 +419,9 @@ func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *mongo.Database) error {
 // @Router /alumni/trash [get]
 func GetTrashedAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	// admin only via route middleware
-	list, err := repository.GetTrashedAlumni(db)
+	list, err := repository.GetTrashedAlumni(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil trash: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.get_trash")
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data trash",
@@ -421,17 +451,11 @@ func SoftDeleteAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 		deletedByID = &userID
 	}
 
-	if err := repository.SoftDeleteAlumni(db, idStr, deletedByID); err != nil {
+	if err := repository.SoftDeleteAlumni(c.UserContext(), db, idStr, deletedByID); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Data alumni tidak ditemukan atau sudah dihapus",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_found_or_deleted")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal soft delete: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.soft_delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -455,17 +479,11 @@ func SoftDeleteAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 func RestoreAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	idStr := c.Params("id")
 
-	if err := repository.RestoreAlumni(db, idStr); err != nil {
+	if err := repository.RestoreAlumni(c.UserContext(), db, idStr); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Data tidak ditemukan atau belum di-trash",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_in_trash")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal restore: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.restore")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -489,17 +507,11 @@ func RestoreAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 func HardDeleteAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	idStr := c.Params("id")
 
-	if err := repository.HardDeleteAlumni(db, idStr); err != nil {
+	if err := repository.HardDeleteAlumni(c.UserContext(), db, idStr); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"message": "Hapus permanen hanya untuk data alumni yang ada di trash",
-				"success": false,
-			})
+			return apperror.BadRequest("alumni.hard_delete_not_trashed")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal hapus permanen: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.hard_delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
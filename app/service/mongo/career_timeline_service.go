@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// defaultTimelineGapDays is the shortest gap reported on a timeline. Jeda
+// lebih pendek dianggap wajar saat pindah kerja.
+const defaultTimelineGapDays = 90
+
+// pekerjaanRules are the consistency rules checked when a job is created or
+// updated. Tiap aturan bisa dimatikan lewat env tanpa rilis baru.
+type pekerjaanRules struct {
+	EndAfterStart bool // PEKERJAAN_RULE_END_AFTER_START, tanggal selesai tidak sebelum tanggal mulai
+	NoFutureStart bool // PEKERJAAN_RULE_NO_FUTURE_START, tanggal mulai tidak di masa depan
+	StatusEndDate bool // PEKERJAAN_RULE_STATUS_END_DATE, status sesuai dengan tanggal selesai
+	NoOverlap     bool // PEKERJAAN_RULE_NO_OVERLAP, tidak beririsan dengan pekerjaan lain
+}
+
+func loadPekerjaanRules() pekerjaanRules {
+	return pekerjaanRules{
+		EndAfterStart: env.Bool("PEKERJAAN_RULE_END_AFTER_START", true),
+		NoFutureStart: env.Bool("PEKERJAAN_RULE_NO_FUTURE_START", true),
+		StatusEndDate: env.Bool("PEKERJAAN_RULE_STATUS_END_DATE", true),
+		NoOverlap:     env.Bool("PEKERJAAN_RULE_NO_OVERLAP", false),
+	}
+}
+
+// timelineGapDays returns TIMELINE_GAP_DAYS, default 90
+func timelineGapDays() int {
+	return int(env.Int64("TIMELINE_GAP_DAYS", defaultTimelineGapDays))
+}
+
+// dateOnly truncates t to midnight UTC, sama seperti tanggal dari request
+func dateOnly(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func today() time.Time {
+	return dateOnly(time.Now())
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func hasDate(d *model.Date) bool {
+	return d != nil && !d.IsZero()
+}
+
+// jobPeriod returns the period of a job. Pekerjaan tanpa tanggal selesai
+// dianggap berjalan sampai hari ini.
+func jobPeriod(start model.Date, end *model.Date, now time.Time) (from, to time.Time, ongoing bool) {
+	from = dateOnly(start.Time)
+	if !hasDate(end) {
+		if from.After(now) {
+			return from, from, true
+		}
+		return from, now, true
+	}
+	return from, dateOnly(end.Time), false
+}
+
+// statusIssue checks status_pekerjaan against the end date. Status aktif
+// dengan tanggal selesai di masa depan tetap sah, misalnya pegawai kontrak.
+func statusIssue(status string, end *model.Date, now time.Time) string {
+	switch status {
+	case model.StatusPekerjaanAktif:
+		if hasDate(end) && dateOnly(end.Time).Before(now) {
+			return model.TimelineActiveWithEndDate
+		}
+	case model.StatusPekerjaanSelesai, model.StatusPekerjaanResigned:
+		if !hasDate(end) {
+			return model.TimelineFinishedWithoutEndDate
+		}
+	}
+	return ""
+}
+
+func datePtr(t time.Time) *model.Date {
+	return &model.Date{Time: t}
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// buildCareerTimeline orders the jobs of an alumni chronologically and flags
+// overlaps, gaps of at least gapDays and status/tanggal yang tidak konsisten
+func buildCareerTimeline(alumniID string, jobs []model.PekerjaanAlumni, now time.Time, gapDays int) model.CareerTimeline {
+	sorted := make([]model.PekerjaanAlumni, len(jobs))
+	copy(sorted, jobs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].TanggalMulaiKerja.Time, sorted[j].TanggalMulaiKerja.Time
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return sorted[i].ID.Hex() < sorted[j].ID.Hex()
+	})
+
+	timeline := model.CareerTimeline{
+		AlumniID:         alumniID,
+		Entries:          make([]model.CareerTimelineEntry, 0, len(sorted)),
+		Issues:           []model.TimelineIssue{},
+		GapThresholdDays: gapDays,
+	}
+
+	type period struct {
+		job      model.PekerjaanAlumni
+		from, to time.Time
+	}
+	var periods []period
+
+	for _, job := range sorted {
+		from, to, ongoing := jobPeriod(job.TanggalMulaiKerja, job.TanggalSelesaiKerja, now)
+		entry := model.CareerTimelineEntry{PekerjaanAlumni: job, Ongoing: ongoing}
+		id := job.ID.Hex()
+
+		if to.Before(from) {
+			timeline.Issues = append(timeline.Issues, model.TimelineIssue{
+				Type:         model.TimelineEndBeforeStart,
+				PekerjaanIDs: []string{id},
+				From:         datePtr(from),
+				To:           datePtr(to),
+				Args:         []interface{}{job.NamaPerusahaan},
+			})
+		} else {
+			entry.DurationDays = daysBetween(from, to) + 1
+			periods = append(periods, period{job, from, to})
+		}
+
+		switch statusIssue(job.StatusPekerjaan, job.TanggalSelesaiKerja, now) {
+		case model.TimelineActiveWithEndDate:
+			timeline.Issues = append(timeline.Issues, model.TimelineIssue{
+				Type:         model.TimelineActiveWithEndDate,
+				PekerjaanIDs: []string{id},
+				To:           datePtr(to),
+				Args:         []interface{}{job.NamaPerusahaan, formatDate(to)},
+			})
+		case model.TimelineFinishedWithoutEndDate:
+			timeline.Issues = append(timeline.Issues, model.TimelineIssue{
+				Type:         model.TimelineFinishedWithoutEndDate,
+				PekerjaanIDs: []string{id},
+				Args:         []interface{}{job.NamaPerusahaan, job.StatusPekerjaan},
+			})
+		}
+
+		timeline.Entries = append(timeline.Entries, entry)
+	}
+
+	// Irisan dicek untuk setiap pasangan, periode sudah urut berdasarkan tanggal mulai
+	for i := range periods {
+		for j := i + 1; j < len(periods); j++ {
+			a, b := periods[i], periods[j]
+			if b.from.After(a.to) {
+				break
+			}
+			to := a.to
+			if b.to.Before(to) {
+				to = b.to
+			}
+			timeline.Issues = append(timeline.Issues, model.TimelineIssue{
+				Type:         model.TimelineOverlap,
+				PekerjaanIDs: []string{a.job.ID.Hex(), b.job.ID.Hex()},
+				From:         datePtr(b.from),
+				To:           datePtr(to),
+				Days:         daysBetween(b.from, to) + 1,
+				Args:         []interface{}{a.job.NamaPerusahaan, b.job.NamaPerusahaan},
+			})
+		}
+	}
+
+	// Gabungkan periode untuk menghitung total pengalaman dan jeda
+	for i := 0; i < len(periods); {
+		from, to := periods[i].from, periods[i].to
+		last := periods[i].job
+		j := i + 1
+		for ; j < len(periods) && !periods[j].from.After(to.AddDate(0, 0, 1)); j++ {
+			if periods[j].to.After(to) {
+				to = periods[j].to
+				last = periods[j].job
+			}
+		}
+		timeline.TotalExperienceDays += daysBetween(from, to) + 1
+
+		if j < len(periods) {
+			next := periods[j]
+			gap := daysBetween(to, next.from) - 1
+			if gap >= gapDays {
+				timeline.Issues = append(timeline.Issues, model.TimelineIssue{
+					Type:         model.TimelineGap,
+					PekerjaanIDs: []string{last.ID.Hex(), next.job.ID.Hex()},
+					From:         datePtr(to.AddDate(0, 0, 1)),
+					To:           datePtr(next.from.AddDate(0, 0, -1)),
+					Days:         gap,
+					Args:         []interface{}{gap, last.NamaPerusahaan, next.job.NamaPerusahaan},
+				})
+			}
+		}
+		i = j
+	}
+
+	return timeline
+}
+
+// checkPekerjaanRules returns the rule violations of a job being saved.
+// others adalah pekerjaan lain milik alumni yang sama, tanpa pekerjaan yang sedang diupdate.
+func checkPekerjaanRules(rules pekerjaanRules, start model.Date, end *model.Date, status string, others []model.PekerjaanAlumni, now time.Time) []model.PekerjaanViolation {
+	var violations []model.PekerjaanViolation
+	if start.IsZero() {
+		return violations
+	}
+
+	from, to, _ := jobPeriod(start, end, now)
+	if rules.EndAfterStart && to.Before(from) {
+		violations = append(violations, model.PekerjaanViolation{Code: model.PekerjaanRuleEndBeforeStart})
+	}
+	if rules.NoFutureStart && from.After(now) {
+		violations = append(violations, model.PekerjaanViolation{Code: model.PekerjaanRuleFutureStart})
+	}
+	if rules.StatusEndDate {
+		switch statusIssue(status, end, now) {
+		case model.TimelineActiveWithEndDate:
+			violations = append(violations, model.PekerjaanViolation{Code: model.PekerjaanRuleActiveWithEndDate})
+		case model.TimelineFinishedWithoutEndDate:
+			violations = append(violations, model.PekerjaanViolation{Code: model.PekerjaanRuleFinishedWithoutEndDate})
+		}
+	}
+	if rules.NoOverlap && !to.Before(from) {
+		for _, other := range others {
+			otherFrom, otherTo, _ := jobPeriod(other.TanggalMulaiKerja, other.TanggalSelesaiKerja, now)
+			if otherTo.Before(otherFrom) {
+				continue
+			}
+			if !from.After(otherTo) && !otherFrom.After(to) {
+				violations = append(violations, model.PekerjaanViolation{
+					Code: model.PekerjaanRuleOverlap,
+					Args: []interface{}{other.NamaPerusahaan, formatDate(otherFrom), formatDate(otherTo)},
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// localizeTimelineIssues fills the messages of issues in lang
+func localizeTimelineIssues(lang string, issues []model.TimelineIssue) {
+	for i := range issues {
+		issues[i].Message = apperror.Message(lang, "timeline."+issues[i].Type, issues[i].Args...)
+	}
+}
+
+// localizePekerjaanViolations fills the messages of violations in lang
+func localizePekerjaanViolations(lang string, violations []model.PekerjaanViolation) {
+	for i := range violations {
+		violations[i].Message = apperror.Message(lang, "pekerjaan_rule."+violations[i].Code, violations[i].Args...)
+	}
+}
+
+// validatePekerjaanConsistency checks a job against the configured rules.
+// excludeID diisi saat update agar pekerjaan itu sendiri tidak dianggap beririsan.
+func validatePekerjaanConsistency(ctx context.Context, db *mongo.Database, alumniID, excludeID string, start model.Date, end *model.Date, status string) ([]model.PekerjaanViolation, error) {
+	rules := loadPekerjaanRules()
+
+	var others []model.PekerjaanAlumni
+	if rules.NoOverlap {
+		jobs, err := repository.GetPekerjaanByAlumniID(ctx, db, alumniID)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			if job.ID.Hex() != excludeID {
+				others = append(others, job)
+			}
+		}
+	}
+
+	return checkPekerjaanRules(rules, start, end, status, others, today()), nil
+}
+
+// GetCareerTimelineService godoc
+// @Summary Timeline karier alumni
+// @Description Mengurutkan riwayat pekerjaan alumni secara kronologis dan menandai pekerjaan yang beririsan, jeda antar pekerjaan, serta status yang tidak sesuai dengan tanggal selesai
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "Alumni ID (MongoDB ObjectID)"
+// @Success 200 {object} map[string]interface{} "Timeline karier alumni"
+// @Failure 404 {object} map[string]interface{} "Alumni tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security Bearer
+// @Router /alumni/{id}/timeline [get]
+func GetCareerTimelineService(c *fiber.Ctx, db *mongo.Database) error {
+	id := c.Params("id")
+
+	alumni, err := repository.GetAlumniByID(c.UserContext(), db, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return apperror.NotFound("alumni.not_found")
+		}
+		return apperror.Internal(err, "alumni.get")
+	}
+
+	jobs, err := repository.GetPekerjaanByAlumniID(c.UserContext(), db, alumni.ID.Hex())
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.get")
+	}
+	hideGajiEach(gajiViewerOf(c), jobs)
+
+	timeline := buildCareerTimeline(alumni.ID.Hex(), jobs, today(), timelineGapDays())
+	localizeTimelineIssues(apperror.Language(c), timeline.Issues)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil timeline karier alumni",
+		"success": true,
+		"data":    timeline,
+	})
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"clean-arch/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testDate(s string) model.Date {
+	t, _ := time.Parse("2006-01-02", s)
+	return model.Date{Time: t}
+}
+
+func testJob(company, start, end, status string) model.PekerjaanAlumni {
+	job := model.PekerjaanAlumni{
+		ID:                primitive.NewObjectID(),
+		NamaPerusahaan:    company,
+		TanggalMulaiKerja: testDate(start),
+		StatusPekerjaan:   status,
+	}
+	if end != "" {
+		d := testDate(end)
+		job.TanggalSelesaiKerja = &d
+	}
+	return job
+}
+
+func issueTypes(issues []model.TimelineIssue) map[string]int {
+	types := map[string]int{}
+	for _, issue := range issues {
+		types[issue.Type]++
+	}
+	return types
+}
+
+func TestBuildCareerTimeline(t *testing.T) {
+	now := testDate("2024-06-30").Time
+	jobs := []model.PekerjaanAlumni{
+		testJob("C", "2023-06-01", "", "aktif"),
+		testJob("A", "2020-01-01", "2020-12-31", "selesai"),
+		testJob("B", "2020-12-01", "2021-05-31", "resigned"),
+		testJob("D", "2022-01-01", "2022-03-31", "aktif"),
+		testJob("E", "2022-04-01", "", "selesai"),
+	}
+
+	timeline := buildCareerTimeline("alumni", jobs, now, 90)
+
+	var order string
+	for _, entry := range timeline.Entries {
+		order += entry.NamaPerusahaan
+	}
+	if order != "ABDEC" {
+		t.Fatalf("entries order = %q, want ABDEC", order)
+	}
+	if !timeline.Entries[4].Ongoing || timeline.Entries[0].DurationDays != 366 {
+		t.Errorf("unexpected entry computation: %+v", timeline.Entries)
+	}
+
+	types := issueTypes(timeline.Issues)
+	want := map[string]int{
+		model.TimelineOverlap:                2, // A-B, E-C (E tanpa tanggal selesai berjalan sampai hari ini)
+		model.TimelineGap:                    1, // Juni-Desember 2021
+		model.TimelineActiveWithEndDate:      1, // D
+		model.TimelineFinishedWithoutEndDate: 1, // E
+	}
+	for typ, n := range want {
+		if types[typ] != n {
+			t.Errorf("%s issues = %d, want %d (%+v)", typ, types[typ], n, timeline.Issues)
+		}
+	}
+
+	for _, issue := range timeline.Issues {
+		if issue.Type == model.TimelineGap && issue.Days != 214 {
+			t.Errorf("gap days = %d, want 214", issue.Days)
+		}
+	}
+
+	// A-B digabung (2020-01-01..2021-05-31), D-E-C digabung (2022-01-01..2024-06-30)
+	if want := 517 + 912; timeline.TotalExperienceDays != want {
+		t.Errorf("total experience = %d, want %d", timeline.TotalExperienceDays, want)
+	}
+}
+
+func TestBuildCareerTimelineGapThreshold(t *testing.T) {
+	now := testDate("2024-06-30").Time
+	jobs := []model.PekerjaanAlumni{
+		testJob("A", "2020-01-01", "2020-12-31", "selesai"),
+		testJob("B", "2021-02-01", "2021-12-31", "selesai"),
+	}
+
+	if n := issueTypes(buildCareerTimeline("alumni", jobs, now, 90).Issues)[model.TimelineGap]; n != 0 {
+		t.Errorf("31 day gap should be below the threshold, got %d issues", n)
+	}
+	if n := issueTypes(buildCareerTimeline("alumni", jobs, now, 30).Issues)[model.TimelineGap]; n != 1 {
+		t.Errorf("31 day gap should be reported with threshold 30, got %d issues", n)
+	}
+}
+
+func TestCheckPekerjaanRules(t *testing.T) {
+	now := testDate("2024-06-30").Time
+	rules := pekerjaanRules{EndAfterStart: true, NoFutureStart: true, StatusEndDate: true, NoOverlap: true}
+	end := func(s string) *model.Date {
+		d := testDate(s)
+		return &d
+	}
+	others := []model.PekerjaanAlumni{testJob("A", "2020-01-01", "2020-12-31", "selesai")}
+
+	tests := []struct {
+		name   string
+		start  string
+		end    *model.Date
+		status string
+		want   int
+	}{
+		{"valid", "2021-01-01", end("2021-12-31"), "selesai", 0},
+		{"valid ongoing", "2021-01-01", nil, "aktif", 0},
+		{"contract ending later", "2021-01-01", end("2025-01-01"), "aktif", 0},
+		{"end before start", "2021-06-01", end("2021-01-01"), "selesai", 1},
+		{"future start", "2024-07-01", nil, "aktif", 1},
+		{"active already ended", "2021-01-01", end("2021-12-31"), "aktif", 1},
+		{"finished without end", "2021-01-01", nil, "resigned", 1},
+		{"overlap", "2020-06-01", end("2021-06-01"), "selesai", 1},
+	}
+
+	for _, tt := range tests {
+		got := checkPekerjaanRules(rules, testDate(tt.start), tt.end, tt.status, others, now)
+		if len(got) != tt.want {
+			t.Errorf("%s: violations = %v, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if got := checkPekerjaanRules(pekerjaanRules{}, testDate("2021-06-01"), end("2021-01-01"), "aktif", others, now); len(got) != 0 {
+		t.Errorf("disabled rules should not report violations, got %v", got)
+	}
+}
+
+func TestLocalizeTimelineAndRules(t *testing.T) {
+	issues := []model.TimelineIssue{{Type: model.TimelineGap, Args: []interface{}{120, "A", "B"}}}
+	localizeTimelineIssues("en", issues)
+	if want := "120 days without a job between A and B"; issues[0].Message != want {
+		t.Errorf("gap message = %q, want %q", issues[0].Message, want)
+	}
+	localizeTimelineIssues("id", issues)
+	if want := "Jeda 120 hari tanpa pekerjaan antara A dan B"; issues[0].Message != want {
+		t.Errorf("gap message = %q, want %q", issues[0].Message, want)
+	}
+
+	now := testDate("2024-06-30").Time
+	others := []model.PekerjaanAlumni{testJob("A", "2020-01-01", "2020-12-31", "selesai")}
+	violations := checkPekerjaanRules(pekerjaanRules{NoOverlap: true}, testDate("2020-06-01"), nil, "aktif", others, now)
+	if len(violations) != 1 || violations[0].Code != model.PekerjaanRuleOverlap {
+		t.Fatalf("violations = %+v, want one overlap", violations)
+	}
+	localizePekerjaanViolations("en", violations)
+	if want := "The period overlaps the job at A (2020-01-01 to 2020-12-31)"; violations[0].Message != want {
+		t.Errorf("overlap message = %q, want %q", violations[0].Message, want)
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetCertificateReviewQueueService lists certificates for verifiers.
+// Query: status (pending|approved|rejected|all, default pending), owner_type,
+// uploaded_from, uploaded_to (YYYY-MM-DD), search on file name, page, limit,
+// sortBy and order. Oldest uploads come first unless sortBy/order are given.
+func GetCertificateReviewQueueService(c *fiber.Ctx, db *mongo.Database) error {
+	params := utils.ParsePaginationParams(c)
+	if c.Query("sortBy") == "" {
+		params.SortBy = "uploaded_at"
+	}
+	if c.Query("order") == "" {
+		params.Order = "asc"
+	}
+
+	filter, err := parseCertificateReviewFilter(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	files, total, err := repository.GetCertificatesForReview(c.UserContext(), db, filter, params)
+	if err != nil {
+		return apperror.Internal(err, "certificate.get")
+	}
+
+	items := []model.CertificateReview{}
+	for i := range files {
+		items = append(items, model.CertificateReview{
+			FileResponse: *toFileResponse(c.UserContext(), &files[i], db),
+			Owner:        getUserInfo(c.UserContext(), db, fileOwnerType(&files[i]), files[i].UserID),
+		})
+	}
+
+	return c.JSON(utils.CreatePaginationResponse(items, params, total))
+}
+
+func parseCertificateReviewFilter(c *fiber.Ctx) (model.CertificateReviewFilter, error) {
+	filter := model.CertificateReviewFilter{
+		Status:    c.Query("status", model.VerificationPending),
+		OwnerType: c.Query("owner_type"),
+	}
+
+	switch filter.Status {
+	case model.VerificationPending, model.VerificationApproved, model.VerificationRejected:
+	case "all":
+		filter.Status = ""
+	default:
+		return filter, fmt.Errorf("status must be pending, approved, rejected or all")
+	}
+
+	if filter.OwnerType != "" && filter.OwnerType != model.OwnerTypeUser && filter.OwnerType != model.OwnerTypeAlumni {
+		return filter, fmt.Errorf("owner_type must be user or alumni")
+	}
+
+	var err error
+	filter.UploadedFrom, filter.UploadedTo, err = parseDateRange(c, "uploaded_from", "uploaded_to")
+	return filter, err
+}
+
+// ApproveCertificateService approves a pending certificate (verifier or admin)
+func ApproveCertificateService(c *fiber.Ctx, db *mongo.Database) error {
+	return decideCertificate(c, db, model.VerificationApproved, nil)
+}
+
+// RejectCertificateService rejects a pending certificate with a reason (verifier or admin)
+func RejectCertificateService(c *fiber.Ctx, db *mongo.Database) error {
+	var req model.RejectCertificateRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return apperror.BadRequest("certificate.reason_required")
+	}
+
+	return decideCertificate(c, db, model.VerificationRejected, &reason)
+}
+
+func decideCertificate(c *fiber.Ctx, db *mongo.Database, status string, reason *string) error {
+	file, err := repository.GetFileByID(c.UserContext(), db, c.Params("id"))
+	if err != nil || file.Category != "certificate" {
+		return apperror.NotFound("certificate.not_found")
+	}
+
+	if file.ScanStatus == model.ScanStatusQuarantined || file.ScanStatus == model.ScanStatusPending {
+		return apperror.Conflict("certificate.not_scanned")
+	}
+
+	verifierID, _ := c.Locals("user_id").(string)
+	updated, err := repository.UpdateFileVerification(c.UserContext(), db, file.ID, model.VerificationPending, status, reason, verifierID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return apperror.Conflict("certificate.already_reviewed")
+		}
+		return apperror.Internal(err, "certificate.update")
+	}
+
+	owner := fileOwner{Type: fileOwnerType(updated), ID: updated.UserID}
+	if owner.Type == model.OwnerTypeAlumni {
+		refreshAlumniVerification(c.UserContext(), db, owner.ID)
+	}
+
+	if status == model.VerificationApproved {
+		notifyOwner(c.UserContext(), db, owner, model.NotificationCertificateApproved, &updated.ID, updated.OriginalName)
+	} else {
+		notifyOwner(c.UserContext(), db, owner, model.NotificationCertificateRejected, &updated.ID, updated.OriginalName, *reason)
+	}
+
+	message := "Certificate approved"
+	if status == model.VerificationRejected {
+		message = "Certificate rejected"
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": message,
+		"data":    toFileResponse(c.UserContext(), updated, db),
+	})
+}
+
+// refreshAlumniVerification sets the verified badge when the alumni has at
+// least one approved certificate left
+func refreshAlumniVerification(ctx context.Context, db *mongo.Database, alumniID string) {
+	count, err := repository.CountApprovedCertificates(ctx, db, model.OwnerTypeAlumni, alumniID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to count approved certificates", "alumni_id", alumniID, "error", err)
+		return
+	}
+	if err := repository.SetAlumniVerified(ctx, db, alumniID, count > 0); err != nil {
+		logger.FromContext(ctx).Error("Failed to update verified badge", "alumni_id", alumniID, "error", err)
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"clean-arch/app/model/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParseCertificateReviewFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		status  string
+		wantErr bool
+	}{
+		{"", model.VerificationPending, false},
+		{"?status=all", "", false},
+		{"?status=rejected&owner_type=alumni", model.VerificationRejected, false},
+		{"?status=unknown", "", true},
+		{"?owner_type=admin", "", true},
+		{"?uploaded_from=01-01-2024", "", true},
+	}
+
+	for _, tt := range tests {
+		var got model.CertificateReviewFilter
+		var err error
+
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			got, err = parseCertificateReviewFilter(c)
+			return nil
+		})
+		if _, e := app.Test(httptest.NewRequest("GET", "/"+tt.query, nil)); e != nil {
+			t.Fatalf("request %q: %v", tt.query, e)
+		}
+
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %v, got %v", tt.query, tt.wantErr, err)
+			continue
+		}
+		if !tt.wantErr && got.Status != tt.status {
+			t.Errorf("%q: expected status %q, got %q", tt.query, tt.status, got.Status)
+		}
+	}
+}
+
+func TestParseCertificateReviewFilterDateRange(t *testing.T) {
+	var got model.CertificateReviewFilter
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		got, _ = parseCertificateReviewFilter(c)
+		return nil
+	})
+	app.Test(httptest.NewRequest("GET", "/?uploaded_from=2024-01-01&uploaded_to=2024-01-31", nil))
+
+	if got.UploadedFrom == nil || got.UploadedFrom.Format("2006-01-02") != "2024-01-01" {
+		t.Errorf("unexpected uploaded_from %v", got.UploadedFrom)
+	}
+	if got.UploadedTo == nil || got.UploadedTo.Format("2006-01-02 15:04") != "2024-01-31 23:59" {
+		t.Errorf("uploaded_to should include the whole day, got %v", got.UploadedTo)
+	}
+}
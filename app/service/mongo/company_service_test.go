@@ -0,0 +1,81 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/utils/mongo"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testCompany(nama string, aliases ...string) model.Company {
+	return model.Company{ID: primitive.NewObjectID(), Nama: nama, Aliases: aliases, Keys: utils.CompanyKeys(nama, aliases)}
+}
+
+func TestBuildCompany(t *testing.T) {
+	website := " https://telkom.co.id "
+	company, err := buildCompany(" PT Telkom Indonesia ", []string{"Telkom", " "}, "Telekomunikasi", "Bandung", &website)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if company.Nama != "PT Telkom Indonesia" || !reflect.DeepEqual(company.Aliases, []string{"Telkom"}) {
+		t.Errorf("company = %+v", company)
+	}
+	if company.Website == nil || *company.Website != "https://telkom.co.id" {
+		t.Errorf("website = %v", company.Website)
+	}
+	if !reflect.DeepEqual(company.Keys, []string{"telkom indonesia", "telkom"}) {
+		t.Errorf("keys = %v", company.Keys)
+	}
+
+	invalid := "telkom.co.id"
+	if _, err := buildCompany("Telkom", nil, "", "", &invalid); err == nil {
+		t.Error("website without scheme should be rejected")
+	}
+	if _, err := buildCompany("  ", nil, "", "", nil); err == nil {
+		t.Error("empty name should be rejected")
+	}
+}
+
+func TestSuggestCompanies(t *testing.T) {
+	telkom := testCompany("PT Telkom Indonesia", "Telkom")
+	tokopedia := testCompany("Tokopedia")
+	mandiri := testCompany("Bank Mandiri")
+	companies := []model.Company{mandiri, telkom, tokopedia}
+
+	got := suggestCompanies(companies, "Telkom Indonesa", 5, primitive.NilObjectID)
+	if len(got) == 0 || got[0].ID != telkom.ID {
+		t.Fatalf("suggestions = %+v, want Telkom first", got)
+	}
+	for _, s := range got {
+		if s.ID == mandiri.ID {
+			t.Error("unrelated company should not be suggested")
+		}
+	}
+
+	if got := suggestCompanies(companies, "Telkom", 5, telkom.ID); len(got) != 0 {
+		t.Errorf("excluded company suggested: %+v", got)
+	}
+}
+
+func TestMergeCompanyInto(t *testing.T) {
+	website := "https://telkom.co.id"
+	target := testCompany("PT Telkom Indonesia")
+	source := testCompany("Telkom Indonesia Tbk", "Telkom", "TELKOM")
+	source.BidangIndustri = "Telekomunikasi"
+	source.Website = &website
+
+	mergeCompanyInto(&target, source)
+
+	if !reflect.DeepEqual(target.Aliases, []string{"Telkom"}) {
+		t.Errorf("aliases = %v, want [Telkom]", target.Aliases)
+	}
+	if !reflect.DeepEqual(target.Keys, []string{"telkom indonesia", "telkom"}) {
+		t.Errorf("keys = %v", target.Keys)
+	}
+	if target.BidangIndustri != "Telekomunikasi" || target.Website == nil {
+		t.Errorf("missing details not copied: %+v", target)
+	}
+}
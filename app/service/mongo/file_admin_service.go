@@ -0,0 +1,137 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetAdminFilesService lists files of every owner for admins.
+// Query: category, owner_type, owner_id, uploader_type, uploaded_by,
+// file_type (e.g. application/pdf or image/*), min_size, max_size (bytes),
+// uploaded_from, uploaded_to (YYYY-MM-DD), deleted (exclude|include|only),
+// current_only, search on file name, page, limit, sortBy and order.
+// Totals per category cover every matching file, not only the current page.
+func GetAdminFilesService(c *fiber.Ctx, db *mongo.Database) error {
+	params := utils.ParsePaginationParams(c)
+	if c.Query("sortBy") == "" {
+		params.SortBy = "uploaded_at"
+	}
+
+	filter, err := parseFileAdminFilter(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	files, total, err := repository.GetFilesForAdmin(c.UserContext(), db, filter, params)
+	if err != nil {
+		return apperror.Internal(err, "file.get")
+	}
+
+	totals, err := repository.GetFileTotalsByCategory(c.UserContext(), db, filter, params.Search)
+	if err != nil {
+		return apperror.Internal(err, "file.totals")
+	}
+
+	items := []model.FileAdminItem{}
+	for i := range files {
+		items = append(items, model.FileAdminItem{
+			FileResponse: *toFileResponse(c.UserContext(), &files[i], db),
+			Owner:        getUserInfo(c.UserContext(), db, fileOwnerType(&files[i]), files[i].UserID),
+			DeletedAt:    files[i].DeletedAt,
+		})
+	}
+
+	response := utils.CreatePaginationResponse(items, params, total)
+	response["totals"] = totals
+	return c.JSON(response)
+}
+
+func parseFileAdminFilter(c *fiber.Ctx) (model.FileAdminFilter, error) {
+	filter := model.FileAdminFilter{
+		Category:     c.Query("category"),
+		OwnerType:    c.Query("owner_type"),
+		OwnerID:      c.Query("owner_id"),
+		UploaderType: c.Query("uploader_type"),
+		UploadedBy:   c.Query("uploaded_by"),
+		FileType:     c.Query("file_type"),
+		Deleted:      c.Query("deleted", model.FileDeletedExclude),
+		CurrentOnly:  c.QueryBool("current_only"),
+	}
+
+	if filter.Category != "" && !slices.Contains(fileCategories, filter.Category) {
+		return filter, fmt.Errorf("category must be one of %s", strings.Join(fileCategories, ", "))
+	}
+	if filter.OwnerType != "" && filter.OwnerType != model.OwnerTypeUser && filter.OwnerType != model.OwnerTypeAlumni {
+		return filter, fmt.Errorf("owner_type must be user or alumni")
+	}
+	if filter.UploaderType != "" && filter.UploaderType != model.OwnerTypeUser && filter.UploaderType != model.OwnerTypeAlumni {
+		return filter, fmt.Errorf("uploader_type must be user or alumni")
+	}
+
+	switch filter.Deleted {
+	case model.FileDeletedExclude, model.FileDeletedInclude, model.FileDeletedOnly:
+	default:
+		return filter, fmt.Errorf("deleted must be exclude, include or only")
+	}
+
+	var err error
+	if filter.MinSize, err = parseSizeQuery(c, "min_size"); err != nil {
+		return filter, err
+	}
+	if filter.MaxSize, err = parseSizeQuery(c, "max_size"); err != nil {
+		return filter, err
+	}
+	if filter.MinSize != nil && filter.MaxSize != nil && *filter.MinSize > *filter.MaxSize {
+		return filter, fmt.Errorf("min_size must not be greater than max_size")
+	}
+
+	filter.UploadedFrom, filter.UploadedTo, err = parseDateRange(c, "uploaded_from", "uploaded_to")
+	return filter, err
+}
+
+func parseSizeQuery(c *fiber.Ctx, key string) (*int64, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative number of bytes", key)
+	}
+	return &n, nil
+}
+
+// parseDateRange reads two YYYY-MM-DD query values. The end date is
+// inclusive, sampai akhir hari tersebut.
+func parseDateRange(c *fiber.Ctx, fromKey, toKey string) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if v := c.Query(fromKey); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be formatted as YYYY-MM-DD", fromKey)
+		}
+		from = &t
+	}
+	if v := c.Query(toKey); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be formatted as YYYY-MM-DD", toKey)
+		}
+		t = t.Add(24*time.Hour - time.Nanosecond)
+		to = &t
+	}
+
+	return from, to, nil
+}
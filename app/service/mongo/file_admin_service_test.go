@@ -0,0 +1,48 @@
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"clean-arch/app/model/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParseFileAdminFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{"", false},
+		{"?category=photo&owner_type=alumni&deleted=only&min_size=10&max_size=20", false},
+		{"?category=video", true},
+		{"?uploader_type=admin", true},
+		{"?deleted=yes", true},
+		{"?min_size=-1", true},
+		{"?min_size=20&max_size=10", true},
+		{"?uploaded_to=2024/01/31", true},
+	}
+
+	for _, tt := range tests {
+		var got model.FileAdminFilter
+		var err error
+
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			got, err = parseFileAdminFilter(c)
+			return nil
+		})
+		if _, e := app.Test(httptest.NewRequest("GET", "/"+tt.query, nil)); e != nil {
+			t.Fatalf("request %q: %v", tt.query, e)
+		}
+
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %v, got %v", tt.query, tt.wantErr, err)
+			continue
+		}
+		if tt.query == "" && got.Deleted != model.FileDeletedExclude {
+			t.Errorf("deleted files should be excluded by default, got %q", got.Deleted)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clean-arch/app/model/mongo"
+)
+
+func TestWriteExportArchive(t *testing.T) {
+	dir := t.TempDir()
+	present := filepath.Join(dir, "a.pdf")
+	if err := os.WriteFile(present, []byte("%PDF-1.4 ijazah"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	alumni := &model.Alumni{NIM: "2019001", Nama: "Budi Santoso", Jurusan: "Informatika", Angkatan: 2019, TahunLulus: 2023}
+	used := map[string]bool{}
+	entries := []exportEntry{
+		{Alumni: alumni, File: model.File{FilePath: present, OriginalName: "ijazah.pdf", Category: "certificate", Version: 2},
+			ArchivePath: exportArchivePath(used, alumni.NIM, alumni.Nama, "certificate", 2, "ijazah.pdf")},
+		{Alumni: alumni, File: model.File{FilePath: filepath.Join(dir, "gone.pdf"), OriginalName: "gone.pdf", Category: "transcript", Version: 1},
+			ArchivePath: exportArchivePath(used, alumni.NIM, alumni.Nama, "transcript", 1, "gone.pdf")},
+	}
+
+	var buf bytes.Buffer
+	if err := writeExportArchive(&buf, entries); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+
+	names := map[string]*zip.File{}
+	for _, f := range zr.File {
+		names[f.Name] = f
+	}
+	if _, ok := names["2019001_Budi_Santoso/certificate/v2_ijazah.pdf"]; !ok {
+		t.Errorf("certificate missing from archive, got %v", names)
+	}
+	if len(zr.File) != 2 {
+		t.Errorf("expected certificate and manifest only, got %d entries", len(zr.File))
+	}
+
+	rc, err := names[exportManifestName].Open()
+	if err != nil {
+		t.Fatalf("manifest missing: %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d", len(rows))
+	}
+	status := len(exportManifestHeader) - 1
+	if rows[1][status] != "included" || rows[1][status-1] == "" {
+		t.Errorf("expected included row with checksum, got %v", rows[1])
+	}
+	if rows[2][status] != "missing" {
+		t.Errorf("expected missing row, got %v", rows[2])
+	}
+}
+
+func TestExportArchivePathIsUnique(t *testing.T) {
+	used := map[string]bool{}
+	first := exportArchivePath(used, "2019001", "Budi", "photo", 0, "foto profil.jpg")
+	second := exportArchivePath(used, "2019001", "Budi", "photo", 0, "foto profil.jpg")
+
+	if first != "2019001_Budi/photo/foto_profil.jpg" {
+		t.Errorf("unexpected path %q", first)
+	}
+	if second != "2019001_Budi/photo/foto_profil_2.jpg" {
+		t.Errorf("expected numbered duplicate, got %q", second)
+	}
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultFileRetention = 30 * 24 * time.Hour
+	defaultFileGCPeriod  = 24 * time.Hour
+	// File di disk yang lebih muda dari grace period tidak dianggap orphan,
+	// karena bisa saja upload-nya belum sempat membuat record
+	defaultFileGCGrace = time.Hour
+
+	gcActionNone          = "none"
+	gcActionPurged        = "purged"
+	gcActionRecordRemoved = "record_removed"
+	gcActionObjectRemoved = "object_removed"
+)
+
+var (
+	fileGCMutex      sync.Mutex
+	errFileGCRunning = errors.New("file garbage collection is already running")
+)
+
+// FileGCOptions controls one reconciliation run
+type FileGCOptions struct {
+	DryRun    bool
+	Retention time.Duration
+	Grace     time.Duration
+}
+
+// fileGCOptionsFromEnv reads FILE_RETENTION, FILE_GC_GRACE and FILE_GC_DRY_RUN.
+// Like the admin endpoint, scheduled runs only report unless FILE_GC_DRY_RUN
+// is explicitly false.
+func fileGCOptionsFromEnv() FileGCOptions {
+	return FileGCOptions{
+		DryRun:    env.Bool("FILE_GC_DRY_RUN", true),
+		Retention: env.Duration("FILE_RETENTION", defaultFileRetention),
+		Grace:     env.Duration("FILE_GC_GRACE", defaultFileGCGrace),
+	}
+}
+
+// ReconcileFiles purges files soft-deleted longer than the retention period,
+// finds records whose bytes are missing and bytes on disk without a record.
+// In dry-run mode findings are only reported.
+func ReconcileFiles(ctx context.Context, db *mongo.Database, opts FileGCOptions) (*model.FileGCReport, error) {
+	if !fileGCMutex.TryLock() {
+		return nil, errFileGCRunning
+	}
+	defer fileGCMutex.Unlock()
+
+	now := utils.GetNowTime()
+	report := &model.FileGCReport{
+		DryRun:         opts.DryRun,
+		Retention:      opts.Retention.String(),
+		StartedAt:      now,
+		Purged:         []model.FileGCItem{},
+		MissingObjects: []model.FileGCItem{},
+		OrphanObjects:  []model.FileGCItem{},
+		Errors:         []string{},
+	}
+
+	// 1. Hard purge file yang sudah soft-delete melewati retention
+	expired, err := repository.GetFilesDeletedBefore(ctx, db, now.Add(-opts.Retention))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range expired {
+		item := model.FileGCItem{FileID: file.ID.Hex(), FilePath: file.FilePath, FileSize: file.FileSize, Action: gcActionNone}
+		if !opts.DryRun {
+			if err := os.Remove(file.FilePath); err != nil && !os.IsNotExist(err) {
+				report.Errors = append(report.Errors, "remove "+file.FilePath+": "+err.Error())
+				report.Purged = append(report.Purged, item)
+				continue
+			}
+			if err := repository.HardDeleteFile(ctx, db, file.ID); err != nil && err != mongo.ErrNoDocuments {
+				report.Errors = append(report.Errors, "delete record "+file.ID.Hex()+": "+err.Error())
+				report.Purged = append(report.Purged, item)
+				continue
+			}
+			item.Action = gcActionPurged
+			report.BytesReclaimed += file.FileSize
+		}
+		report.Purged = append(report.Purged, item)
+	}
+
+	// 2. Record tanpa object di disk
+	files, err := repository.GetAllFilesIncludingDeleted(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(files))
+	for _, file := range files {
+		known[filepath.Clean(file.FilePath)] = true
+
+		if file.DeletedAt != nil {
+			continue
+		}
+		if _, err := os.Stat(file.FilePath); err == nil || !os.IsNotExist(err) {
+			continue
+		}
+
+		item := model.FileGCItem{FileID: file.ID.Hex(), FilePath: file.FilePath, FileSize: file.FileSize, Action: gcActionNone}
+		if !opts.DryRun {
+			// Soft delete lewat jalur yang sama dengan DeleteFileService agar
+			// versi sebelumnya dipromosikan dan record ikut di-purge setelah retention
+			if err := deleteFile(ctx, db, &file, "system"); err != nil {
+				report.Errors = append(report.Errors, "delete record "+file.ID.Hex()+": "+err.Error())
+			} else {
+				item.Action = gcActionRecordRemoved
+			}
+		}
+		report.MissingObjects = append(report.MissingObjects, item)
+	}
+
+	sessions, err := repository.GetAllUploadSessions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		known[filepath.Clean(session.TempPath)] = true
+	}
+
+	// 3. Object di disk tanpa record
+	err = filepath.WalkDir(uploadBasePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			return nil
+		}
+		if d.IsDir() || known[filepath.Clean(path)] {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			return nil
+		}
+		if now.Sub(info.ModTime()) < opts.Grace {
+			return nil
+		}
+
+		item := model.FileGCItem{FilePath: path, FileSize: info.Size(), Action: gcActionNone}
+		if !opts.DryRun {
+			if err := os.Remove(path); err != nil {
+				report.Errors = append(report.Errors, "remove "+path+": "+err.Error())
+			} else {
+				item.Action = gcActionObjectRemoved
+				report.BytesReclaimed += info.Size()
+			}
+		}
+		report.OrphanObjects = append(report.OrphanObjects, item)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	report.FinishedAt = utils.GetNowTime()
+	return report, nil
+}
+
+// RunFileGCService runs the reconciler on demand (admin only).
+// Dry run is the default; pass ?dry_run=false to apply changes.
+func RunFileGCService(c *fiber.Ctx, db *mongo.Database) error {
+	opts := fileGCOptionsFromEnv()
+	opts.DryRun = c.QueryBool("dry_run", true)
+
+	if v := c.Query("retention"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return apperror.BadRequest("file.invalid_retention")
+		}
+		opts.Retention = d
+	}
+
+	report, err := ReconcileFiles(c.UserContext(), db, opts)
+	if err != nil {
+		if err == errFileGCRunning {
+			return apperror.Conflict("file.gc_running")
+		}
+		return apperror.Internal(err, "file.reconcile")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "File reconciliation finished",
+		"data":    report,
+	})
+}
+
+// StartFileGarbageCollector runs the reconciler every FILE_GC_INTERVAL
+// (default 24h). Set FILE_GC_INTERVAL=0 to disable the schedule.
+func StartFileGarbageCollector(db *mongo.Database) {
+	interval := env.Duration("FILE_GC_INTERVAL", defaultFileGCPeriod)
+	if interval <= 0 {
+		return
+	}
+
+	ctx := logger.Background("file_gc")
+	l := logger.FromContext(ctx)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			report, err := ReconcileFiles(ctx, db, fileGCOptionsFromEnv())
+			if err != nil {
+				l.Error("File garbage collection failed", "error", err)
+				continue
+			}
+			l.Info("File garbage collection finished", "dry_run", report.DryRun, "purged", len(report.Purged), "missing_objects", len(report.MissingObjects), "orphan_objects", len(report.OrphanObjects), "bytes_reclaimed", report.BytesReclaimed, "errors", len(report.Errors))
+		}
+	}()
+}
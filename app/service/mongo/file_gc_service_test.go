@@ -0,0 +1,13 @@
+package service
+
+import "testing"
+
+func TestFileGCOptionsFromEnvDryRun(t *testing.T) {
+	tests := map[string]bool{"": true, "true": true, "invalid": true, "false": false, "0": false}
+	for value, want := range tests {
+		t.Setenv("FILE_GC_DRY_RUN", value)
+		if got := fileGCOptionsFromEnv().DryRun; got != want {
+			t.Errorf("FILE_GC_DRY_RUN=%q: DryRun = %v, want %v", value, got, want)
+		}
+	}
+}
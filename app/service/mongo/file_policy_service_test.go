@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+
+	"clean-arch/app/model/mongo"
+)
+
+func TestCheckUploadFile(t *testing.T) {
+	policy := &model.UploadPolicy{
+		Category:          "certificate",
+		MaxFileSize:       1024,
+		AllowedTypes:      []string{"application/pdf"},
+		AllowedExtensions: []string{".pdf", ".jpg"},
+	}
+
+	tests := []struct {
+		name        string
+		fileName    string
+		contentType string
+		size        int64
+		wantKey     string
+	}{
+		{"allowed", "sertifikat.pdf", "application/pdf", 1024, ""},
+		{"too large", "sertifikat.pdf", "application/pdf", 1025, "upload.file_too_large"},
+		{"extension case", "FOTO.JPG", "application/octet-stream", 10, ""},
+		{"mime only", "sertifikat", "application/pdf", 10, ""},
+		{"type not allowed", "script.exe", "application/octet-stream", 10, "upload.type_not_allowed"},
+	}
+
+	for _, tt := range tests {
+		violation := checkUploadFile(policy, tt.fileName, tt.contentType, tt.size)
+		if tt.wantKey == "" {
+			if violation != nil {
+				t.Errorf("%s: unexpected violation %s", tt.name, violation.Key)
+			}
+			continue
+		}
+		if violation == nil || violation.Key != tt.wantKey {
+			t.Errorf("%s: expected %s, got %v", tt.name, tt.wantKey, violation)
+		}
+	}
+
+	if violation := checkUploadFile(&model.UploadPolicy{}, "apa.saja", "", 1<<30); violation != nil {
+		t.Errorf("empty policy should allow everything, got %s", violation.Key)
+	}
+}
+
+func TestCheckUploadQuota(t *testing.T) {
+	policy := &model.UploadPolicy{Category: "portfolio", MaxFiles: 3, MaxTotalBytes: 1000}
+
+	tests := []struct {
+		name    string
+		usage   model.CategoryUsage
+		size    int64
+		wantKey string
+	}{
+		{"under limits", model.CategoryUsage{FileCount: 2, TotalBytes: 500}, 500, ""},
+		{"max files reached", model.CategoryUsage{FileCount: 3, TotalBytes: 100}, 10, "upload.max_files"},
+		{"quota exceeded", model.CategoryUsage{FileCount: 1, TotalBytes: 900}, 101, "upload.quota_exceeded"},
+	}
+
+	for _, tt := range tests {
+		violation := checkUploadQuota(policy, &tt.usage, tt.size)
+		if tt.wantKey == "" {
+			if violation != nil {
+				t.Errorf("%s: unexpected violation %s", tt.name, violation.Key)
+			}
+			continue
+		}
+		if violation == nil || violation.Key != tt.wantKey {
+			t.Errorf("%s: expected %s, got %v", tt.name, tt.wantKey, violation)
+		}
+	}
+
+	// Batas 0 berarti tidak dibatasi
+	unlimited := &model.UploadPolicy{Category: "portfolio"}
+	if violation := checkUploadQuota(unlimited, &model.CategoryUsage{FileCount: 100, TotalBytes: 1 << 40}, 1); violation != nil {
+		t.Errorf("unlimited policy should not refuse, got %s", violation.Key)
+	}
+}
@@ -3,211 +3,155 @@ package service
 import (
 	"clean-arch/app/model/mongo"
 	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/metrics"
 	"clean-arch/utils/mongo"
+	"context"
+	"io"
 	"mime/multipart"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 const (
-	// File size limits
-	maxPhotoSize       = 1 * 1024 * 1024 // 1MB
-	maxCertificateSize = 2 * 1024 * 1024 // 2MB
+	// Default file size limits, see defaultUploadPolicy
+	maxPhotoSize       = 1 * 1024 * 1024  // 1MB
+	maxCertificateSize = 2 * 1024 * 1024  // 2MB
+	maxTranscriptSize  = 10 * 1024 * 1024 // 10MB
+	maxPortfolioSize   = 25 * 1024 * 1024 // 25MB
 	uploadBasePath     = "./uploads"
 	photosDir          = "photos"
 	certificatesDir    = "certificates"
 )
 
-// UploadPhotoService handles photo upload
-func UploadPhotoService(c *fiber.Ctx, db *mongo.Database) error {
-	currentUserID := c.Locals("user_id")
-	currentRole := c.Locals("role")
-
-	if currentUserID == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "User ID not found in token",
-		})
-	}
-
-	userID := currentUserID.(string)
-
-	targetUserID := c.FormValue("user_id")
-	if targetUserID != "" {
-		// Only admin can upload for another user
-		if currentRole != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Only admin can upload for other users",
-			})
-		}
-		userID = targetUserID
-	}
-
-	// Get file from form
-	fileHeader, err := c.FormFile("file")
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "File is required",
-			"error":   err.Error(),
-		})
-	}
-
-	if fileHeader.Size > maxPhotoSize {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Photo size must not exceed 1MB",
-		})
-	}
-
-	allowedMimeTypes := map[string]bool{
-		"image/jpeg": true,
-		"image/png":  true,
-		"image/jpg":  true,
-	}
-
-	contentType := fileHeader.Header.Get("Content-Type")
-	if !allowedMimeTypes[contentType] {
-		ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
-		if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Only JPEG and PNG formats are allowed",
-			})
-		}
-	}
-
-	_, err = repository.GetUserByID(db, userID)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "User not found",
-		})
-	}
+// fileCategories lists the supported upload categories
+var fileCategories = []string{"photo", "certificate", "transcript", "portfolio"}
 
-	uploadedFile, err := saveFile(db, fileHeader, "photo", userID, currentUserID.(string))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to upload file",
-			"error":   err.Error(),
-		})
-	}
+// fileOwner identifies a user or alumni that owns or uploads a file
+type fileOwner struct {
+	Type string
+	ID   string
+}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"message": "Photo uploaded successfully",
-		"data":    toFileResponse(uploadedFile, db),
-	})
+// UploadPhotoService handles photo upload for users and, on the alumni
+// routes, for the logged in alumni
+func UploadPhotoService(c *fiber.Ctx, db *mongo.Database) error {
+	return uploadCategoryFile(c, db, "photo", "Photo uploaded successfully")
 }
 
 // UploadCertificateService handles certificate/diploma upload
 func UploadCertificateService(c *fiber.Ctx, db *mongo.Database) error {
-	currentUserID := c.Locals("user_id")
-	currentRole := c.Locals("role")
+	return uploadCategoryFile(c, db, "certificate", "Certificate uploaded successfully")
+}
 
-	if currentUserID == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "User ID not found in token",
-		})
+func uploadCategoryFile(c *fiber.Ctx, db *mongo.Database, category, successMessage string) error {
+	uploader, ok := currentFileOwner(c)
+	if !ok {
+		return apperror.Unauthorized("auth.user_id_missing")
 	}
 
-	userID := currentUserID.(string)
-
+	owner := uploader
 	targetUserID := c.FormValue("user_id")
-	if targetUserID != "" {
-		// Only admin can upload for another user
-		if currentRole != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Only admin can upload for other users",
-			})
+	targetAlumniID := c.FormValue("alumni_id")
+	if targetUserID != "" || targetAlumniID != "" {
+		// Only admin can upload for another user or alumni
+		if c.Locals("role") != "admin" {
+			return apperror.Forbidden("upload.admin_only")
+		}
+		if targetAlumniID != "" {
+			owner = fileOwner{Type: model.OwnerTypeAlumni, ID: targetAlumniID}
+		} else {
+			owner = fileOwner{Type: model.OwnerTypeUser, ID: targetUserID}
 		}
-		userID = targetUserID
 	}
 
 	// Get file from form
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "File is required",
-			"error":   err.Error(),
-		})
+		return apperror.BadRequest("file.required").Wrap(err)
 	}
 
-	if fileHeader.Size > maxCertificateSize {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Certificate size must not exceed 2MB",
-		})
+	if !fileOwnerExists(c.UserContext(), db, owner) {
+		key := "user.not_found"
+		if owner.Type == model.OwnerTypeAlumni {
+			key = "alumni.not_found"
+		}
+		return apperror.NotFound(key)
 	}
 
-	contentType := fileHeader.Header.Get("Content-Type")
-	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
-
-	if contentType != "application/pdf" && ext != ".pdf" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Only PDF format is allowed",
-		})
+	if err := enforceUploadPolicy(c.UserContext(), db, category, owner, fileHeader); err != nil {
+		return err
 	}
 
-	_, err = repository.GetUserByID(db, userID)
+	uploadedFile, err := saveFile(c.UserContext(), db, fileHeader, category, owner, uploader)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "User not found",
-		})
+		return apperror.Internal(err, "upload.failed")
 	}
 
-	uploadedFile, err := saveFile(db, fileHeader, "certificate", userID, currentUserID.(string))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to upload file",
-			"error":   err.Error(),
-		})
+	if uploadedFile.ScanStatus == model.ScanStatusQuarantined {
+		return rejectQuarantinedFile(c, uploadedFile)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
-		"message": "Certificate uploaded successfully",
-		"data":    toFileResponse(uploadedFile, db),
+		"message": successMessage,
+		"data":    toFileResponse(c.UserContext(), uploadedFile, db),
 	})
 }
 
-// GetFilesService retrieves files for specific user
+// GetFilesService retrieves files for specific user or alumni
 func GetFilesService(c *fiber.Ctx, db *mongo.Database) error {
-	userID := c.Query("user_id")
+	owner := fileOwner{Type: model.OwnerTypeUser, ID: c.Query("user_id")}
+	if alumniID := c.Query("alumni_id"); alumniID != "" {
+		owner = fileOwner{Type: model.OwnerTypeAlumni, ID: alumniID}
+	}
 	category := c.Query("category") // "photo" atau "certificate"
 
-	if userID == "" || category == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "user_id and category are required",
-		})
+	if owner.ID == "" || category == "" {
+		return apperror.BadRequest("file.owner_category_required")
 	}
 
-	files, err := repository.GetFileByUserID(db, userID, category)
+	return respondOwnerFiles(c, db, owner, category)
+}
+
+// GetOwnFilesService retrieves files of the logged in alumni
+func GetOwnFilesService(c *fiber.Ctx, db *mongo.Database) error {
+	owner, ok := currentFileOwner(c)
+	if !ok {
+		return apperror.Unauthorized("auth.user_id_missing")
+	}
+
+	category := c.Query("category")
+	if category == "" {
+		return apperror.BadRequest("file.category_required")
+	}
+
+	return respondOwnerFiles(c, db, owner, category)
+}
+
+// respondOwnerFiles lists the current version of an owner's files, or every
+// version with ?all_versions=true
+func respondOwnerFiles(c *fiber.Ctx, db *mongo.Database, owner fileOwner, category string) error {
+	var files []model.File
+	var err error
+	if c.QueryBool("all_versions") {
+		files, err = repository.GetFileVersions(c.UserContext(), db, owner.Type, owner.ID, category)
+	} else {
+		files, err = repository.GetFilesByOwner(c.UserContext(), db, owner.Type, owner.ID, category)
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to retrieve files",
-			"error":   err.Error(),
-		})
+		return apperror.Internal(err, "file.get")
 	}
 
 	var responses []model.FileResponse
 	for _, file := range files {
-		responses = append(responses, *toFileResponse(&file, db))
+		responses = append(responses, *toFileResponse(c.UserContext(), &file, db))
 	}
 
 	return c.JSON(fiber.Map{
@@ -221,32 +165,18 @@ func GetFilesService(c *fiber.Ctx, db *mongo.Database) error {
 func DeleteFileService(c *fiber.Ctx, db *mongo.Database) error {
 	fileID := c.Params("id")
 
-	file, err := repository.GetFileByID(db, fileID)
+	file, err := repository.GetFileByID(c.UserContext(), db, fileID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "File not found",
-		})
+		return apperror.NotFound("file.not_found")
 	}
 
-	currentUserID := c.Locals("user_id")
-	currentRole := c.Locals("role")
-
-	if currentRole != "admin" && currentUserID != file.UserID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": "You can only delete your own files",
-		})
+	current, _ := currentFileOwner(c)
+	if c.Locals("role") != "admin" && !ownsFile(current, file) {
+		return apperror.Forbidden("file.delete_own_only")
 	}
 
-	// Soft delete
-	err = repository.DeleteFile(db, fileID, currentUserID.(string))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to delete file",
-			"error":   err.Error(),
-		})
+	if err := deleteFile(c.UserContext(), db, file, current.ID); err != nil {
+		return apperror.Internal(err, "file.delete")
 	}
 
 	return c.JSON(fiber.Map{
@@ -255,61 +185,202 @@ func DeleteFileService(c *fiber.Ctx, db *mongo.Database) error {
 	})
 }
 
-// Helper function to save file to disk and database
-func saveFile(db *mongo.Database, fileHeader *multipart.FileHeader, category, userID, uploadedBy string) (*model.File, error) {
-	uploadDir := filepath.Join(uploadBasePath, category)
-	if err := os.MkdirAll(uploadDir, os.ModePerm); err != nil {
-		return nil, err
+// deleteFile soft deletes file and keeps its version chain and the alumni
+// verification badge consistent. Dipakai oleh DeleteFileService dan file GC.
+func deleteFile(ctx context.Context, db *mongo.Database, file *model.File, deletedBy string) error {
+	if err := repository.DeleteFile(ctx, db, file.ID.Hex(), deletedBy); err != nil {
+		return err
 	}
 
-	ext := filepath.Ext(fileHeader.Filename)
-	newFileName := uuid.New().String() + ext
-	filePath := filepath.Join(uploadDir, newFileName)
+	// Versi sebelumnya menjadi aktif kembali jika versi aktif dihapus
+	if file.SupersededAt == nil {
+		promoteLatestVersion(ctx, db, file)
+	}
+
+	// Badge verified ikut berubah jika sertifikat yang disetujui dihapus
+	if file.Category == "certificate" && fileOwnerType(file) == model.OwnerTypeAlumni {
+		refreshAlumniVerification(ctx, db, file.UserID)
+	}
+	return nil
+}
+
+// DownloadFileService streams a stored file to its owner or an admin.
+// Files that are quarantined or still waiting for a scan are not served.
+func DownloadFileService(c *fiber.Ctx, db *mongo.Database) error {
+	fileID := c.Params("id")
+
+	file, err := repository.GetFileByID(c.UserContext(), db, fileID)
+	if err != nil {
+		return apperror.NotFound("file.not_found")
+	}
+
+	current, _ := currentFileOwner(c)
+	if c.Locals("role") != "admin" && !ownsFile(current, file) {
+		return apperror.Forbidden("file.download_own_only")
+	}
+
+	switch file.ScanStatus {
+	case model.ScanStatusQuarantined:
+		return apperror.Forbidden("file.quarantined")
+	case model.ScanStatusPending:
+		return apperror.Conflict("file.scan_pending")
+	}
+
+	return c.Download(file.FilePath, file.OriginalName)
+}
 
+// rejectQuarantinedFile responds to an upload whose content was flagged by the scanner
+func rejectQuarantinedFile(c *fiber.Ctx, file *model.File) error {
+	signature := ""
+	if file.ScanSignature != nil {
+		signature = *file.ScanSignature
+	}
+
+	return apperror.New(fiber.StatusUnprocessableEntity, "file.malware").WithDetails(fiber.Map{
+		"signature": signature,
+		"file_id":   file.ID.Hex(),
+	})
+}
+
+// currentFileOwner returns the user or alumni behind the request token
+func currentFileOwner(c *fiber.Ctx) (fileOwner, bool) {
+	if alumniID, ok := c.Locals("alumni_id").(string); ok && alumniID != "" {
+		return fileOwner{Type: model.OwnerTypeAlumni, ID: alumniID}, true
+	}
+	if userID, ok := c.Locals("user_id").(string); ok && userID != "" {
+		return fileOwner{Type: model.OwnerTypeUser, ID: userID}, true
+	}
+	return fileOwner{}, false
+}
+
+// ownsFile reports whether owner is the owner of file
+func ownsFile(owner fileOwner, file *model.File) bool {
+	return owner.ID == file.UserID && owner.Type == fileOwnerType(file)
+}
+
+// fileOwnerType returns the owner type, treating records without one as user files
+func fileOwnerType(file *model.File) string {
+	if file.OwnerType == "" {
+		return model.OwnerTypeUser
+	}
+	return file.OwnerType
+}
+
+func fileOwnerExists(ctx context.Context, db *mongo.Database, owner fileOwner) bool {
+	if owner.Type == model.OwnerTypeAlumni {
+		_, err := repository.GetAlumniByID(ctx, db, owner.ID)
+		return err == nil
+	}
+	_, err := repository.GetUserByID(ctx, db, owner.ID)
+	return err == nil
+}
+
+// Helper function to save file to disk and database
+func saveFile(ctx context.Context, db *mongo.Database, fileHeader *multipart.FileHeader, category string, owner, uploadedBy fileOwner) (*model.File, error) {
 	file, err := fileHeader.Open()
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
+	return storeFile(ctx, db, file, fileHeader.Filename, fileHeader.Header.Get("Content-Type"), category, owner, uploadedBy)
+}
+
+// storeFile writes src into the category upload directory, scans it and
+// creates the file record. Used by both multipart and resumable uploads.
+func storeFile(ctx context.Context, db *mongo.Database, src io.Reader, originalName, contentType, category string, owner, uploadedBy fileOwner) (*model.File, error) {
+	uploadDir := filepath.Join(uploadBasePath, category)
+	if err := os.MkdirAll(uploadDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	ext := filepath.Ext(originalName)
+	newFileName := uuid.New().String() + ext
+	filePath := filepath.Join(uploadDir, newFileName)
+
 	out, err := os.Create(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer out.Close()
 
-	if _, err := out.ReadFrom(file); err != nil {
+	size, err := out.ReadFrom(src)
+	if err != nil {
+		out.Close()
+		os.Remove(filePath)
 		return nil, err
 	}
+	out.Close()
 
 	fileModel := &model.File{
-		UserID:       userID,
+		UserID:       owner.ID,
+		OwnerType:    owner.Type,
 		FileName:     newFileName,
-		OriginalName: fileHeader.Filename,
+		OriginalName: originalName,
 		FilePath:     filePath,
-		FileSize:     fileHeader.Size,
-		FileType:     fileHeader.Header.Get("Content-Type"),
+		FileSize:     size,
+		FileType:     contentType,
 		Category:     category,
 		UploadedAt:   utils.GetNowTime(),
-		UploadedBy:   uploadedBy,
+		UploadedBy:   uploadedBy.ID,
+		UploaderType: uploadedBy.Type,
+	}
+	if category == "certificate" {
+		fileModel.VerificationStatus = model.VerificationPending
 	}
 
-	if err := repository.CreateFile(db, fileModel); err != nil {
-		os.Remove(filePath)
+	// Scan sebelum metadata disimpan; file terinfeksi tetap dicatat dengan status karantina
+	scanStoredFile(ctx, fileModel)
+
+	version, err := repository.NextFileVersion(ctx, db, owner.Type, owner.ID, category)
+	if err != nil {
+		os.Remove(fileModel.FilePath)
+		return nil, err
+	}
+	fileModel.Version = version
+	if fileModel.ScanStatus == model.ScanStatusQuarantined {
+		// File karantina masuk riwayat tetapi tidak pernah menjadi versi aktif
+		supersededAt := fileModel.UploadedAt
+		fileModel.SupersededAt = &supersededAt
+	}
+
+	if err := repository.CreateFile(ctx, db, fileModel); err != nil {
+		os.Remove(fileModel.FilePath)
 		return nil, err
 	}
 
+	if fileModel.SupersededAt == nil {
+		if err := repository.SupersedeFileVersions(ctx, db, fileModel); err != nil {
+			logger.FromContext(ctx).Error("Failed to supersede previous versions", "category", category, "owner_id", owner.ID, "error", err)
+		}
+		// Sertifikat lama yang disetujui tidak lagi aktif
+		if category == "certificate" && owner.Type == model.OwnerTypeAlumni {
+			refreshAlumniVerification(ctx, db, owner.ID)
+		}
+	}
+
+	metrics.ObserveUpload(category, size)
 	return fileModel, nil
 }
 
 // toFileResponse converts File model to FileResponse
-func toFileResponse(file *model.File, db *mongo.Database) *model.FileResponse {
-	// Fetch user info from users collection
-	userInfo := getUserInfo(db, file.UploadedBy)
+func toFileResponse(ctx context.Context, file *model.File, db *mongo.Database) *model.FileResponse {
+	// Fetch uploader info from users or alumni collection
+	return newFileResponse(file, getUserInfo(ctx, db, file.UploaderType, file.UploadedBy))
+}
+
+// newFileResponse builds the response of a file whose uploader is already known
+func newFileResponse(file *model.File, userInfo model.UserInfo) *model.FileResponse {
+
+	// Sertifikat lama belum punya status verifikasi
+	verificationStatus := file.VerificationStatus
+	if file.Category == "certificate" && verificationStatus == "" {
+		verificationStatus = model.VerificationPending
+	}
 
 	return &model.FileResponse{
 		ID:           file.ID.Hex(),
 		UserID:       file.UserID,
+		OwnerType:    fileOwnerType(file),
 		FileName:     file.FileName,
 		OriginalName: file.OriginalName,
 		FilePath:     file.FilePath,
@@ -318,25 +389,84 @@ func toFileResponse(file *model.File, db *mongo.Database) *model.FileResponse {
 		Category:     file.Category,
 		UploadedAt:   file.UploadedAt,
 		UploadedBy:   userInfo,
+		ScanStatus:   file.ScanStatus,
 		CreatedAt:    file.CreatedAt,
 		UpdatedAt:    file.UpdatedAt,
+
+		VerificationStatus: verificationStatus,
+		RejectionReason:    file.RejectionReason,
+		VerifiedAt:         file.VerifiedAt,
+
+		Version:      file.Version,
+		IsCurrent:    file.SupersededAt == nil,
+		SupersededAt: file.SupersededAt,
 	}
 }
 
-// getUserInfo fetches user info with username, email, and role
-func getUserInfo(db *mongo.Database, userID string) model.UserInfo {
-	user, err := repository.GetUserByID(db, userID)
-	if err != nil {
+// getUserInfo fetches uploader info with username, email, and role.
+// Untuk alumni, username diisi dengan NIM.
+func getUserInfo(ctx context.Context, db *mongo.Database, uploaderType, userID string) model.UserInfo {
+	if uploaderType == model.OwnerTypeAlumni {
+		alumni, err := repository.GetAlumniByID(ctx, db, userID)
+		if err == nil {
+			return model.UserInfo{
+				Username: alumni.NIM,
+				Email:    alumni.Email,
+				Role:     alumni.Role,
+			}
+		}
+	} else if user, err := repository.GetUserByID(ctx, db, userID); err == nil {
 		return model.UserInfo{
-			Username: "Unknown",
-			Email:    "unknown@example.com",
-			Role:     "unknown",
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
 		}
 	}
 
-	return model.UserInfo{
-		Username: user.Username,
-		Email:    user.Email,
-		Role:     user.Role,
+	return unknownUserInfo
+}
+
+// unknownUserInfo is shown when the uploader no longer exists
+var unknownUserInfo = model.UserInfo{
+	Username: "Unknown",
+	Email:    "unknown@example.com",
+	Role:     "unknown",
+}
+
+// getAlumniFiles returns the current photo and the certificates of an alumni.
+// File yang dikarantina tidak ditampilkan.
+func getAlumniFiles(ctx context.Context, db *mongo.Database, alumniID string) model.AlumniFiles {
+	objID, err := primitive.ObjectIDFromHex(alumniID)
+	if err != nil {
+		return buildAlumniFiles(nil)
+	}
+
+	relations, err := repository.GetAlumniRelations(ctx, db, []primitive.ObjectID{objID}, []string{model.IncludeFiles})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to load files of alumni", "alumni_id", alumniID, "error", err)
+	}
+	return buildAlumniFiles(relations[objID].Files)
+}
+
+// buildAlumniFiles groups the files loaded with the alumni relations,
+// terbaru lebih dulu sehingga foto pertama adalah foto aktif
+func buildAlumniFiles(files []model.FileWithUploader) model.AlumniFiles {
+	result := model.AlumniFiles{Certificates: []model.FileResponse{}}
+	for i := range files {
+		userInfo := unknownUserInfo
+		if files[i].Uploader != nil {
+			userInfo = *files[i].Uploader
+		}
+		response := newFileResponse(&files[i].File, userInfo)
+
+		switch files[i].File.Category {
+		case "photo":
+			if result.Photo == nil {
+				result.Photo = response
+			}
+		case "certificate":
+			result.Certificates = append(result.Certificates, *response)
+		}
 	}
+	return result
 }
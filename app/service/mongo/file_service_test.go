@@ -0,0 +1,67 @@
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"clean-arch/app/model/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestOwnsFile(t *testing.T) {
+	alumniFile := &model.File{UserID: "42", OwnerType: model.OwnerTypeAlumni}
+	legacyFile := &model.File{UserID: "42"}
+
+	tests := []struct {
+		name  string
+		owner fileOwner
+		file  *model.File
+		want  bool
+	}{
+		{"alumni owns own file", fileOwner{Type: model.OwnerTypeAlumni, ID: "42"}, alumniFile, true},
+		{"user with same id", fileOwner{Type: model.OwnerTypeUser, ID: "42"}, alumniFile, false},
+		{"other alumni", fileOwner{Type: model.OwnerTypeAlumni, ID: "7"}, alumniFile, false},
+		{"legacy file belongs to user", fileOwner{Type: model.OwnerTypeUser, ID: "42"}, legacyFile, true},
+		{"alumni and legacy file", fileOwner{Type: model.OwnerTypeAlumni, ID: "42"}, legacyFile, false},
+	}
+
+	for _, tt := range tests {
+		if got := ownsFile(tt.owner, tt.file); got != tt.want {
+			t.Errorf("%s: ownsFile = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCurrentFileOwner(t *testing.T) {
+	tests := []struct {
+		locals map[string]string
+		want   fileOwner
+		wantOK bool
+	}{
+		{map[string]string{"alumni_id": "a1"}, fileOwner{Type: model.OwnerTypeAlumni, ID: "a1"}, true},
+		{map[string]string{"user_id": "u1"}, fileOwner{Type: model.OwnerTypeUser, ID: "u1"}, true},
+		{map[string]string{}, fileOwner{}, false},
+	}
+
+	for _, tt := range tests {
+		var got fileOwner
+		var ok bool
+
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			for k, v := range tt.locals {
+				c.Locals(k, v)
+			}
+			got, ok = currentFileOwner(c)
+			return nil
+		})
+		if _, err := app.Test(httptest.NewRequest("GET", "/", nil)); err != nil {
+			t.Fatalf("request %v: %v", tt.locals, err)
+		}
+
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%v: got %+v (%v), want %+v (%v)", tt.locals, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
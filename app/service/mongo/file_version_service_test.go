@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/utils/apperror"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPromotionCandidate(t *testing.T) {
+	superseded := time.Now()
+	deleted := model.File{ID: primitive.NewObjectID(), Version: 3}
+	v2 := model.File{ID: primitive.NewObjectID(), Version: 2, SupersededAt: &superseded}
+	v1 := model.File{ID: primitive.NewObjectID(), Version: 1, SupersededAt: &superseded}
+
+	// Versi tertinggi yang tersisa menjadi aktif
+	if got := promotionCandidate([]model.File{deleted, v2, v1}, &deleted); got == nil || got.ID != v2.ID {
+		t.Errorf("expected version 2 to be promoted, got %+v", got)
+	}
+
+	// Versi karantina dilewati
+	quarantined := v2
+	quarantined.ScanStatus = model.ScanStatusQuarantined
+	if got := promotionCandidate([]model.File{deleted, quarantined, v1}, &deleted); got == nil || got.ID != v1.ID {
+		t.Errorf("expected version 1 when version 2 is quarantined, got %+v", got)
+	}
+
+	// Masih ada versi aktif lain, tidak ada yang dipromosikan
+	current := model.File{ID: primitive.NewObjectID(), Version: 0}
+	if got := promotionCandidate([]model.File{deleted, current, v1}, &deleted); got != nil {
+		t.Errorf("expected no promotion while another version is current, got %+v", got)
+	}
+
+	if got := promotionCandidate([]model.File{deleted}, &deleted); got != nil {
+		t.Errorf("expected no promotion without history, got %+v", got)
+	}
+}
+
+func TestCheckRestorable(t *testing.T) {
+	superseded := time.Now()
+
+	tests := []struct {
+		name    string
+		file    model.File
+		wantKey string
+	}{
+		{"superseded version", model.File{SupersededAt: &superseded, ScanStatus: model.ScanStatusClean}, ""},
+		{"already current", model.File{ScanStatus: model.ScanStatusClean}, "file.already_current"},
+		{"quarantined", model.File{SupersededAt: &superseded, ScanStatus: model.ScanStatusQuarantined}, "file.restore_quarantined"},
+	}
+
+	for _, tt := range tests {
+		err := checkRestorable(&tt.file)
+		if tt.wantKey == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		var appErr *apperror.Error
+		if !errors.As(err, &appErr) || appErr.Key != tt.wantKey {
+			t.Errorf("%s: expected %s, got %v", tt.name, tt.wantKey, err)
+		}
+	}
+}
@@ -1,32 +1,143 @@
 package service
 
 import (
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/health"
+	"clean-arch/utils/mailer"
 	"context"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func CheckpointService(c *fiber.Ctx, db *mongo.Database) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+const (
+	// Hasil readiness dipakai ulang selama HEALTH_CACHE_TTL
+	defaultHealthCacheTTL = 5 * time.Second
+	// Batas waktu setiap check, diatur dengan HEALTH_CHECK_TIMEOUT
+	defaultHealthCheckTimeout = 2 * time.Second
+)
 
-	// Try to ping the database
-	if err := db.Client().Ping(ctx, nil); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal cek database: " + err.Error(),
-			"success": false,
-		})
+// readiness is set by InitReadiness; sebelum itu /readyz menjawab 503
+var readiness *health.Checker
+
+// InitReadiness registers the dependency checks behind /readyz
+func InitReadiness(db *mongo.Database) {
+	readiness = health.NewChecker(
+		env.Duration("HEALTH_CACHE_TTL", defaultHealthCacheTTL),
+		env.Duration("HEALTH_CHECK_TIMEOUT", defaultHealthCheckTimeout),
+		health.Check{Name: "database", Run: func(ctx context.Context) error {
+			return db.Client().Ping(ctx, nil)
+		}},
+		health.Check{Name: "indexes", Run: func(ctx context.Context) error {
+			missing, err := repository.MissingIndexes(ctx, db)
+			if err != nil {
+				return err
+			}
+			if len(missing) > 0 {
+				return fmt.Errorf("%d missing indexes: %s", len(missing), strings.Join(missing, ", "))
+			}
+			return nil
+		}},
+		health.Check{Name: "storage", Run: checkStorageWritable},
+		health.Check{Name: "mailer", Run: checkMailerReachable},
+	)
+}
+
+// DrainReadiness makes /readyz report draining, dipanggil saat shutdown agar
+// load balancer berhenti mengirim request baru
+func DrainReadiness() {
+	if readiness != nil {
+		readiness.Drain()
+	}
+}
+
+// checkStorageWritable writes and removes a probe file in the upload directory
+func checkStorageWritable(ctx context.Context) error {
+	if err := os.MkdirAll(uploadBasePath, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(uploadBasePath, ".readyz-*")
+	if err != nil {
+		return err
 	}
+	defer os.Remove(f.Name())
 
-	dbName := db.Name()
+	if _, err := f.WriteString("ok"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message":  "OK",
-		"success":  true,
-		"database": dbName,
-		"expected": "alumni_db",
-		"matches":  dbName == "alumni_db",
+// checkMailerReachable pings the SMTP server; mailer Noop dilaporkan disabled
+func checkMailerReachable(ctx context.Context) error {
+	if !notificationMailer.Enabled() {
+		return health.ErrDisabled
+	}
+	if p, ok := notificationMailer.(mailer.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// LivenessService godoc
+// @Summary Liveness probe
+// @Description Menandakan proses berjalan, tanpa memeriksa dependensi
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Proses berjalan"
+// @Router /healthz [get]
+func LivenessService(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "OK",
+		"data":    fiber.Map{"status": health.StatusOK},
 	})
 }
+
+// CheckpointService godoc
+// @Summary Liveness probe (deprecated)
+// @Description Alias lama dari /healthz, akan dihapus pada rilis berikutnya
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Proses berjalan"
+// @Deprecated
+// @Router /api/checkpoint [get]
+func CheckpointService(c *fiber.Ctx) error {
+	c.Set("Deprecation", "true")
+	c.Set(fiber.HeaderLink, `</healthz>; rel="successor-version"`)
+	return LivenessService(c)
+}
+
+// ReadinessService godoc
+// @Summary Readiness probe
+// @Description Memeriksa database, storage upload dan mailer. Status dan latency setiap check dikembalikan; hasil di-cache beberapa detik
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Semua dependensi siap"
+// @Failure 503 {object} map[string]interface{} "Ada check yang gagal, aplikasi masih startup atau sedang dimatikan"
+// @Router /readyz [get]
+func ReadinessService(c *fiber.Ctx) error {
+	if readiness == nil {
+		return apperror.New(fiber.StatusServiceUnavailable, "health.starting")
+	}
+
+	report := readiness.Check(c.UserContext())
+	switch report.Status {
+	case health.StatusOK:
+		return c.JSON(fiber.Map{
+			"success": true,
+			"message": "OK",
+			"data":    report,
+		})
+	case health.StatusDraining:
+		return apperror.New(fiber.StatusServiceUnavailable, "health.draining")
+	}
+	return apperror.New(fiber.StatusServiceUnavailable, "health.not_ready").WithDetails(report)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestReadinessService(t *testing.T) {
+	t.Cleanup(func() { readiness = nil })
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/readyz", ReadinessService)
+	status := func() int {
+		resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+
+	readiness = nil
+	if got := status(); got != fiber.StatusServiceUnavailable {
+		t.Errorf("before InitReadiness: %d", got)
+	}
+
+	var dbErr error
+	readiness = health.NewChecker(0, time.Second,
+		health.Check{Name: "database", Run: func(ctx context.Context) error { return dbErr }},
+		health.Check{Name: "mailer", Run: checkMailerReachable},
+	)
+	if got := status(); got != fiber.StatusOK {
+		t.Errorf("healthy: %d", got)
+	}
+
+	dbErr = errors.New("server selection timeout")
+	if got := status(); got != fiber.StatusServiceUnavailable {
+		t.Errorf("database down: %d", got)
+	}
+
+	dbErr = nil
+	DrainReadiness()
+	if got := status(); got != fiber.StatusServiceUnavailable {
+		t.Errorf("draining: %d", got)
+	}
+}
+
+func TestCheckpointAlias(t *testing.T) {
+	app := fiber.New()
+	app.Get("/api/checkpoint", CheckpointService)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/checkpoint", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("status = %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Deprecation") != "true" {
+		t.Errorf("missing Deprecation header")
+	}
+}
+
+func TestCheckStorageWritable(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := checkStorageWritable(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
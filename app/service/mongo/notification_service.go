@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/mailer"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const mailTimeout = 30 * time.Second
+
+var notificationMailer mailer.Mailer = mailer.Noop{}
+
+// SetMailer sets the mailer used to email notifications
+func SetMailer(m mailer.Mailer) {
+	if m == nil {
+		m = mailer.Noop{}
+	}
+	notificationMailer = m
+}
+
+// notifyOwner stores an in-app notification and, when a mailer is
+// configured, emails it to the owner in the background. Judul dan isi diambil
+// dari katalog pesan (notification.<type>_title dan notification.<type>_body)
+// dalam bahasa default, karena notifikasi disimpan sekali dan juga dikirim
+// sebagai email.
+func notifyOwner(ctx context.Context, db *mongo.Database, owner fileOwner, notificationType string, fileID *primitive.ObjectID, args ...interface{}) {
+	lang := apperror.DefaultLanguage()
+	title := apperror.Message(lang, "notification."+notificationType+"_title")
+	message := apperror.Message(lang, "notification."+notificationType+"_body", args...)
+
+	notification := &model.Notification{
+		OwnerType: owner.Type,
+		OwnerID:   owner.ID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		FileID:    fileID,
+	}
+	if err := repository.CreateNotification(ctx, db, notification); err != nil {
+		logger.FromContext(ctx).Error("Failed to store notification", "owner_type", owner.Type, "owner_id", owner.ID, "error", err)
+	}
+
+	if !notificationMailer.Enabled() {
+		return
+	}
+
+	email := ownerEmail(ctx, db, owner)
+	if email == "" {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+		if err := notificationMailer.Send(ctx, email, title, message); err != nil {
+			logger.FromContext(ctx).Error("Failed to email notification", "error", err)
+		}
+	}()
+}
+
+func ownerEmail(ctx context.Context, db *mongo.Database, owner fileOwner) string {
+	if owner.Type == model.OwnerTypeAlumni {
+		if alumni, err := repository.GetAlumniByID(ctx, db, owner.ID); err == nil {
+			return alumni.Email
+		}
+		return ""
+	}
+	if user, err := repository.GetUserByID(ctx, db, owner.ID); err == nil {
+		return user.Email
+	}
+	return ""
+}
+
+// GetNotificationsService lists notifications of the logged in user or alumni.
+// Pass ?unread=true to only list unread ones.
+func GetNotificationsService(c *fiber.Ctx, db *mongo.Database) error {
+	owner, ok := currentFileOwner(c)
+	if !ok {
+		return apperror.Unauthorized("auth.user_id_missing")
+	}
+
+	notifications, err := repository.GetNotificationsByOwner(c.UserContext(), db, owner.Type, owner.ID, c.QueryBool("unread"))
+	if err != nil {
+		return apperror.Internal(err, "notification.get")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Notifications retrieved successfully",
+		"data":    notifications,
+	})
+}
+
+// MarkNotificationReadService marks one of the caller's notifications as read
+func MarkNotificationReadService(c *fiber.Ctx, db *mongo.Database) error {
+	owner, ok := currentFileOwner(c)
+	if !ok {
+		return apperror.Unauthorized("auth.user_id_missing")
+	}
+
+	id := c.Params("id")
+	if !primitive.IsValidObjectID(id) {
+		return apperror.BadRequest("notification.invalid_id")
+	}
+
+	err := repository.MarkNotificationRead(c.UserContext(), db, id, owner.Type, owner.ID)
+	if err == mongo.ErrNoDocuments {
+		return apperror.NotFound("notification.not_found")
+	}
+	if err != nil {
+		return apperror.Internal(err, "notification.update")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Notification marked as read",
+	})
+}
@@ -1,12 +1,15 @@
 package service
 
 import (
-	"log"
+	"errors"
 	"strconv"
 	"strings"
 
 	"clean-arch/app/model/mongo"
 	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/mongo"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -22,16 +25,11 @@ import (
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /pekerjaan [get]
 func GetAllPekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/pekerjaan", username)
-
-	pekerjaan, err := repository.GetAllPekerjaan(db)
+	pekerjaan, err := repository.GetAllPekerjaan(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
+	hideGajiEach(gajiViewerOf(c), pekerjaan)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data pekerjaan",
@@ -52,24 +50,16 @@ func GetAllPekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /pekerjaan/{id} [get]
 func GetPekerjaanByIDService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
-	log.Printf("User %s mengakses GET /api/pekerjaan/%s", username, id)
-
-	pekerjaan, err := repository.GetPekerjaanByID(db, id)
+	pekerjaan, err := repository.GetPekerjaanByID(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
+	hideGaji(gajiViewerOf(c), pekerjaan)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data pekerjaan",
@@ -89,18 +79,13 @@ func GetPekerjaanByIDService(c *fiber.Ctx, db *mongo.Database) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /pekerjaan/alumni/{alumni_id} [get]
 func GetPekerjaanByAlumniIDService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
 	alumniID := c.Params("alumni_id")
 
-	log.Printf("Admin %s mengakses GET /api/pekerjaan/alumni/%s", username, alumniID)
-
-	pekerjaan, err := repository.GetPekerjaanByAlumniID(db, alumniID)
+	pekerjaan, err := repository.GetPekerjaanByAlumniID(c.UserContext(), db, alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
+	hideGajiEach(gajiViewerOf(c), pekerjaan)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data pekerjaan alumni",
@@ -118,44 +103,66 @@ func GetPekerjaanByAlumniIDService(c *fiber.Ctx, db *mongo.Database) error {
 // @Param body body model.CreatePekerjaanRequest true "Data riwayat pekerjaan"
 // @Success 201 {object} map[string]interface{} "Riwayat pekerjaan berhasil dibuat"
 // @Failure 400 {object} map[string]interface{} "Data tidak valid"
+// @Failure 409 {object} map[string]interface{} "Nama perusahaan baru mirip perusahaan lain, kirim new_company atau pilih company_id dari company_suggestions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security Bearer
 // @Router /pekerjaan [post]
 func CreatePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 	alumniID := c.Locals("alumni_id").(string)
-	nama := c.Locals("nama").(string)
-	log.Printf("Alumni %s (ID: %s) menambah pekerjaan baru", nama, alumniID)
+	logger.From(c).Info("Alumni menambah pekerjaan", "alumni_id", alumniID)
 
 	var req model.CreatePekerjaanRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Format data tidak valid: " + err.Error(),
-			"success": false,
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
 	}
 
-	// Validasi input
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Nama perusahaan dan posisi jabatan wajib diisi",
-			"success": false,
-		})
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyStatusPekerjaan, &req.StatusPekerjaan); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyBidangIndustri, &req.BidangIndustri); err != nil {
+		return taxonomyErrorResponse(c, err)
 	}
 
-	pekerjaan, err := repository.CreatePekerjaan(db, req, alumniID)
+	if err := prepareGaji(&req.Gaji, &req.GajiRange); err != nil {
+		return apperror.BadRequest("pekerjaan.invalid_gaji").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	violations, err := validatePekerjaanConsistency(c.UserContext(), db, alumniID, "", req.TanggalMulaiKerja, req.TanggalSelesaiKerja, req.StatusPekerjaan)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menambah pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.check_history")
+	}
+	if len(violations) > 0 {
+		localizePekerjaanViolations(apperror.Language(c), violations)
+		return apperror.BadRequest("pekerjaan.inconsistent").WithDetails(fiber.Map{"violations": violations})
+	}
+
+	pendingCompany, suggestions, err := resolvePekerjaanCompany(c.UserContext(), db, &req.CompanyID, &req.NamaPerusahaan, req.NewCompany, req.BidangIndustri, req.LokasiKerja)
+	if err == errCompanyNotFound {
+		return apperror.BadRequest("pekerjaan.company_not_found")
+	}
+	if err == errCompanyUnconfirmed {
+		return apperror.Conflict("pekerjaan.company_unconfirmed", req.NamaPerusahaan).WithDetails(fiber.Map{"company_suggestions": suggestions})
+	}
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.match_company")
+	}
+
+	pekerjaan, err := repository.CreatePekerjaan(c.UserContext(), db, req, alumniID)
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.create")
+	}
+
+	if pendingCompany != nil {
+		linkNewCompany(c.UserContext(), db, pekerjaan, pendingCompany)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+	response := fiber.Map{
 		"message": "Pekerjaan berhasil ditambahkan",
 		"success": true,
 		"data":    pekerjaan,
-	})
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
 // UpdatePekerjaanService godoc
@@ -170,73 +177,83 @@ func CreatePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 // @Failure 400 {object} map[string]interface{} "Data tidak valid"
 // @Failure 403 {object} map[string]interface{} "Anda tidak punya akses"
 // @Failure 404 {object} map[string]interface{} "Pekerjaan tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "Nama perusahaan baru mirip perusahaan lain, kirim new_company atau pilih company_id dari company_suggestions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security Bearer
 // @Router /pekerjaan/{id} [put]
 func UpdatePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 	alumniID := c.Locals("alumni_id").(string)
-	nama := c.Locals("nama").(string)
 	id := c.Params("id")
 
-	log.Printf("Alumni %s (ID: %s) mengupdate pekerjaan ID %s", nama, alumniID, id)
+	logger.From(c).Info("Alumni mengupdate pekerjaan", "alumni_id", alumniID, "pekerjaan_id", id)
 
-	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(db, id)
+	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
 	if ownerAlumniID != alumniID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Anda hanya dapat mengupdate riwayat pekerjaan milik Anda sendiri",
-			"success": false,
-		})
+		return apperror.Forbidden("pekerjaan.update_own_only")
 	}
 
 	var req model.UpdatePekerjaanRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Format data tidak valid: " + err.Error(),
-			"success": false,
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyStatusPekerjaan, &req.StatusPekerjaan); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyBidangIndustri, &req.BidangIndustri); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+
+	if err := prepareGaji(&req.Gaji, &req.GajiRange); err != nil {
+		return apperror.BadRequest("pekerjaan.invalid_gaji").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	violations, err := validatePekerjaanConsistency(c.UserContext(), db, alumniID, id, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, req.StatusPekerjaan)
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.check_history")
+	}
+	if len(violations) > 0 {
+		localizePekerjaanViolations(apperror.Language(c), violations)
+		return apperror.BadRequest("pekerjaan.inconsistent").WithDetails(fiber.Map{"violations": violations})
 	}
 
-	// Validasi input
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Nama perusahaan dan posisi jabatan wajib diisi",
-			"success": false,
-		})
+	pendingCompany, suggestions, err := resolvePekerjaanCompany(c.UserContext(), db, &req.CompanyID, &req.NamaPerusahaan, req.NewCompany, req.BidangIndustri, req.LokasiKerja)
+	if err == errCompanyNotFound {
+		return apperror.BadRequest("pekerjaan.company_not_found")
+	}
+	if err == errCompanyUnconfirmed {
+		return apperror.Conflict("pekerjaan.company_unconfirmed", req.NamaPerusahaan).WithDetails(fiber.Map{"company_suggestions": suggestions})
+	}
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.match_company")
 	}
 
-	pekerjaan, err := repository.UpdatePekerjaan(db, id, req)
+	pekerjaan, err := repository.UpdatePekerjaan(c.UserContext(), db, id, req)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengupdate pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.update")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+	if pendingCompany != nil {
+		linkNewCompany(c.UserContext(), db, pekerjaan, pendingCompany)
+	}
+
+	response := fiber.Map{
 		"message": "Pekerjaan berhasil diupdate",
 		"success": true,
 		"data":    pekerjaan,
-	})
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 // DeletePekerjaanService godoc
@@ -251,23 +268,16 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /pekerjaan/{id} [delete]
 func DeletePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
-	log.Printf("Admin %s menghapus pekerjaan ID %s", username, id)
+	logger.From(c).Info("Admin menghapus pekerjaan", "pekerjaan_id", id)
 
-	err := repository.DeletePekerjaan(db, id)
+	err := repository.DeletePekerjaan(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -287,13 +297,14 @@ func DeletePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 // @Param sortBy query string false "Field untuk sorting (default: created_at)"
 // @Param order query string false "Urutan sorting asc/desc (default: desc)"
 // @Param search query string false "Pencarian berdasarkan nama perusahaan atau jabatan"
+// @Param search_mode query string false "regex (default, substring) atau fulltext (ranking, toleransi typo, highlight)"
+// @Param cursor query string false "Pagination cursor (keyset), kosongkan untuk halaman pertama lalu pakai meta.next_cursor/prev_cursor"
+// @Param count query bool false "false untuk melewati penghitungan total (meta.total = -1)"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma, contoh id,nama_perusahaan"
 // @Success 200 {object} map[string]interface{} "Daftar riwayat pekerjaan dengan metadata pagination"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /cleanarch/pekerjaan [get]
 func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *mongo.Database) error {
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/pekerjaan dengan pagination", username)
-
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
@@ -309,6 +320,11 @@ func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *mongo.Database) erro
 		limit = 10
 	}
 
+	searchMode, err := utils.ParseSearchMode(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
 	// Create pagination params
 	params := model.PaginationParams{
 		Page:   page,
@@ -316,24 +332,69 @@ func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *mongo.Database) erro
 		SortBy: sortBy,
 		Order:  strings.ToLower(order),
 		Search: search,
+
+		SearchMode: searchMode,
 	}
 
+	cursorMode := utils.ParseCursorParams(c, &params)
+	if cursorMode && searchMode == model.SearchModeFullText && search != "" {
+		return apperror.BadRequest("request.cursor_fulltext")
+	}
+
+	sel, err := utils.ParseFieldSelection(c, pekerjaanFields, nil)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+	params.Fields = sel.Fields
+
 	// Get data with pagination
-	pekerjaan, total, err := repository.GetAllPekerjaanWithPagination(db, params)
+	var data interface{}
+	var total int
+	var cursorPage model.CursorPage
+	if searchMode == model.SearchModeFullText && search != "" {
+		data, total, err = repository.SearchPekerjaanFullText(c.UserContext(), db, params)
+	} else if cursorMode {
+		data, total, cursorPage, err = repository.GetAllPekerjaanByCursor(c.UserContext(), db, params)
+	} else {
+		data, total, err = repository.GetAllPekerjaanWithPagination(c.UserContext(), db, params)
+	}
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return apperror.BadRequest("request.invalid_cursor")
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
-	// Calculate total pages
-	totalPages := (total + limit - 1) / limit
+	switch list := data.(type) {
+	case []model.PekerjaanAlumni:
+		hideGajiEach(gajiViewerOf(c), list)
+	case []model.PekerjaanSearchHit:
+		canView := gajiViewerOf(c)
+		for i := range list {
+			hideGaji(canView, &list[i].PekerjaanAlumni)
+		}
+	}
+
+	if len(sel.Fields) > 0 {
+		switch list := data.(type) {
+		case []model.PekerjaanAlumni:
+			data, err = selectEach(list, sel.Fields)
+		case []model.PekerjaanSearchHit:
+			data, err = selectEach(list, append(sel.Fields, "score", "highlights"))
+		}
+		if err != nil {
+			return apperror.Internal(err, "pekerjaan.get")
+		}
+	}
 
-	// Create response with pagination metadata
-	response := model.PekerjaanResponse{
-		Data: pekerjaan,
-		Meta: model.MetaInfo{
+	// Calculate total pages, -1 bila count=false
+	totalPages := utils.CalculateTotalPages(total, limit)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data pekerjaan",
+		"success": true,
+		"data":    data,
+		"meta": model.MetaInfo{
 			Page:   page,
 			Limit:  limit,
 			Total:  total,
@@ -341,14 +402,11 @@ func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *mongo.Database) erro
 			SortBy: sortBy,
 			Order:  order,
 			Search: search,
-		},
-	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Berhasil mengambil data pekerjaan",
-		"success": true,
-		"data":    response.Data,
-		"meta":    response.Meta,
+			SearchMode: searchMode,
+			NextCursor: cursorPage.Next,
+			PrevCursor: cursorPage.Prev,
+		},
 	})
 }
 
@@ -368,41 +426,28 @@ func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *mongo.Database) erro
 func SoftDeletePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 	alumniID, isAlumni := c.Locals("alumni_id").(string)
 	role := c.Locals("role").(string)
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	// Get the owner of this job record
-	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(db, id)
+	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
 	if isAlumni && role == "alumni" {
 		// Alumni trying to delete - can only delete their own
 		if ownerAlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"message": "Maaf tidak bisa mengubah atau mengedit data selain diri sendiri",
-				"success": false,
-			})
+			return apperror.Forbidden("pekerjaan.own_data_only")
 		}
-		log.Printf("Alumni ID %s menghapus pekerjaan ID %s miliknya sendiri", alumniID, id)
+		logger.From(c).Info("Alumni menghapus pekerjaan miliknya", "alumni_id", alumniID, "pekerjaan_id", id)
 	} else if role == "admin" {
 		// Admin can delete any
-		log.Printf("Admin %s menghapus pekerjaan ID %s", username, id)
+		logger.From(c).Info("Admin menghapus pekerjaan", "pekerjaan_id", id)
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Anda tidak memiliki akses untuk menghapus pekerjaan",
-			"success": false,
-		})
+		return apperror.Forbidden("pekerjaan.delete_forbidden")
 	}
 
 	var deletedBy string
@@ -416,18 +461,12 @@ func SoftDeletePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 		deletedBy = alumniID
 	}
 
-	err = repository.SoftDeletePekerjaan(db, id, deletedBy)
+	err = repository.SoftDeletePekerjaan(c.UserContext(), db, id, deletedBy)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan atau sudah dihapus",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found_or_deleted")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -452,55 +491,36 @@ func SoftDeletePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 func RestorePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 	alumniID, isAlumni := c.Locals("alumni_id").(string)
 	role := c.Locals("role").(string)
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	// Get the owner of this job record
-	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(db, id)
+	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
 	if isAlumni && role == "alumni" {
 		// Alumni trying to restore - can only restore their own
 		if ownerAlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"message": "Maaf tidak bisa mengubah atau mengedit data selain diri sendiri",
-				"success": false,
-			})
+			return apperror.Forbidden("pekerjaan.own_data_only")
 		}
-		log.Printf("Alumni ID %s merestorasi pekerjaan ID %s miliknya sendiri", alumniID, id)
+		logger.From(c).Info("Alumni merestorasi pekerjaan miliknya", "alumni_id", alumniID, "pekerjaan_id", id)
 	} else if role == "admin" {
 		// Admin can restore any
-		log.Printf("Admin %s merestorasi pekerjaan ID %s", username, id)
+		logger.From(c).Info("Admin merestorasi pekerjaan", "pekerjaan_id", id)
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Anda tidak memiliki akses untuk merestorasi pekerjaan",
-			"success": false,
-		})
+		return apperror.Forbidden("pekerjaan.restore_forbidden")
 	}
 
-	err = repository.RestorePekerjaan(db, id)
+	err = repository.RestorePekerjaan(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan atau tidak terhapus",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_in_trash")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal merestorasi pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.restore")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -525,55 +545,36 @@ func RestorePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 func HardDeletePekerjaanService(c *fiber.Ctx, db *mongo.Database) error {
 	alumniID, isAlumni := c.Locals("alumni_id").(string)
 	role := c.Locals("role").(string)
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	// Get the owner of this job record
-	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(db, id)
+	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
 	if isAlumni && role == "alumni" {
 		// Alumni trying to hard delete - can only delete their own
 		if ownerAlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"message": "Maaf tidak bisa mengubah atau mengedit data selain diri sendiri",
-				"success": false,
-			})
+			return apperror.Forbidden("pekerjaan.own_data_only")
 		}
-		log.Printf("Alumni ID %s hard delete pekerjaan ID %s miliknya sendiri", alumniID, id)
+		logger.From(c).Info("Alumni menghapus permanen pekerjaan miliknya", "alumni_id", alumniID, "pekerjaan_id", id)
 	} else if role == "admin" {
 		// Admin can hard delete any
-		log.Printf("Admin %s hard delete pekerjaan ID %s", username, id)
+		logger.From(c).Info("Admin menghapus permanen pekerjaan", "pekerjaan_id", id)
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Anda tidak memiliki akses untuk menghapus pekerjaan",
-			"success": false,
-		})
+		return apperror.Forbidden("pekerjaan.delete_forbidden")
 	}
 
-	err = repository.HardDeletePekerjaan(db, id)
+	err = repository.HardDeletePekerjaan(c.UserContext(), db, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
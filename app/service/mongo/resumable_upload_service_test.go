@@ -0,0 +1,93 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"clean-arch/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	chunk := "bagian pertama transkrip"
+	sum := sha256.Sum256([]byte(chunk))
+	header := "sha256 " + base64.StdEncoding.EncodeToString(sum[:])
+
+	if err := verifyChecksum(header, strings.NewReader(chunk)); err != nil {
+		t.Fatalf("expected checksum to match, got %v", err)
+	}
+
+	if err := verifyChecksum(header, strings.NewReader(chunk+"x")); err == nil {
+		t.Error("expected mismatch for modified chunk")
+	}
+
+	if err := verifyChecksum("crc32 AAAA", strings.NewReader(chunk)); !errors.Is(err, errUnsupportedChecksum) {
+		t.Errorf("expected errUnsupportedChecksum, got %v", err)
+	}
+
+	if err := verifyChecksum("sha256", strings.NewReader(chunk)); err == nil {
+		t.Error("expected error for malformed header")
+	}
+}
+
+func TestSessionOwnerAndUploader(t *testing.T) {
+	// Admin mengunggah untuk alumni: pemilik file alumni, sesi milik admin
+	session := &model.UploadSession{
+		ID:           primitive.NewObjectID(),
+		UserID:       "a1",
+		OwnerType:    model.OwnerTypeAlumni,
+		UploadedBy:   "admin1",
+		UploaderType: model.OwnerTypeUser,
+	}
+	if got := sessionOwner(session); got != (fileOwner{Type: model.OwnerTypeAlumni, ID: "a1"}) {
+		t.Errorf("owner = %+v, want alumni a1", got)
+	}
+	if got := sessionUploader(session); got != (fileOwner{Type: model.OwnerTypeUser, ID: "admin1"}) {
+		t.Errorf("uploader = %+v, want user admin1", got)
+	}
+	if got := uploadSessionLocation(session); got != "/api/files/uploads/"+session.ID.Hex() {
+		t.Errorf("location = %q", got)
+	}
+
+	// Alumni mengunggah sendiri, lokasi sesi di route alumni
+	session.UploadedBy, session.UploaderType = "a1", model.OwnerTypeAlumni
+	if got := uploadSessionLocation(session); got != "/api/files/alumni/uploads/"+session.ID.Hex() {
+		t.Errorf("alumni location = %q", got)
+	}
+
+	// Sesi lama tanpa owner_type dan uploader_type milik user
+	legacy := &model.UploadSession{UserID: "u1", UploadedBy: "u1"}
+	if got := sessionOwner(legacy); got != (fileOwner{Type: model.OwnerTypeUser, ID: "u1"}) {
+		t.Errorf("legacy owner = %+v, want user u1", got)
+	}
+	if got := sessionUploader(legacy); got != (fileOwner{Type: model.OwnerTypeUser, ID: "u1"}) {
+		t.Errorf("legacy uploader = %+v, want user u1", got)
+	}
+}
+
+func TestUploadSessionExpired(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		session model.UploadSession
+		want    bool
+	}{
+		{"active", model.UploadSession{Status: model.UploadSessionUploading, ExpiresAt: now.Add(time.Hour)}, false},
+		{"expired upload", model.UploadSession{Status: model.UploadSessionUploading, ExpiresAt: expired}, true},
+		{"completing in progress", model.UploadSession{Status: model.UploadSessionCompleting, ExpiresAt: expired, UpdatedAt: now.Add(-time.Minute)}, false},
+		{"completing stuck", model.UploadSession{Status: model.UploadSessionCompleting, ExpiresAt: expired, UpdatedAt: now.Add(-2 * defaultUploadCompletingTimeout)}, true},
+	}
+
+	for _, tt := range tests {
+		if got := uploadSessionExpired(&tt.session, now); got != tt.want {
+			t.Errorf("%s: uploadSessionExpired = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"strings"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// defaultSalaryMinGroupSize is the smallest group whose salary percentiles are
+// shown, agar gaji individu tidak bisa ditebak dari grup kecil
+const defaultSalaryMinGroupSize = 5
+
+// gajiViewer reports whether the requester may see the salary of an alumni
+type gajiViewer func(alumniID string) bool
+
+// gajiViewerOf returns the salary visibility of the requester: admin melihat
+// semua gaji, alumni hanya gajinya sendiri, pengguna lain hanya statistik agregat.
+func gajiViewerOf(c *fiber.Ctx) gajiViewer {
+	if role, _ := c.Locals("role").(string); role == "admin" {
+		return func(string) bool { return true }
+	}
+	self, _ := c.Locals("alumni_id").(string)
+	return func(alumniID string) bool {
+		return self != "" && self == alumniID
+	}
+}
+
+// hideGaji removes gaji and gaji_range of a job the viewer may not see
+func hideGaji(canView gajiViewer, p *model.PekerjaanAlumni) {
+	if p != nil && !canView(p.AlumniID.Hex()) {
+		p.Gaji = nil
+		p.GajiRange = nil
+	}
+}
+
+// hideGajiEach is hideGaji for every job in list
+func hideGajiEach(canView gajiViewer, list []model.PekerjaanAlumni) {
+	for i := range list {
+		hideGaji(canView, &list[i])
+	}
+}
+
+// prepareGaji validates the salary of a create/update request. Jika hanya
+// gaji_range yang dikirim, gaji terstruktur di-parse dari teksnya; jika gaji
+// dikirim tanpa gaji_range, gaji_range diisi dari gaji agar tetap konsisten.
+func prepareGaji(gaji **model.Gaji, gajiRange **string) error {
+	if *gaji != nil {
+		if err := utils.NormalizeGaji(*gaji); err != nil {
+			return err
+		}
+		if *gajiRange == nil || strings.TrimSpace(**gajiRange) == "" {
+			text := utils.FormatGaji(**gaji)
+			*gajiRange = &text
+		}
+		return nil
+	}
+
+	if *gajiRange != nil && strings.TrimSpace(**gajiRange) != "" {
+		parsed, err := utils.ParseGajiRange(**gajiRange)
+		if err != nil {
+			return err
+		}
+		*gaji = parsed
+	}
+	return nil
+}
+
+// MigrateGajiRange converts the free-text gaji_range of existing jobs into
+// structured salary. Teks yang tidak dikenali dibiarkan dan dicatat di log.
+func MigrateGajiRange(db *mongo.Database) {
+	ctx := logger.Background("gaji_migration")
+	l := logger.FromContext(ctx)
+
+	list, err := repository.GetPekerjaanWithoutGaji(ctx, db)
+	if err != nil {
+		l.Error("Gagal membaca gaji_range untuk migrasi gaji", "error", err)
+		return
+	}
+
+	converted, skipped := 0, 0
+	for _, p := range list {
+		gaji, err := utils.ParseGajiRange(*p.GajiRange)
+		if err != nil {
+			l.Warn("Migrasi gaji: pekerjaan dilewati", "pekerjaan_id", p.ID.Hex(), "error", err)
+			skipped++
+			continue
+		}
+		if err := repository.SetPekerjaanGaji(ctx, db, p.ID, *gaji); err != nil {
+			l.Error("Migrasi gaji: gagal menyimpan pekerjaan", "pekerjaan_id", p.ID.Hex(), "error", err)
+			skipped++
+			continue
+		}
+		converted++
+	}
+	if converted+skipped > 0 {
+		l.Info("Migrasi gaji_range selesai", "converted", converted, "skipped", skipped)
+	}
+}
+
+// suppressSmallGroups hides the percentiles of groups smaller than minSize
+func suppressSmallGroups(stats *model.SalaryStatistics, minSize int) {
+	stats.MinGroupSize = minSize
+	suppress := func(g *model.SalaryGroup) {
+		if g.Count > 0 && g.Count < minSize {
+			g.Suppressed = true
+			g.P25, g.P50, g.P75, g.P90 = nil, nil, nil, nil
+		}
+	}
+
+	suppress(&stats.Overall)
+	for _, groups := range [][]model.SalaryGroup{stats.ByJurusan, stats.ByBidangIndustri, stats.ByTahunLulus} {
+		for i := range groups {
+			suppress(&groups[i])
+		}
+	}
+}
+
+// GetSalaryStatisticsService godoc
+// @Summary Statistik gaji alumni
+// @Description Persentil gaji bulanan (P25, P50, P75, P90) pekerjaan aktif per jurusan, bidang industri dan tahun lulus. Grup yang lebih kecil dari SALARY_MIN_GROUP_SIZE disembunyikan
+// @Tags Alumni
+// @Produce json
+// @Param currency query string false "Mata uang (default: IDR)"
+// @Param jurusan query string false "Filter jurusan, dipisah koma"
+// @Param angkatan query string false "Filter angkatan, contoh 2018..2020"
+// @Param tahun_lulus query string false "Filter tahun lulus, contoh 2022..2024"
+// @Success 200 {object} map[string]interface{} "Statistik gaji"
+// @Failure 400 {object} map[string]interface{} "Filter tidak valid"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /alumni/statistics/salary [get]
+func GetSalaryStatisticsService(c *fiber.Ctx, db *mongo.Database) error {
+	alumniFilter, err := utils.ParseAlumniFilter(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_filter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(c.Query("currency", model.DefaultGajiCurrency)))
+	if len(currency) != 3 {
+		return apperror.BadRequest("salary.invalid_currency")
+	}
+
+	stats, err := repository.GetSalaryStatistics(c.UserContext(), db, alumniFilter, currency)
+	if err != nil {
+		return apperror.Internal(err, "salary.statistics")
+	}
+	suppressSmallGroups(stats, int(env.Int64("SALARY_MIN_GROUP_SIZE", defaultSalaryMinGroupSize)))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil statistik gaji",
+		"success": true,
+		"data":    stats,
+	})
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"clean-arch/app/model/mongo"
+)
+
+func TestPrepareGaji(t *testing.T) {
+	text := "5-8 juta"
+	var gaji *model.Gaji
+	gajiRange := &text
+	if err := prepareGaji(&gaji, &gajiRange); err != nil {
+		t.Fatal(err)
+	}
+	if gaji == nil || gaji.Min != 5_000_000 || gaji.Max != 8_000_000 {
+		t.Errorf("gaji parsed from gaji_range = %+v", gaji)
+	}
+
+	gaji = &model.Gaji{Min: 6_000_000, Max: 9_000_000}
+	gajiRange = nil
+	if err := prepareGaji(&gaji, &gajiRange); err != nil {
+		t.Fatal(err)
+	}
+	if gaji.Currency != "IDR" || gajiRange == nil || *gajiRange != "IDR 6.000.000 - 9.000.000 / bulan" {
+		t.Errorf("gaji = %+v, gaji_range = %v", gaji, gajiRange)
+	}
+
+	gaji = &model.Gaji{Min: 9_000_000, Max: 6_000_000}
+	if err := prepareGaji(&gaji, &gajiRange); err == nil {
+		t.Error("max below min should be rejected")
+	}
+}
+
+func TestSuppressSmallGroups(t *testing.T) {
+	p50 := 5_000_000.0
+	stats := &model.SalaryStatistics{
+		Overall:   model.SalaryGroup{Count: 12, P50: &p50},
+		ByJurusan: []model.SalaryGroup{{Value: "Informatika", Count: 10, P50: &p50}, {Value: "Fisika", Count: 2, P50: &p50}},
+	}
+	suppressSmallGroups(stats, 5)
+
+	if stats.MinGroupSize != 5 || stats.Overall.Suppressed {
+		t.Errorf("overall = %+v", stats.Overall)
+	}
+	if stats.ByJurusan[0].Suppressed || stats.ByJurusan[0].P50 == nil {
+		t.Error("large group should keep its percentiles")
+	}
+	if !stats.ByJurusan[1].Suppressed || stats.ByJurusan[1].P50 != nil {
+		t.Error("small group should be suppressed")
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/logger"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// EnsureSearchIndexes prepares the text indexes used by search_mode=fulltext.
+// Gagal membuat index tidak menghentikan aplikasi, mode regex tetap bisa dipakai.
+func EnsureSearchIndexes(db *mongo.Database) {
+	ctx := logger.Background("search_indexes")
+	if err := repository.EnsureSearchIndexes(ctx, db); err != nil {
+		logger.FromContext(ctx).Error("Gagal membuat text index pencarian", "error", err)
+	}
+}
@@ -0,0 +1,670 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"clean-arch/app/model/mongo"
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var taxonomyCodePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// taxonomyLabels is how a kind is named in messages
+var taxonomyLabels = map[string]string{
+	model.TaxonomyJurusan:         "Jurusan",
+	model.TaxonomyBidangIndustri:  "Bidang industri",
+	model.TaxonomyStatusPekerjaan: "Status pekerjaan",
+}
+
+// statusTerms are the job statuses used by the application logic. Term ini
+// selalu ada dan tidak bisa dihapus atau dinonaktifkan.
+var statusTerms = []model.TaxonomyTerm{
+	{Code: model.StatusPekerjaanAktif, Label: "Aktif", Aliases: []string{"masih bekerja", "bekerja"}},
+	{Code: model.StatusPekerjaanSelesai, Label: "Selesai", Aliases: []string{"kontrak selesai"}},
+	{Code: model.StatusPekerjaanResigned, Label: "Resign", Aliases: []string{"mengundurkan diri", "resign"}},
+}
+
+// kbliSections are the top-level categories of KBLI 2020, dengan alias untuk
+// sebutan yang lazim dipakai alumni
+var kbliSections = []model.TaxonomyTerm{
+	{Code: "A", Label: "Pertanian, Kehutanan dan Perikanan", Aliases: []string{"Pertanian", "Perkebunan", "Perikanan"}},
+	{Code: "B", Label: "Pertambangan dan Penggalian", Aliases: []string{"Pertambangan", "Tambang", "Migas"}},
+	{Code: "C", Label: "Industri Pengolahan", Aliases: []string{"Manufaktur", "Manufacturing"}},
+	{Code: "D", Label: "Pengadaan Listrik, Gas, Uap/Air Panas dan Udara Dingin", Aliases: []string{"Energi", "Listrik"}},
+	{Code: "E", Label: "Treatment Air, Treatment Air Limbah, Treatment dan Pemulihan Material Sampah, dan Aktivitas Remediasi", Aliases: []string{"Pengelolaan Limbah"}},
+	{Code: "F", Label: "Konstruksi", Aliases: []string{"Construction"}},
+	{Code: "G", Label: "Perdagangan Besar dan Eceran; Reparasi dan Perawatan Mobil dan Sepeda Motor", Aliases: []string{"Perdagangan", "Retail", "E-commerce"}},
+	{Code: "H", Label: "Pengangkutan dan Pergudangan", Aliases: []string{"Transportasi", "Logistik"}},
+	{Code: "I", Label: "Penyediaan Akomodasi dan Penyediaan Makan Minum", Aliases: []string{"Perhotelan", "Hospitality", "Kuliner"}},
+	{Code: "J", Label: "Informasi dan Komunikasi", Aliases: []string{"Teknologi Informasi", "IT", "Telekomunikasi", "Software", "Media"}},
+	{Code: "K", Label: "Aktivitas Keuangan dan Asuransi", Aliases: []string{"Keuangan", "Perbankan", "Banking", "Asuransi", "Fintech"}},
+	{Code: "L", Label: "Real Estat", Aliases: []string{"Properti", "Real Estate"}},
+	{Code: "M", Label: "Aktivitas Profesional, Ilmiah dan Teknis", Aliases: []string{"Konsultan", "Consulting", "Riset"}},
+	{Code: "N", Label: "Aktivitas Penyewaan dan Sewa Guna Usaha Tanpa Hak Opsi, Ketenagakerjaan, Agen Perjalanan dan Penunjang Usaha Lainnya", Aliases: []string{"Outsourcing", "Agen Perjalanan"}},
+	{Code: "O", Label: "Administrasi Pemerintahan, Pertahanan dan Jaminan Sosial Wajib", Aliases: []string{"Pemerintahan", "Instansi Pemerintah", "BUMN"}},
+	{Code: "P", Label: "Pendidikan", Aliases: []string{"Education"}},
+	{Code: "Q", Label: "Aktivitas Kesehatan Manusia dan Aktivitas Sosial", Aliases: []string{"Kesehatan", "Rumah Sakit", "Healthcare"}},
+	{Code: "R", Label: "Kesenian, Hiburan dan Rekreasi", Aliases: []string{"Hiburan", "Entertainment"}},
+	{Code: "S", Label: "Aktivitas Jasa Lainnya", Aliases: []string{"Jasa"}},
+	{Code: "T", Label: "Aktivitas Rumah Tangga sebagai Pemberi Kerja; Aktivitas yang Menghasilkan Barang dan Jasa oleh Rumah Tangga yang Digunakan untuk Memenuhi Kebutuhan Sendiri"},
+	{Code: "U", Label: "Aktivitas Badan Internasional dan Badan Ekstra Internasional Lainnya", Aliases: []string{"Organisasi Internasional", "NGO"}},
+}
+
+// taxonomyError reports a value that is not an active term of its kind
+type taxonomyError struct {
+	Kind    string
+	Value   string
+	Options []string
+}
+
+func (e *taxonomyError) Error() string {
+	return fmt.Sprintf("%s %q tidak terdaftar", taxonomyLabels[e.Kind], e.Value)
+}
+
+// taxonomyKey normalizes a code, label or alias for matching
+func taxonomyKey(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// taxonomyCode derives a code from a label, misalnya "Teknik Informatika"
+// menjadi "teknik-informatika"
+func taxonomyCode(label string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(label)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// taxonomyIndex matches free text to the terms of one kind
+type taxonomyIndex struct {
+	kind  string
+	terms []model.TaxonomyTerm
+	byKey map[string]int
+}
+
+func newTaxonomyIndex(kind string, terms []model.TaxonomyTerm) taxonomyIndex {
+	ix := taxonomyIndex{kind: kind, terms: terms, byKey: map[string]int{}}
+	for i, term := range terms {
+		for _, key := range termKeys(term) {
+			if _, ok := ix.byKey[key]; !ok {
+				ix.byKey[key] = i
+			}
+		}
+	}
+	return ix
+}
+
+// termKeys returns the normalized code, label and aliases of a term
+func termKeys(term model.TaxonomyTerm) []string {
+	keys := []string{taxonomyKey(term.Code), taxonomyKey(term.Label)}
+	for _, alias := range term.Aliases {
+		keys = append(keys, taxonomyKey(alias))
+	}
+	return keys
+}
+
+// match returns the term whose code, label or alias equals value, nil jika tidak ada
+func (ix taxonomyIndex) match(value string) *model.TaxonomyTerm {
+	i, ok := ix.byKey[taxonomyKey(value)]
+	if !ok {
+		return nil
+	}
+	return &ix.terms[i]
+}
+
+// storedValue is what gets saved for a term: kode untuk status pekerjaan,
+// label untuk jurusan dan bidang industri
+func storedValue(term model.TaxonomyTerm) string {
+	if term.Kind == model.TaxonomyStatusPekerjaan {
+		return term.Code
+	}
+	return term.Label
+}
+
+// canonicalize replaces value with the stored value of the matching active
+// term. Taksonomi yang belum memiliki term aktif menerima nilai apa pun.
+func (ix taxonomyIndex) canonicalize(value *string) error {
+	if len(ix.terms) == 0 {
+		return nil
+	}
+	term := ix.match(*value)
+	if term == nil {
+		options := make([]string, len(ix.terms))
+		for i, t := range ix.terms {
+			options[i] = storedValue(t)
+		}
+		return &taxonomyError{Kind: ix.kind, Value: *value, Options: options}
+	}
+	*value = storedValue(*term)
+	return nil
+}
+
+// loadTaxonomy returns the index of the terms of kind
+func loadTaxonomy(ctx context.Context, db *mongo.Database, kind string, activeOnly bool) (taxonomyIndex, error) {
+	terms, err := repository.GetTaxonomyTerms(ctx, db, kind, activeOnly)
+	if err != nil {
+		return taxonomyIndex{}, err
+	}
+	return newTaxonomyIndex(kind, terms), nil
+}
+
+// canonicalizeTaxonomy validates value against the active terms of kind.
+// Nilai kosong dilewati, kewajiban mengisi diperiksa oleh pemanggil.
+func canonicalizeTaxonomy(ctx context.Context, db *mongo.Database, kind string, value *string) error {
+	if strings.TrimSpace(*value) == "" {
+		return nil
+	}
+	ix, err := loadTaxonomy(ctx, db, kind, true)
+	if err != nil {
+		return err
+	}
+	return ix.canonicalize(value)
+}
+
+// taxonomyErrorResponse writes a 400 for a taxonomy mismatch atau 500 untuk
+// kegagalan lain
+func taxonomyErrorResponse(c *fiber.Ctx, err error) error {
+	var terr *taxonomyError
+	if errors.As(err, &terr) {
+		return apperror.BadRequest("taxonomy.unknown_value", terr.Kind, terr.Value).WithDetails(fiber.Map{
+			"fields":  utils.FieldErrors{utils.NewFieldError(terr.Kind, "taxonomy", "validation.taxonomy", terr.Kind)},
+			"options": terr.Options,
+		})
+	}
+	return apperror.Internal(err, "taxonomy.check")
+}
+
+// buildTaxonomyBreakdown groups raw value counts by the term they match
+func buildTaxonomyBreakdown(ix taxonomyIndex, counts map[string]int) model.TaxonomyBreakdown {
+	breakdown := model.TaxonomyBreakdown{
+		Groups:       []model.TaxonomyGroup{},
+		Unclassified: []model.TaxonomyUnclassified{},
+	}
+	groups := map[string]int{}
+	for value, count := range counts {
+		term := ix.match(value)
+		if term == nil {
+			breakdown.Unclassified = append(breakdown.Unclassified, model.TaxonomyUnclassified{Value: value, Count: count})
+			continue
+		}
+		if i, ok := groups[term.Code]; ok {
+			breakdown.Groups[i].Count += count
+			continue
+		}
+		groups[term.Code] = len(breakdown.Groups)
+		breakdown.Groups = append(breakdown.Groups, model.TaxonomyGroup{Code: term.Code, Label: term.Label, Count: count})
+	}
+
+	sort.Slice(breakdown.Groups, func(i, j int) bool {
+		if breakdown.Groups[i].Count != breakdown.Groups[j].Count {
+			return breakdown.Groups[i].Count > breakdown.Groups[j].Count
+		}
+		return breakdown.Groups[i].Code < breakdown.Groups[j].Code
+	})
+	sort.Slice(breakdown.Unclassified, func(i, j int) bool {
+		if breakdown.Unclassified[i].Count != breakdown.Unclassified[j].Count {
+			return breakdown.Unclassified[i].Count > breakdown.Unclassified[j].Count
+		}
+		return breakdown.Unclassified[i].Value < breakdown.Unclassified[j].Value
+	})
+	return breakdown
+}
+
+// termsFromValues turns existing free-text values into terms of kind yang
+// belum dikenali ix. Nilai dengan kode yang sama digabung sebagai alias.
+func termsFromValues(ix taxonomyIndex, kind string, values []string) []model.TaxonomyTerm {
+	terms := []model.TaxonomyTerm{}
+	byCode := map[string]int{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		code := taxonomyCode(value)
+		if code == "" || ix.match(value) != nil {
+			continue
+		}
+		if i, ok := byCode[code]; ok {
+			if taxonomyKey(terms[i].Label) != taxonomyKey(value) {
+				terms[i].Aliases = append(terms[i].Aliases, value)
+			}
+			continue
+		}
+		byCode[code] = len(terms)
+		terms = append(terms, model.TaxonomyTerm{
+			Kind: kind, Code: code, Label: value, Aliases: []string{}, Active: true, Source: model.TaxonomySourceData,
+		})
+	}
+	return terms
+}
+
+// withKind stamps seed terms with their kind and source
+func withKind(seed []model.TaxonomyTerm, kind, source string) []model.TaxonomyTerm {
+	terms := make([]model.TaxonomyTerm, len(seed))
+	for i, term := range seed {
+		term.Kind = kind
+		term.Source = source
+		term.Active = true
+		if term.Aliases == nil {
+			term.Aliases = []string{}
+		}
+		terms[i] = term
+	}
+	return terms
+}
+
+// seedTaxonomyFromData adds the existing values of kind as terms. Hanya
+// dijalankan selama kind belum punya term, agar term yang dihapus admin tidak
+// dibuat ulang dari data lama setiap kali aplikasi start.
+func seedTaxonomyFromData(ctx context.Context, db *mongo.Database, kind string) (int, error) {
+	ix, err := loadTaxonomy(ctx, db, kind, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(ix.terms) > 0 {
+		return 0, nil
+	}
+	values, err := repository.GetDistinctTaxonomyValues(ctx, db, kind)
+	if err != nil {
+		return 0, err
+	}
+	return repository.SeedTaxonomyTerms(ctx, db, termsFromValues(ix, kind, values))
+}
+
+// hasTaxonomySource reports whether kind already has a term from source
+func hasTaxonomySource(ctx context.Context, db *mongo.Database, kind, source string) (bool, error) {
+	terms, err := repository.GetTaxonomyTerms(ctx, db, kind, false)
+	if err != nil {
+		return false, err
+	}
+	for _, term := range terms {
+		if term.Source == source {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// InitTaxonomies creates the taxonomy index and seeds the reference data:
+// status pekerjaan bawaan, kategori KBLI 2020 untuk bidang industri jika
+// TAXONOMY_SEED_KBLI=true, dan nilai jurusan (serta bidang industri bila KBLI
+// tidak dipakai) yang sudah tersimpan agar data lama tetap lolos validasi.
+// Data hanya diisi sekali per kind, setelah itu taksonomi dikelola admin.
+func InitTaxonomies(db *mongo.Database) {
+	ctx := logger.Background("taxonomy_seed")
+	l := logger.FromContext(ctx)
+
+	if err := repository.EnsureTaxonomyIndexes(ctx, db); err != nil {
+		l.Error("Gagal membuat index taksonomi", "error", err)
+	}
+
+	seeds := withKind(statusTerms, model.TaxonomyStatusPekerjaan, model.TaxonomySourceSystem)
+	seedKBLI := env.Bool("TAXONOMY_SEED_KBLI", false)
+	if seedKBLI {
+		// KBLI hanya diisi sekali, kategori yang dihapus admin tidak kembali
+		seeded, err := hasTaxonomySource(ctx, db, model.TaxonomyBidangIndustri, model.TaxonomySourceKBLI)
+		if err != nil {
+			l.Error("Gagal membaca taksonomi KBLI", "error", err)
+		} else if !seeded {
+			seeds = append(seeds, withKind(kbliSections, model.TaxonomyBidangIndustri, model.TaxonomySourceKBLI)...)
+		}
+	}
+	added, err := repository.SeedTaxonomyTerms(ctx, db, seeds)
+	if err != nil {
+		l.Error("Gagal mengisi taksonomi bawaan", "error", err)
+		return
+	}
+
+	fromData := []string{model.TaxonomyJurusan}
+	if !seedKBLI {
+		fromData = append(fromData, model.TaxonomyBidangIndustri)
+	}
+	for _, kind := range fromData {
+		n, err := seedTaxonomyFromData(ctx, db, kind)
+		if err != nil {
+			l.Error("Gagal mengisi taksonomi dari data", "kind", kind, "error", err)
+			continue
+		}
+		added += n
+	}
+	if added > 0 {
+		l.Info("Taksonomi diisi", "added", added)
+	}
+}
+
+// parseTaxonomyKind reads the :kind parameter, false jika tidak dikenal
+func parseTaxonomyKind(c *fiber.Ctx) (string, bool) {
+	kind := c.Params("kind")
+	_, ok := taxonomyLabels[kind]
+	return kind, ok
+}
+
+// cleanAliases trims aliases and drops empty ones
+func cleanAliases(aliases []string) []string {
+	cleaned := []string{}
+	for _, a := range aliases {
+		if a = strings.TrimSpace(a); a != "" {
+			cleaned = append(cleaned, a)
+		}
+	}
+	return cleaned
+}
+
+// findTaxonomyKeyConflict returns the first code, label or alias of term
+// already used by another term of the same kind, string kosong jika tidak ada
+func findTaxonomyKeyConflict(ctx context.Context, db *mongo.Database, term model.TaxonomyTerm) (string, error) {
+	terms, err := repository.GetTaxonomyTerms(ctx, db, term.Kind, false)
+	if err != nil {
+		return "", err
+	}
+	used := map[string]bool{}
+	for _, other := range terms {
+		if other.ID == term.ID {
+			continue
+		}
+		for _, key := range termKeys(other) {
+			used[key] = true
+		}
+	}
+	for _, key := range termKeys(term) {
+		if used[key] {
+			return key, nil
+		}
+	}
+	return "", nil
+}
+
+// GetTaxonomiesService godoc
+// @Summary Daftar semua taksonomi
+// @Description Mengambil term jurusan, bidang industri dan status pekerjaan yang dikelompokkan per jenis
+// @Tags Taxonomies
+// @Produce json
+// @Param include_inactive query bool false "true untuk menyertakan term nonaktif"
+// @Success 200 {object} map[string]interface{} "Taksonomi per jenis"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /taxonomies [get]
+func GetTaxonomiesService(c *fiber.Ctx, db *mongo.Database) error {
+	terms, err := repository.GetTaxonomyTerms(c.UserContext(), db, "", !c.QueryBool("include_inactive", false))
+	if err != nil {
+		return apperror.Internal(err, "taxonomy.get")
+	}
+
+	data := map[string][]model.TaxonomyTerm{}
+	for _, kind := range model.TaxonomyKinds {
+		data[kind] = []model.TaxonomyTerm{}
+	}
+	for _, term := range terms {
+		data[term.Kind] = append(data[term.Kind], term)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data taksonomi",
+		"success": true,
+		"data":    data,
+	})
+}
+
+// GetTaxonomyTermsService godoc
+// @Summary Daftar term satu taksonomi
+// @Tags Taxonomies
+// @Produce json
+// @Param kind path string true "jurusan, bidang_industri atau status_pekerjaan"
+// @Param include_inactive query bool false "true untuk menyertakan term nonaktif"
+// @Success 200 {object} map[string]interface{} "Daftar term"
+// @Failure 404 {object} map[string]interface{} "Jenis taksonomi tidak dikenal"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /taxonomies/{kind} [get]
+func GetTaxonomyTermsService(c *fiber.Ctx, db *mongo.Database) error {
+	kind, ok := parseTaxonomyKind(c)
+	if !ok {
+		return apperror.NotFound("taxonomy.unknown_kind")
+	}
+
+	terms, err := repository.GetTaxonomyTerms(c.UserContext(), db, kind, !c.QueryBool("include_inactive", false))
+	if err != nil {
+		return apperror.Internal(err, "taxonomy.get")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data taksonomi",
+		"success": true,
+		"data":    terms,
+	})
+}
+
+// CreateTaxonomyTermService godoc
+// @Summary Tambah term taksonomi
+// @Tags Taxonomies
+// @Accept json
+// @Produce json
+// @Param kind path string true "jurusan, bidang_industri atau status_pekerjaan"
+// @Param body body model.CreateTaxonomyTermRequest true "Data term"
+// @Success 201 {object} map[string]interface{} "Term berhasil ditambahkan"
+// @Failure 400 {object} map[string]interface{} "Data tidak valid"
+// @Failure 404 {object} map[string]interface{} "Jenis taksonomi tidak dikenal"
+// @Failure 409 {object} map[string]interface{} "Kode, label atau alias sudah dipakai"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security Bearer
+// @Router /taxonomies/{kind} [post]
+func CreateTaxonomyTermService(c *fiber.Ctx, db *mongo.Database) error {
+	kind, ok := parseTaxonomyKind(c)
+	if !ok {
+		return apperror.NotFound("taxonomy.unknown_kind")
+	}
+	if kind == model.TaxonomyStatusPekerjaan {
+		return apperror.BadRequest("taxonomy.status_readonly")
+	}
+
+	var req model.CreateTaxonomyTermRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	term := model.TaxonomyTerm{
+		Kind:    kind,
+		Code:    strings.TrimSpace(req.Code),
+		Label:   strings.TrimSpace(req.Label),
+		Aliases: cleanAliases(req.Aliases),
+		Active:  req.Active == nil || *req.Active,
+		Source:  model.TaxonomySourceManual,
+	}
+	if term.Label == "" || !taxonomyCodePattern.MatchString(term.Code) {
+		return apperror.BadRequest("taxonomy.invalid_term")
+	}
+
+	key, err := findTaxonomyKeyConflict(c.UserContext(), db, term)
+	if err != nil {
+		return apperror.Internal(err, "taxonomy.create")
+	}
+	if key != "" {
+		return apperror.Conflict("taxonomy.key_taken", key)
+	}
+
+	if err := repository.CreateTaxonomyTerm(c.UserContext(), db, &term); err != nil {
+		return apperror.Internal(err, "taxonomy.create")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Term taksonomi berhasil ditambahkan",
+		"success": true,
+		"data":    term,
+	})
+}
+
+// getKindTerm returns the term with id if it belongs to kind,
+// mongo.ErrNoDocuments jika tidak
+func getKindTerm(ctx context.Context, db *mongo.Database, kind string, id primitive.ObjectID) (*model.TaxonomyTerm, error) {
+	term, err := repository.GetTaxonomyTermByID(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if term.Kind != kind {
+		return nil, mongo.ErrNoDocuments
+	}
+	return term, nil
+}
+
+// UpdateTaxonomyTermService godoc
+// @Summary Update term taksonomi
+// @Description Mengubah label, alias dan status aktif term. Kode tidak bisa diubah, data yang tersimpan tidak ikut diubah
+// @Tags Taxonomies
+// @Accept json
+// @Produce json
+// @Param kind path string true "jurusan, bidang_industri atau status_pekerjaan"
+// @Param id path string true "Term ID (MongoDB ObjectID)"
+// @Param body body model.UpdateTaxonomyTermRequest true "Data term"
+// @Success 200 {object} map[string]interface{} "Term berhasil diupdate"
+// @Failure 400 {object} map[string]interface{} "Data tidak valid"
+// @Failure 404 {object} map[string]interface{} "Term tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "Label atau alias sudah dipakai"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security Bearer
+// @Router /taxonomies/{kind}/{id} [put]
+func UpdateTaxonomyTermService(c *fiber.Ctx, db *mongo.Database) error {
+	kind, ok := parseTaxonomyKind(c)
+	if !ok {
+		return apperror.NotFound("taxonomy.unknown_kind")
+	}
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return apperror.BadRequest("request.invalid_id")
+	}
+
+	term, err := getKindTerm(c.UserContext(), db, kind, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return apperror.NotFound("taxonomy.term_not_found")
+		}
+		return apperror.Internal(err, "taxonomy.get_term")
+	}
+
+	var req model.UpdateTaxonomyTermRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	term.Label = strings.TrimSpace(req.Label)
+	term.Aliases = cleanAliases(req.Aliases)
+	if req.Active != nil {
+		term.Active = *req.Active
+	}
+	if term.Label == "" {
+		return apperror.BadRequest("taxonomy.label_required")
+	}
+	if term.Source == model.TaxonomySourceSystem && !term.Active {
+		return apperror.BadRequest("taxonomy.system_deactivate")
+	}
+
+	key, err := findTaxonomyKeyConflict(c.UserContext(), db, *term)
+	if err != nil {
+		return apperror.Internal(err, "taxonomy.update")
+	}
+	if key != "" {
+		return apperror.Conflict("taxonomy.key_taken", key)
+	}
+
+	if err := repository.UpdateTaxonomyTerm(c.UserContext(), db, term); err != nil {
+		return apperror.Internal(err, "taxonomy.update")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Term taksonomi berhasil diupdate",
+		"success": true,
+		"data":    term,
+	})
+}
+
+// DeleteTaxonomyTermService godoc
+// @Summary Hapus term taksonomi
+// @Description Menghapus term. Data yang memakai term ini tidak diubah dan akan muncul sebagai unclassified di statistik, nonaktifkan term bila hanya ingin menolak input baru
+// @Tags Taxonomies
+// @Produce json
+// @Param kind path string true "jurusan, bidang_industri atau status_pekerjaan"
+// @Param id path string true "Term ID (MongoDB ObjectID)"
+// @Success 200 {object} map[string]interface{} "Term berhasil dihapus"
+// @Failure 400 {object} map[string]interface{} "Term bawaan sistem"
+// @Failure 404 {object} map[string]interface{} "Term tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security Bearer
+// @Router /taxonomies/{kind}/{id} [delete]
+func DeleteTaxonomyTermService(c *fiber.Ctx, db *mongo.Database) error {
+	kind, ok := parseTaxonomyKind(c)
+	if !ok {
+		return apperror.NotFound("taxonomy.unknown_kind")
+	}
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return apperror.BadRequest("request.invalid_id")
+	}
+
+	term, err := getKindTerm(c.UserContext(), db, kind, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return apperror.NotFound("taxonomy.term_not_found")
+		}
+		return apperror.Internal(err, "taxonomy.get_term")
+	}
+	if term.Source == model.TaxonomySourceSystem {
+		return apperror.BadRequest("taxonomy.system_delete")
+	}
+
+	if err := repository.DeleteTaxonomyTerm(c.UserContext(), db, term.ID); err != nil {
+		return apperror.Internal(err, "taxonomy.delete")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Term taksonomi berhasil dihapus",
+		"success": true,
+	})
+}
+
+// GetTaxonomyStatisticsService godoc
+// @Summary Statistik per taksonomi
+// @Description Jumlah alumni per jurusan, alumni yang sedang bekerja per bidang industri dan pekerjaan per status, dikelompokkan berdasarkan kode term. Nilai yang tidak cocok dengan term mana pun dilaporkan di unclassified
+// @Tags Alumni
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Statistik per taksonomi"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /alumni/statistics/taxonomy [get]
+func GetTaxonomyStatisticsService(c *fiber.Ctx, db *mongo.Database) error {
+	breakdowns := map[string]model.TaxonomyBreakdown{}
+	for _, kind := range model.TaxonomyKinds {
+		ix, err := loadTaxonomy(c.UserContext(), db, kind, false)
+		if err != nil {
+			return apperror.Internal(err, "taxonomy.get")
+		}
+		counts, err := repository.GetTaxonomyValueCounts(c.UserContext(), db, kind)
+		if err != nil {
+			return apperror.Internal(err, "taxonomy.statistics")
+		}
+		breakdowns[kind] = buildTaxonomyBreakdown(ix, counts)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil statistik taksonomi",
+		"success": true,
+		"data": model.TaxonomyStatistics{
+			Jurusan:         breakdowns[model.TaxonomyJurusan],
+			BidangIndustri:  breakdowns[model.TaxonomyBidangIndustri],
+			StatusPekerjaan: breakdowns[model.TaxonomyStatusPekerjaan],
+		},
+	})
+}
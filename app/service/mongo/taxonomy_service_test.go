@@ -0,0 +1,99 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"clean-arch/app/model/mongo"
+)
+
+func TestTaxonomyCode(t *testing.T) {
+	tests := map[string]string{
+		"Teknik Informatika":       "teknik-informatika",
+		"  S1 - Sistem Informasi ": "s1-sistem-informasi",
+		"Akuntansi!":               "akuntansi",
+		"--":                       "",
+	}
+	for in, want := range tests {
+		if got := taxonomyCode(in); got != want {
+			t.Errorf("taxonomyCode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTaxonomyCanonicalize(t *testing.T) {
+	industri := newTaxonomyIndex(model.TaxonomyBidangIndustri,
+		withKind(kbliSections, model.TaxonomyBidangIndustri, model.TaxonomySourceKBLI))
+
+	value := "  teknologi   informasi "
+	if err := industri.canonicalize(&value); err != nil {
+		t.Fatal(err)
+	}
+	if value != "Informasi dan Komunikasi" {
+		t.Errorf("alias canonicalized to %q", value)
+	}
+
+	value = "j"
+	if err := industri.canonicalize(&value); err != nil || value != "Informasi dan Komunikasi" {
+		t.Errorf("code canonicalized to %q, err %v", value, err)
+	}
+
+	value = "Perdukunan"
+	var terr *taxonomyError
+	if err := industri.canonicalize(&value); !errors.As(err, &terr) || len(terr.Options) != len(kbliSections) {
+		t.Errorf("unknown value err = %v", err)
+	}
+
+	status := newTaxonomyIndex(model.TaxonomyStatusPekerjaan,
+		withKind(statusTerms, model.TaxonomyStatusPekerjaan, model.TaxonomySourceSystem))
+	value = "Resign"
+	if err := status.canonicalize(&value); err != nil || value != model.StatusPekerjaanResigned {
+		t.Errorf("status canonicalized to %q, err %v", value, err)
+	}
+
+	empty := newTaxonomyIndex(model.TaxonomyJurusan, nil)
+	value = "Apa Saja"
+	if err := empty.canonicalize(&value); err != nil || value != "Apa Saja" {
+		t.Errorf("empty taxonomy should accept any value, got %q, err %v", value, err)
+	}
+}
+
+func TestBuildTaxonomyBreakdown(t *testing.T) {
+	ix := newTaxonomyIndex(model.TaxonomyJurusan, []model.TaxonomyTerm{
+		{Kind: model.TaxonomyJurusan, Code: "ti", Label: "Teknik Informatika", Aliases: []string{"Informatika"}},
+		{Kind: model.TaxonomyJurusan, Code: "si", Label: "Sistem Informasi"},
+	})
+	got := buildTaxonomyBreakdown(ix, map[string]int{
+		"Teknik Informatika": 3,
+		"informatika":        2,
+		"Sistem Informasi":   4,
+		"Kedokteran":         1,
+	})
+
+	want := model.TaxonomyBreakdown{
+		Groups: []model.TaxonomyGroup{
+			{Code: "ti", Label: "Teknik Informatika", Count: 5},
+			{Code: "si", Label: "Sistem Informasi", Count: 4},
+		},
+		Unclassified: []model.TaxonomyUnclassified{{Value: "Kedokteran", Count: 1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("breakdown = %+v, want %+v", got, want)
+	}
+}
+
+func TestTermsFromValues(t *testing.T) {
+	ix := newTaxonomyIndex(model.TaxonomyJurusan, []model.TaxonomyTerm{
+		{Kind: model.TaxonomyJurusan, Code: "ti", Label: "Teknik Informatika"},
+	})
+	terms := termsFromValues(ix, model.TaxonomyJurusan,
+		[]string{"Sistem Informasi", "Sistem-Informasi", "teknik informatika", " "})
+
+	if len(terms) != 1 {
+		t.Fatalf("terms = %+v", terms)
+	}
+	if terms[0].Code != "sistem-informasi" || !reflect.DeepEqual(terms[0].Aliases, []string{"Sistem-Informasi"}) {
+		t.Errorf("term = %+v", terms[0])
+	}
+}
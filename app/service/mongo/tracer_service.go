@@ -0,0 +1,54 @@
+package service
+
+import (
+	"clean-arch/app/repository/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// defaultTracerGraduationMonth is the assumed graduation month, karena data
+// alumni hanya menyimpan tahun lulus
+const defaultTracerGraduationMonth = 7
+
+// tracerGraduationMonth returns TRACER_GRADUATION_MONTH (1-12), default Juli
+func tracerGraduationMonth() int {
+	m := int(env.Int64("TRACER_GRADUATION_MONTH", defaultTracerGraduationMonth))
+	if m > 12 {
+		return defaultTracerGraduationMonth
+	}
+	return m
+}
+
+// GetTracerStatisticsService godoc
+// @Summary Statistik tracer study
+// @Description Tingkat keterserapan kerja per tahun lulus dan jurusan, median bulan sampai pekerjaan pertama, persentase bekerja dalam 6/12 bulan, serta distribusi bidang industri dan lokasi kerja pekerjaan pertama
+// @Tags Alumni
+// @Produce json
+// @Param jurusan query string false "Filter jurusan, dipisah koma"
+// @Param angkatan query string false "Filter angkatan, contoh 2018..2020"
+// @Param tahun_lulus query string false "Filter tahun lulus (cohort), contoh 2022..2024"
+// @Success 200 {object} map[string]interface{} "Statistik tracer study"
+// @Failure 400 {object} map[string]interface{} "Filter tidak valid"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /alumni/statistics/tracer [get]
+func GetTracerStatisticsService(c *fiber.Ctx, db *mongo.Database) error {
+	alumniFilter, err := utils.ParseAlumniFilter(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_filter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	stats, err := repository.GetTracerStatistics(c.UserContext(), db, alumniFilter, tracerGraduationMonth())
+	if err != nil {
+		return apperror.Internal(err, "tracer.statistics")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil statistik tracer study",
+		"success": true,
+		"data":    stats,
+	})
+}
@@ -2,13 +2,16 @@ package service
 
 import (
 	"database/sql"
-	"log"
+	"errors"
 	"os"
 	"strconv"
 	"strings"
 
 	"clean-arch/app/model/postgre"
 	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/postgre"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -16,21 +19,15 @@ import (
 func CheckAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	key := c.Params("key")
 	if key != os.Getenv("API_KEY") {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Key tidak valid",
-			"success": false,
-		})
+		return apperror.Unauthorized("alumni.invalid_key")
 	}
 
 	nim := c.FormValue("nim")
 	if nim == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "NIM wajib diisi",
-			"success": false,
-		})
+		return apperror.BadRequest("alumni.nim_required")
 	}
 
-	alumni, err := repository.CheckAlumniByNim(db, nim)
+	alumni, err := repository.CheckAlumniByNim(c.UserContext(), db, nim)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -39,10 +36,7 @@ func CheckAlumniService(c *fiber.Ctx, db *sql.DB) error {
 				"isAlumni": false,
 			})
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal cek alumni karena " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.check")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -54,86 +48,90 @@ func CheckAlumniService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func GetAllAlumniService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni", username)
+	sel, err := utils.ParseFieldSelection(c, alumniFields, alumniIncludes)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
 
-	alumni, err := repository.GetAllAlumni(db)
+	alumni, err := repository.GetAllAlumni(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.get")
+	}
+
+	var data interface{} = alumni
+	if !sel.IsEmpty() {
+		if data, err = shapeAlumni(c.UserContext(), db, alumni, sel, gajiViewerOf(c)); err != nil {
+			return apperror.Internal(err, "alumni.get_relations")
+		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data alumni",
 		"success": true,
-		"data":    alumni,
+		"data":    data,
 	})
 }
 
 func GetAlumniByIDService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
-	log.Printf("User %s mengakses GET /api/alumni/%d", username, idInt)
+	sel, err := utils.ParseFieldSelection(c, alumniFields, alumniIncludes)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
 
-	alumni, err := repository.GetAlumniByID(db, idInt)
+	alumni, err := repository.GetAlumniByID(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Alumni tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-			"success": false,
+		return apperror.Internal(err, "alumni.get")
+	}
+
+	// Tanpa fields/include respons tetap AlumniDetail dengan foto dan sertifikat
+	if !sel.IsEmpty() {
+		shaped, err := shapeAlumni(c.UserContext(), db, []model.Alumni{*alumni}, sel, gajiViewerOf(c))
+		if err != nil {
+			return apperror.Internal(err, "alumni.get_relations")
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Berhasil mengambil data alumni",
+			"success": true,
+			"data":    shaped[0],
 		})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data alumni",
 		"success": true,
-		"data":    alumni,
+		"data": model.AlumniDetail{
+			Alumni:      *alumni,
+			AlumniFiles: getAlumniFiles(c.UserContext(), db, idInt),
+		},
 	})
 }
 
 func CreateAlumniService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
-	log.Printf("Admin %s menambah alumni baru", username)
+	logger.From(c).Info("Admin menambah alumni")
 
 	var req model.CreateAlumniRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Format data tidak valid: " + err.Error(),
-			"success": false,
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
 	}
 
-	// Validasi input
-	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "NIM, nama, jurusan, dan email wajib diisi",
-			"success": false,
-		})
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyJurusan, &req.Jurusan); err != nil {
+		return taxonomyErrorResponse(c, err)
 	}
 
-	alumni, err := repository.CreateAlumni(db, req)
+	alumni, err := repository.CreateAlumni(c.UserContext(), db, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menambah alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.create")
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
@@ -144,48 +142,31 @@ func CreateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
-	log.Printf("Admin %s mengupdate alumni ID %d", username, idInt)
+	logger.From(c).Info("Admin mengupdate alumni", "alumni_id", idInt)
 
 	var req model.UpdateAlumniRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Format data tidak valid: " + err.Error(),
-			"success": false,
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
 	}
 
-	// Validasi input
-	if req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Nama, jurusan, dan email wajib diisi",
-			"success": false,
-		})
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyJurusan, &req.Jurusan); err != nil {
+		return taxonomyErrorResponse(c, err)
 	}
 
-	alumni, err := repository.UpdateAlumni(db, idInt, req)
+	alumni, err := repository.UpdateAlumni(c.UserContext(), db, idInt, req)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Alumni tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengupdate alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.update")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -196,31 +177,21 @@ func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func DeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
-	log.Printf("Admin %s menghapus alumni ID %d", username, idInt)
+	logger.From(c).Info("Admin menghapus alumni", "alumni_id", idInt)
 
-	err = repository.DeleteAlumni(db, idInt)
+	err = repository.DeleteAlumni(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Alumni tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -230,12 +201,9 @@ func DeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func GetAlumniStatisticsService(c *fiber.Ctx, db *sql.DB) error {
-	stats, err := repository.GetAlumniStatistics(db)
+	stats, err := repository.GetAlumniStatistics(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil statistik alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.statistics")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -246,9 +214,6 @@ func GetAlumniStatisticsService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni dengan pagination", username)
-
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
@@ -264,6 +229,11 @@ func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *sql.DB) error {
 		limit = 10
 	}
 
+	searchMode, err := utils.ParseSearchMode(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
 	// Create pagination params
 	params := model.PaginationParams{
 		Page:   page,
@@ -271,24 +241,64 @@ func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *sql.DB) error {
 		SortBy: sortBy,
 		Order:  strings.ToLower(order),
 		Search: search,
+
+		SearchMode: searchMode,
+	}
+
+	cursorMode := utils.ParseCursorParams(c, &params)
+	if cursorMode && searchMode == model.SearchModeFullText && search != "" {
+		return apperror.BadRequest("request.cursor_fulltext")
+	}
+
+	sel, err := utils.ParseFieldSelection(c, alumniFields, alumniIncludes)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+	params.Fields = sel.Fields
+
+	alumniFilter, err := utils.ParseAlumniFilter(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_filter").WithDetails(fiber.Map{"reason": err.Error()})
 	}
 
 	// Get data with pagination
-	alumni, total, err := repository.GetAllAlumniWithPagination(db, params)
+	var data interface{}
+	var total int
+	var cursorPage model.CursorPage
+	if searchMode == model.SearchModeFullText && search != "" {
+		data, total, err = repository.SearchAlumniFullText(c.UserContext(), db, params, alumniFilter)
+	} else if cursorMode {
+		data, total, cursorPage, err = repository.GetAllAlumniByCursor(c.UserContext(), db, params, alumniFilter)
+	} else {
+		data, total, err = repository.GetAllAlumniWithPagination(c.UserContext(), db, params, alumniFilter)
+	}
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return apperror.BadRequest("request.invalid_cursor")
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.get")
 	}
 
-	// Calculate total pages
-	totalPages := (total + limit - 1) / limit
+	if !sel.IsEmpty() {
+		switch list := data.(type) {
+		case []model.Alumni:
+			data, err = shapeAlumni(c.UserContext(), db, list, sel, gajiViewerOf(c))
+		case []model.AlumniSearchHit:
+			data, err = shapeAlumniHits(c.UserContext(), db, list, sel, gajiViewerOf(c))
+		}
+		if err != nil {
+			return apperror.Internal(err, "alumni.get_relations")
+		}
+	}
+
+	// Calculate total pages, -1 bila count=false
+	totalPages := utils.CalculateTotalPages(total, limit)
 
-	// Create response with pagination metadata
-	response := model.AlumniResponse{
-		Data: alumni,
-		Meta: model.MetaInfo{
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data alumni",
+		"success": true,
+		"data":    data,
+		"meta": model.MetaInfo{
 			Page:   page,
 			Limit:  limit,
 			Total:  total,
@@ -296,25 +306,19 @@ func GetAllAlumniWithPaginationService(c *fiber.Ctx, db *sql.DB) error {
 			SortBy: sortBy,
 			Order:  order,
 			Search: search,
-		},
-	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Berhasil mengambil data alumni",
-		"success": true,
-		"data":    response.Data,
-		"meta":    response.Meta,
+			SearchMode: searchMode,
+			NextCursor: cursorPage.Next,
+			PrevCursor: cursorPage.Prev,
+		},
 	})
 }
 
 func GetTrashedAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	// admin only via route middleware
-	list, err := repository.GetTrashedAlumni(db)
+	list, err := repository.GetTrashedAlumni(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil trash: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.get_trash")
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data trash",
@@ -327,10 +331,7 @@ func SoftDeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
 	userID, ok := c.Locals("user_id").(int)
@@ -342,17 +343,11 @@ func SoftDeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
 		deletedByID = 0
 	}
 
-	if err := repository.SoftDeletePekerjaanByAlumniID(db, id, deletedByID); err != nil {
+	if err := repository.SoftDeletePekerjaanByAlumniID(c.UserContext(), db, id, deletedByID); err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Data pekerjaan tidak ditemukan atau sudah dihapus",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found_or_deleted")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal soft delete: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.soft_delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -365,23 +360,14 @@ func RestoreAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
-	if err := repository.RestoreAlumni(db, id); err != nil {
+	if err := repository.RestoreAlumni(c.UserContext(), db, id); err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Data tidak ditemukan atau belum di-trash",
-				"success": false,
-			})
+			return apperror.NotFound("alumni.not_in_trash")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal restore: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.restore")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -394,23 +380,14 @@ func HardDeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
-	if err := repository.HardDeletePekerjaanByAlumniID(db, id); err != nil {
+	if err := repository.HardDeletePekerjaanByAlumniID(c.UserContext(), db, id); err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"message": "Hapus permanen hanya untuk data pekerjaan yang ada di trash",
-				"success": false,
-			})
+			return apperror.BadRequest("pekerjaan.hard_delete_not_trashed")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal hapus permanen: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "alumni.hard_delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -3,6 +3,8 @@ package service
 import (
 	"clean-arch/app/model/postgre"
 	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/metrics"
 	"clean-arch/utils/postgre"
 	"database/sql"
 
@@ -11,45 +13,32 @@ import (
 
 func LoginService(c *fiber.Ctx, db *sql.DB) error {
 	var req model.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Request body tidak valid",
-		})
-	}
-
-	// Validasi input
-	if req.Username == "" || req.Password == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Username dan password harus diisi",
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
 	}
 
 	// Cari user di database
-	user, passwordHash, err := repository.GetUserByUsernameOrEmail(db, req.Username)
+	user, passwordHash, err := repository.GetUserByUsernameOrEmail(c.UserContext(), db, req.Username)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Username atau password salah",
-			})
+			metrics.ObserveLogin("user", metrics.LoginFailure)
+			return apperror.Unauthorized("auth.invalid_credentials")
 		}
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Error database",
-		})
+		metrics.ObserveLogin("user", metrics.LoginError)
+		return apperror.Internal(err, "auth.database")
 	}
 
 	// Check password
 	if !utils.CheckPassword(req.Password, passwordHash) {
-		return c.Status(401).JSON(fiber.Map{
-			"error": "Username atau password salah",
-		})
+		metrics.ObserveLogin("user", metrics.LoginFailure)
+		return apperror.Unauthorized("auth.invalid_credentials")
 	}
 
 	// Generate JWT token
 	token, err := utils.GenerateToken(*user)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Gagal generate token",
-		})
+		metrics.ObserveLogin("user", metrics.LoginError)
+		return apperror.Internal(err, "auth.generate_token")
 	}
 
 	response := model.LoginResponse{
@@ -57,6 +46,7 @@ func LoginService(c *fiber.Ctx, db *sql.DB) error {
 		Token: token,
 	}
 
+	metrics.ObserveLogin("user", metrics.LoginSuccess)
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Login berhasil",
@@ -66,45 +56,32 @@ func LoginService(c *fiber.Ctx, db *sql.DB) error {
 
 func AlumniLoginService(c *fiber.Ctx, db *sql.DB) error {
 	var req model.AlumniLoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Request body tidak valid",
-		})
-	}
-
-	// Validasi input
-	if req.NIM == "" || req.Password == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "NIM dan password harus diisi",
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
 	}
 
 	// Cari alumni di database
-	alumni, err := repository.GetAlumniByNIM(db, req.NIM)
+	alumni, err := repository.GetAlumniByNIM(c.UserContext(), db, req.NIM)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "NIM atau password salah",
-			})
+			metrics.ObserveLogin("alumni", metrics.LoginFailure)
+			return apperror.Unauthorized("auth.invalid_alumni_credentials")
 		}
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Error database",
-		})
+		metrics.ObserveLogin("alumni", metrics.LoginError)
+		return apperror.Internal(err, "auth.database")
 	}
 
 	// Check password
 	if !utils.CheckPassword(req.Password, alumni.Password) {
-		return c.Status(401).JSON(fiber.Map{
-			"error": "NIM atau password salah",
-		})
+		metrics.ObserveLogin("alumni", metrics.LoginFailure)
+		return apperror.Unauthorized("auth.invalid_alumni_credentials")
 	}
 
 	// Generate JWT token
 	token, err := utils.GenerateAlumniToken(*alumni)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Gagal generate token",
-		})
+		metrics.ObserveLogin("alumni", metrics.LoginError)
+		return apperror.Internal(err, "auth.generate_token")
 	}
 
 	// Remove password from response
@@ -115,6 +92,7 @@ func AlumniLoginService(c *fiber.Ctx, db *sql.DB) error {
 		Token:  token,
 	}
 
+	metrics.ObserveLogin("alumni", metrics.LoginSuccess)
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Login berhasil",
@@ -126,15 +104,14 @@ func GetAlumniProfileService(c *fiber.Ctx, db *sql.DB) error {
 	alumniID := c.Locals("alumni_id").(int)
 
 	// Get alumni with job history
-	alumniWithJobs, err := repository.GetAlumniWithJobs(db, alumniID)
+	alumniWithJobs, err := repository.GetAlumniWithJobs(c.UserContext(), db, alumniID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Gagal mengambil data profile",
-		})
+		return apperror.Internal(err, "auth.get_profile")
 	}
 
 	// Remove password from response
 	alumniWithJobs.Alumni.Password = ""
+	alumniWithJobs.AlumniFiles = getAlumniFiles(c.UserContext(), db, alumniID)
 
 	return c.JSON(fiber.Map{
 		"success": true,
@@ -145,26 +122,24 @@ func GetAlumniProfileService(c *fiber.Ctx, db *sql.DB) error {
 
 func RegisterAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	var req model.CreateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Request body tidak valid",
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyJurusan, &req.Jurusan); err != nil {
+		return taxonomyErrorResponse(c, err)
 	}
 
 	// Hash password
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Gagal hash password",
-		})
+		return apperror.Internal(err, "auth.hash_password")
 	}
 
 	// Create alumni
-	alumni, err := repository.CreateAlumniWithAuth(db, req, hashedPassword)
+	alumni, err := repository.CreateAlumniWithAuth(c.UserContext(), db, req, hashedPassword)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Gagal membuat akun alumni",
-		})
+		return apperror.Internal(err, "auth.create_alumni_account")
 	}
 
 	return c.JSON(fiber.Map{
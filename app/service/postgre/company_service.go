@@ -0,0 +1,551 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"clean-arch/app/model/postgre"
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// minCompanySuggestionScore is the lowest similarity shown as a suggestion
+	minCompanySuggestionScore = 0.3
+	defaultCompanySuggestions = 5
+	maxCompanySuggestions     = 20
+)
+
+var errCompanyNotFound = errors.New("perusahaan tidak ditemukan")
+
+// buildCompany validates a create/update request into a company
+func buildCompany(nama string, aliases []string, bidangIndustri, lokasiKerja string, website *string) (model.Company, error) {
+	company := model.Company{
+		Nama:           strings.TrimSpace(nama),
+		Aliases:        []string{},
+		BidangIndustri: strings.TrimSpace(bidangIndustri),
+		LokasiKerja:    strings.TrimSpace(lokasiKerja),
+	}
+	if company.Nama == "" {
+		return company, errors.New("nama perusahaan wajib diisi")
+	}
+	for _, a := range aliases {
+		if a = strings.TrimSpace(a); a != "" {
+			company.Aliases = append(company.Aliases, a)
+		}
+	}
+
+	if website != nil && strings.TrimSpace(*website) != "" {
+		w := strings.TrimSpace(*website)
+		u, err := url.Parse(w)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return company, errors.New("website harus berupa URL http atau https")
+		}
+		company.Website = &w
+	}
+
+	company.Keys = utils.CompanyKeys(company.Nama, company.Aliases)
+	return company, nil
+}
+
+// findCompanyKeyConflict returns the first key of company already used by
+// another company, string kosong jika tidak ada
+func findCompanyKeyConflict(ctx context.Context, db *sql.DB, company model.Company) (string, error) {
+	for _, key := range company.Keys {
+		other, err := repository.GetCompanyByKey(ctx, db, key)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if other.ID != company.ID {
+			return key, nil
+		}
+	}
+	return "", nil
+}
+
+// suggestCompanies ranks companies by similarity of their name and aliases to
+// query. Direktori dimuat utuh dan dinilai di aplikasi agar hasilnya sama
+// di kedua driver database.
+func suggestCompanies(companies []model.Company, query string, limit int, exclude int) []model.CompanySuggestion {
+	suggestions := []model.CompanySuggestion{}
+	for _, company := range companies {
+		if company.ID == exclude {
+			continue
+		}
+		best := 0.0
+		for _, key := range company.Keys {
+			if s := utils.CompanySimilarity(query, key); s > best {
+				best = s
+			}
+		}
+		if best >= minCompanySuggestionScore {
+			suggestions = append(suggestions, model.CompanySuggestion{Company: company, Score: best})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// errCompanyUnconfirmed is returned when a typed company name is new but
+// similar companies already exist in the directory
+var errCompanyUnconfirmed = errors.New("nama perusahaan baru belum dikonfirmasi")
+
+// resolvePekerjaanCompany links a job to the company directory before it is
+// saved. Dengan company_id, nama perusahaan disalin dari company. Tanpa
+// company_id, nama dicocokkan persis (setelah normalisasi) dengan nama dan
+// alias company. Nama yang belum dikenal tidak langsung disimpan: jika ada
+// perusahaan yang mirip, errCompanyUnconfirmed dikembalikan bersama sarannya
+// sampai klien mengirim new_company. Company baru dikembalikan sebagai pending
+// dan baru dibuat oleh linkNewCompany setelah pekerjaan tersimpan.
+func resolvePekerjaanCompany(ctx context.Context, db *sql.DB, companyID **int, nama *string, newCompany bool, bidangIndustri, lokasiKerja string) (*model.Company, []model.CompanySuggestion, error) {
+	if *companyID != nil {
+		company, err := repository.GetCompanyByID(ctx, db, **companyID)
+		if err == sql.ErrNoRows {
+			return nil, nil, errCompanyNotFound
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		*nama = company.Nama
+		return nil, nil, nil
+	}
+
+	company, err := repository.GetCompanyByKey(ctx, db, utils.NormalizeCompanyName(*nama))
+	if err == nil {
+		*companyID = &company.ID
+		*nama = company.Nama
+		return nil, nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, nil, err
+	}
+
+	pending, err := buildCompany(*nama, nil, bidangIndustri, lokasiKerja, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	*nama = pending.Nama
+	if newCompany {
+		return &pending, nil, nil
+	}
+
+	companies, err := repository.GetAllCompanies(ctx, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	if suggestions := suggestCompanies(companies, pending.Nama, defaultCompanySuggestions, 0); len(suggestions) > 0 {
+		return nil, suggestions, errCompanyUnconfirmed
+	}
+	return &pending, nil, nil
+}
+
+// linkNewCompany creates the pending company of a saved job and links the
+// job to it. Kegagalan tidak membatalkan pekerjaan yang sudah tersimpan;
+// InitCompanyDirectory akan menautkannya lagi saat start berikutnya.
+func linkNewCompany(ctx context.Context, db *sql.DB, pekerjaan *model.PekerjaanAlumni, pending *model.Company) {
+	company, err := createOrGetCompany(ctx, db, *pending)
+	if err == nil {
+		err = repository.SetPekerjaanCompany(ctx, db, pekerjaan.ID, company.ID)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn("Pekerjaan tersimpan tanpa perusahaan", "pekerjaan_id", pekerjaan.ID, "error", err)
+		return
+	}
+	pekerjaan.CompanyID = &company.ID
+}
+
+// createOrGetCompany creates the company, atau mengambil company yang sudah
+// memakai namanya bila dibuat bersamaan oleh request lain.
+func createOrGetCompany(ctx context.Context, db *sql.DB, company model.Company) (*model.Company, error) {
+	err := repository.CreateCompany(ctx, db, &company)
+	if err == repository.ErrCompanyKeyTaken {
+		return repository.GetCompanyByKey(ctx, db, company.Keys[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+// createCompanyFromPekerjaan adds a company for a name typed on a job
+func createCompanyFromPekerjaan(ctx context.Context, db *sql.DB, nama, bidangIndustri, lokasiKerja string) (*model.Company, error) {
+	company, err := buildCompany(nama, nil, bidangIndustri, lokasiKerja, nil)
+	if err != nil {
+		return nil, err
+	}
+	return createOrGetCompany(ctx, db, company)
+}
+
+// InitCompanyDirectory links the existing jobs to companies, membuat
+// company baru untuk nama yang belum dikenal.
+func InitCompanyDirectory(db *sql.DB) {
+	ctx := logger.Background("company_directory")
+	l := logger.FromContext(ctx)
+
+	list, err := repository.GetPekerjaanWithoutCompany(ctx, db)
+	if err != nil {
+		l.Error("Gagal membaca pekerjaan tanpa perusahaan", "error", err)
+		return
+	}
+
+	linked, created := 0, 0
+	for _, p := range list {
+		key := utils.NormalizeCompanyName(p.NamaPerusahaan)
+		if key == "" {
+			continue
+		}
+
+		company, err := repository.GetCompanyByKey(ctx, db, key)
+		if err == sql.ErrNoRows {
+			if company, err = createCompanyFromPekerjaan(ctx, db, p.NamaPerusahaan, p.BidangIndustri, p.LokasiKerja); err == nil {
+				created++
+			}
+		}
+		if err != nil {
+			l.Warn("Direktori perusahaan: pekerjaan dilewati", "pekerjaan_id", p.ID, "error", err)
+			continue
+		}
+
+		if err := repository.SetPekerjaanCompany(ctx, db, p.ID, company.ID); err != nil {
+			l.Error("Direktori perusahaan: gagal menautkan pekerjaan", "pekerjaan_id", p.ID, "error", err)
+			continue
+		}
+		linked++
+	}
+	if linked > 0 {
+		l.Info("Direktori perusahaan diperbarui", "linked", linked, "created", created)
+	}
+}
+
+// parseCompanyID parses the :id parameter, false jika tidak valid
+func parseCompanyID(c *fiber.Ctx) (int, bool) {
+	id, err := strconv.Atoi(c.Params("id"))
+	return id, err == nil
+}
+
+func GetCompaniesService(c *fiber.Ctx, db *sql.DB) error {
+	companies, err := repository.GetAllCompanies(c.UserContext(), db)
+	if err != nil {
+		return apperror.Internal(err, "company.get")
+	}
+	counts, err := repository.CountCompanyAlumni(c.UserContext(), db)
+	if err != nil {
+		return apperror.Internal(err, "company.get")
+	}
+
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		matches := suggestCompanies(companies, search, 0, 0)
+		companies = make([]model.Company, len(matches))
+		for i, m := range matches {
+			companies[i] = m.Company
+		}
+	}
+
+	data := make([]model.CompanySummary, len(companies))
+	for i, company := range companies {
+		data[i] = model.CompanySummary{Company: company, AlumniCount: counts[company.ID]}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data perusahaan",
+		"success": true,
+		"data":    data,
+	})
+}
+
+func SuggestCompaniesService(c *fiber.Ctx, db *sql.DB) error {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		return apperror.BadRequest("company.query_required")
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultCompanySuggestions)))
+	if limit < 1 || limit > maxCompanySuggestions {
+		limit = defaultCompanySuggestions
+	}
+
+	companies, err := repository.GetAllCompanies(c.UserContext(), db)
+	if err != nil {
+		return apperror.Internal(err, "company.get")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil saran perusahaan",
+		"success": true,
+		"data":    suggestCompanies(companies, q, limit, 0),
+	})
+}
+
+func GetCompanyByIDService(c *fiber.Ctx, db *sql.DB) error {
+	id, ok := parseCompanyID(c)
+	if !ok {
+		return apperror.BadRequest("request.invalid_id")
+	}
+
+	company, err := repository.GetCompanyByID(c.UserContext(), db, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.NotFound("company.not_found")
+		}
+		return apperror.Internal(err, "company.get")
+	}
+	counts, err := repository.CountCompanyAlumni(c.UserContext(), db)
+	if err != nil {
+		return apperror.Internal(err, "company.get")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data perusahaan",
+		"success": true,
+		"data":    model.CompanySummary{Company: *company, AlumniCount: counts[company.ID]},
+	})
+}
+
+func GetCompanyAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	id, ok := parseCompanyID(c)
+	if !ok {
+		return apperror.BadRequest("request.invalid_id")
+	}
+
+	company, err := repository.GetCompanyByID(c.UserContext(), db, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.NotFound("company.not_found")
+		}
+		return apperror.Internal(err, "company.get")
+	}
+
+	alumni, err := repository.GetCompanyAlumni(c.UserContext(), db, id, c.QueryBool("current", false))
+	if err != nil {
+		return apperror.Internal(err, "company.get_alumni")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data alumni perusahaan",
+		"success": true,
+		"data": fiber.Map{
+			"company": company,
+			"alumni":  alumni,
+		},
+	})
+}
+
+func CreateCompanyService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.CreateCompanyRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	company, err := buildCompany(req.Nama, req.Aliases, req.BidangIndustri, req.LokasiKerja, req.Website)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_data").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	conflict, err := findCompanyKeyConflict(c.UserContext(), db, company)
+	if err != nil {
+		return apperror.Internal(err, "company.create")
+	}
+	if conflict != "" {
+		return apperror.Conflict("company.name_taken", conflict)
+	}
+
+	if err := repository.CreateCompany(c.UserContext(), db, &company); err != nil {
+		// Nama yang sama bisa disimpan request lain setelah pengecekan di atas
+		if err == repository.ErrCompanyKeyTaken {
+			return apperror.Conflict("company.name_taken", company.Nama)
+		}
+		return apperror.Internal(err, "company.create")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Perusahaan berhasil ditambahkan",
+		"success": true,
+		"data":    company,
+	})
+}
+
+func UpdateCompanyService(c *fiber.Ctx, db *sql.DB) error {
+	id, ok := parseCompanyID(c)
+	if !ok {
+		return apperror.BadRequest("request.invalid_id")
+	}
+
+	var req model.UpdateCompanyRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	existing, err := repository.GetCompanyByID(c.UserContext(), db, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.NotFound("company.not_found")
+		}
+		return apperror.Internal(err, "company.update")
+	}
+
+	company, err := buildCompany(req.Nama, req.Aliases, req.BidangIndustri, req.LokasiKerja, req.Website)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_data").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+	company.ID = existing.ID
+	company.CreatedAt = existing.CreatedAt
+
+	conflict, err := findCompanyKeyConflict(c.UserContext(), db, company)
+	if err != nil {
+		return apperror.Internal(err, "company.update")
+	}
+	if conflict != "" {
+		return apperror.Conflict("company.name_taken", conflict)
+	}
+
+	if err := repository.UpdateCompany(c.UserContext(), db, &company); err != nil {
+		// Nama yang sama bisa disimpan request lain setelah pengecekan di atas
+		if err == repository.ErrCompanyKeyTaken {
+			return apperror.Conflict("company.name_taken", company.Nama)
+		}
+		return apperror.Internal(err, "company.update")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Perusahaan berhasil diupdate",
+		"success": true,
+		"data":    company,
+	})
+}
+
+func DeleteCompanyService(c *fiber.Ctx, db *sql.DB) error {
+	id, ok := parseCompanyID(c)
+	if !ok {
+		return apperror.BadRequest("request.invalid_id")
+	}
+
+	err := repository.DeleteCompany(c.UserContext(), db, id)
+	if err == sql.ErrNoRows {
+		return apperror.NotFound("company.not_found")
+	}
+	if err == repository.ErrCompanyInUse {
+		linked, err := repository.CountCompanyPekerjaan(c.UserContext(), db, id)
+		if err != nil {
+			return apperror.Internal(err, "company.delete")
+		}
+		return apperror.Conflict("company.in_use", linked)
+	}
+	if err != nil {
+		return apperror.Internal(err, "company.delete")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Perusahaan berhasil dihapus",
+		"success": true,
+	})
+}
+
+// mergeCompanyInto adds the name, aliases and missing details of source to
+// target. Nama sumber menjadi alias target.
+func mergeCompanyInto(target *model.Company, source model.Company) {
+	target.Aliases = append(target.Aliases, source.Nama)
+	target.Aliases = append(target.Aliases, source.Aliases...)
+	if target.BidangIndustri == "" {
+		target.BidangIndustri = source.BidangIndustri
+	}
+	if target.LokasiKerja == "" {
+		target.LokasiKerja = source.LokasiKerja
+	}
+	if target.Website == nil {
+		target.Website = source.Website
+	}
+
+	// Alias yang ternormalisasi sama dengan nama atau alias lain dibuang
+	seen := map[string]bool{utils.NormalizeCompanyName(target.Nama): true}
+	aliases := []string{}
+	for _, a := range target.Aliases {
+		if key := utils.NormalizeCompanyName(a); key != "" && !seen[key] {
+			seen[key] = true
+			aliases = append(aliases, a)
+		}
+	}
+	target.Aliases = aliases
+	target.Keys = utils.CompanyKeys(target.Nama, target.Aliases)
+}
+
+func MergeCompaniesService(c *fiber.Ctx, db *sql.DB) error {
+	id, ok := parseCompanyID(c)
+	if !ok {
+		return apperror.BadRequest("request.invalid_id")
+	}
+
+	var req model.MergeCompaniesRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+	if len(req.SourceIDs) == 0 {
+		return apperror.BadRequest("company.source_ids_required")
+	}
+
+	target, err := repository.GetCompanyByID(c.UserContext(), db, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.NotFound("company.not_found")
+		}
+		return apperror.Internal(err, "company.merge")
+	}
+
+	var sourceIDs []int
+	seen := map[int]bool{}
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == target.ID {
+			return apperror.BadRequest("company.merge_self")
+		}
+		if seen[sourceID] {
+			continue
+		}
+		seen[sourceID] = true
+
+		source, err := repository.GetCompanyByID(c.UserContext(), db, sourceID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return apperror.NotFound("company.source_not_found", strconv.Itoa(sourceID))
+			}
+			return apperror.Internal(err, "company.merge")
+		}
+		mergeCompanyInto(target, *source)
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+
+	moved, err := repository.MergeCompanies(c.UserContext(), db, target, sourceIDs)
+	if err != nil {
+		if err == repository.ErrCompanyKeyTaken {
+			return apperror.Conflict("company.name_taken", target.Nama)
+		}
+		return apperror.Internal(err, "company.merge")
+	}
+
+	logger.From(c).Info("Admin menggabungkan perusahaan", "sources", len(sourceIDs), "target_id", target.ID, "moved", moved)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Perusahaan berhasil digabungkan",
+		"success": true,
+		"data": model.MergeCompaniesResult{
+			Company:        *target,
+			MergedCount:    len(sourceIDs),
+			PekerjaanMoved: moved,
+		},
+	})
+}
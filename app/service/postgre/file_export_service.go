@@ -0,0 +1,451 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"clean-arch/app/model/postgre"
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/postgre"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultExportSyncMaxFiles = 200
+	defaultExportSyncMaxBytes = 200 * 1024 * 1024 // 200MB
+	defaultExportArtifactTTL  = 24 * time.Hour
+	defaultExportDir          = "./exports"
+	exportCleanupPeriod       = time.Hour
+	exportManifestName        = "manifest.csv"
+)
+
+var (
+	// Satu job export berjalan dalam satu waktu agar disk tidak dibebani berlebihan
+	exportJobSlots = make(chan struct{}, 1)
+
+	unsafeArchiveChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+	exportManifestHeader = []string{
+		"nim", "nama", "jurusan", "angkatan", "tahun_lulus", "category", "version", "original_name",
+		"archive_path", "file_type", "file_size", "uploaded_at", "verification_status", "sha256", "status",
+	}
+)
+
+// exportEntry is one file of an export archive
+type exportEntry struct {
+	Alumni      *model.Alumni
+	File        model.File
+	ArchivePath string
+}
+
+// ExportFilesService streams a ZIP archive of the current documents of the
+// alumni matching the filter, with a manifest.csv describing every file.
+// Exports above EXPORT_SYNC_MAX_FILES files or EXPORT_SYNC_MAX_BYTES bytes, or
+// with "async": true, are built by a background job instead (202 Accepted).
+func ExportFilesService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.FileExportRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	if err := validateFileExportRequest(&req); err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	entries, totalBytes, err := collectExportEntries(c.UserContext(), db, req)
+	if err != nil {
+		return apperror.Internal(err, "export.collect")
+	}
+	if len(entries) == 0 {
+		return apperror.NotFound("export.no_match")
+	}
+
+	maxFiles := env.Int64("EXPORT_SYNC_MAX_FILES", defaultExportSyncMaxFiles)
+	maxBytes := env.Int64("EXPORT_SYNC_MAX_BYTES", defaultExportSyncMaxBytes)
+	if req.Async || int64(len(entries)) > maxFiles || totalBytes > maxBytes {
+		return queueExportJob(c, db, req, entries, totalBytes)
+	}
+
+	return streamExportArchive(c, entries, "alumni-documents-"+time.Now().Format("20060102-150405")+".zip")
+}
+
+// ExportOwnFilesService streams a ZIP archive of the logged in alumni's
+// current documents. Query: optional category.
+func ExportOwnFilesService(c *fiber.Ctx, db *sql.DB) error {
+	owner, ok := currentFileOwner(c)
+	if !ok || owner.Type != model.OwnerTypeAlumni {
+		return apperror.Unauthorized("auth.alumni_id_missing")
+	}
+
+	req := model.FileExportRequest{AlumniIDs: []int{owner.ID}, Category: c.Query("category")}
+	if err := validateFileExportRequest(&req); err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	entries, _, err := collectExportEntries(c.UserContext(), db, req)
+	if err != nil {
+		return apperror.Internal(err, "export.collect")
+	}
+	if len(entries) == 0 {
+		return apperror.NotFound("export.empty")
+	}
+
+	return streamExportArchive(c, entries, "documents-"+entries[0].Alumni.NIM+".zip")
+}
+
+// GetExportJobService returns the status of an export job
+func GetExportJobService(c *fiber.Ctx, db *sql.DB) error {
+	job, err := repository.GetFileExportJobByID(c.UserContext(), db, c.Params("id"))
+	if err != nil {
+		return apperror.NotFound("export.not_found")
+	}
+
+	if job.Status == model.ExportJobCompleted {
+		job.DownloadURL = "/api/files/export/jobs/" + job.ID + "/download"
+	}
+	if job.Error != nil {
+		message := apperror.Message(apperror.Language(c), *job.Error)
+		job.Error = &message
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Export job retrieved successfully",
+		"data":    job,
+	})
+}
+
+// DownloadExportJobService serves the artifact of a completed export job
+func DownloadExportJobService(c *fiber.Ctx, db *sql.DB) error {
+	job, err := repository.GetFileExportJobByID(c.UserContext(), db, c.Params("id"))
+	if err != nil {
+		return apperror.NotFound("export.not_found")
+	}
+
+	if job.Status != model.ExportJobCompleted {
+		return apperror.Conflict("export.not_ready", job.Status)
+	}
+
+	if _, err := os.Stat(job.ArtifactPath); err != nil {
+		return apperror.New(fiber.StatusGone, "export.expired")
+	}
+
+	return c.Download(job.ArtifactPath, "alumni-documents-"+job.ID+".zip")
+}
+
+// StartFileExportCleanup fails jobs interrupted by a restart and removes
+// expired export artifacts every hour
+func StartFileExportCleanup(db *sql.DB) {
+	ctx := logger.Background("file_export_cleanup")
+	l := logger.FromContext(ctx)
+	ttl := env.Duration("EXPORT_ARTIFACT_TTL", defaultExportArtifactTTL)
+
+	if n, err := repository.FailUnfinishedFileExportJobs(ctx, db, "interrupted by server restart", time.Now().Add(ttl)); err != nil {
+		l.Error("Failed to mark interrupted export jobs", "error", err)
+	} else if n > 0 {
+		l.Info("Marked interrupted export jobs as failed", "count", n)
+	}
+	if parts, err := filepath.Glob(filepath.Join(exportBaseDir(), "*.part")); err == nil {
+		for _, part := range parts {
+			os.Remove(part)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(exportCleanupPeriod)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			jobs, err := repository.GetExpiredFileExportJobs(ctx, db, time.Now())
+			if err != nil {
+				l.Error("Failed to list expired export jobs", "error", err)
+				continue
+			}
+			for _, job := range jobs {
+				if job.ArtifactPath != "" {
+					if err := os.Remove(job.ArtifactPath); err != nil && !os.IsNotExist(err) {
+						l.Error("Failed to remove export artifact", "path", job.ArtifactPath, "error", err)
+						continue
+					}
+				}
+				if err := repository.DeleteFileExportJob(ctx, db, job.ID); err != nil {
+					l.Error("Failed to delete export job", "job_id", job.ID, "error", err)
+				}
+			}
+			if len(jobs) > 0 {
+				l.Info("Removed expired export jobs", "count", len(jobs))
+			}
+		}
+	}()
+}
+
+func validateFileExportRequest(req *model.FileExportRequest) error {
+	if len(req.AlumniIDs) == 0 && len(req.Angkatan) == 0 && len(req.Jurusan) == 0 {
+		return fmt.Errorf("alumni_ids, angkatan or jurusan is required")
+	}
+	if req.Category != "" && !slices.Contains(fileCategories, req.Category) {
+		return fmt.Errorf("category must be one of %s", strings.Join(fileCategories, ", "))
+	}
+	return nil
+}
+
+// collectExportEntries resolves the filter into the files to archive and
+// their total size
+func collectExportEntries(ctx context.Context, db *sql.DB, req model.FileExportRequest) ([]exportEntry, int64, error) {
+	alumniList, err := repository.GetAlumniForExport(ctx, db, req.AlumniIDs, req.Angkatan, req.Jurusan)
+	if err != nil || len(alumniList) == 0 {
+		return nil, 0, err
+	}
+
+	byID := make(map[int]*model.Alumni, len(alumniList))
+	ids := make([]int, 0, len(alumniList))
+	for i := range alumniList {
+		byID[alumniList[i].ID] = &alumniList[i]
+		ids = append(ids, alumniList[i].ID)
+	}
+
+	files, err := repository.GetExportableFiles(ctx, db, model.OwnerTypeAlumni, ids, req.Category)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var entries []exportEntry
+	var totalBytes int64
+	used := make(map[string]bool, len(files))
+	for _, file := range files {
+		alumni := byID[file.UserID]
+		entries = append(entries, exportEntry{
+			Alumni:      alumni,
+			File:        file,
+			ArchivePath: exportArchivePath(used, alumni.NIM, alumni.Nama, file.Category, file.Version, file.OriginalName),
+		})
+		totalBytes += file.FileSize
+	}
+
+	slices.SortFunc(entries, func(a, b exportEntry) int {
+		return strings.Compare(a.ArchivePath, b.ArchivePath)
+	})
+
+	return entries, totalBytes, nil
+}
+
+// exportArchivePath builds a unique path such as
+// "2019001_Budi_Santoso/certificate/v2_ijazah.pdf"
+func exportArchivePath(used map[string]bool, nim, nama, category string, version int, originalName string) string {
+	dir := sanitizeArchiveName(nim + "_" + nama)
+	name := sanitizeArchiveName(originalName)
+	if version > 0 {
+		name = fmt.Sprintf("v%d_%s", version, name)
+	}
+
+	path := dir + "/" + category + "/" + name
+	ext := filepath.Ext(path)
+	for i := 2; used[path]; i++ {
+		path = fmt.Sprintf("%s/%s/%s_%d%s", dir, category, strings.TrimSuffix(name, filepath.Ext(name)), i, ext)
+	}
+	used[path] = true
+	return path
+}
+
+func sanitizeArchiveName(s string) string {
+	s = strings.Trim(unsafeArchiveChars.ReplaceAllString(s, "_"), "._")
+	if s == "" {
+		return "file"
+	}
+	return s
+}
+
+// streamExportArchive writes the archive directly to the response while it
+// is built, without temporary files
+func streamExportArchive(c *fiber.Ctx, entries []exportEntry, fileName string) error {
+	c.Attachment(fileName)
+
+	// Callback berjalan setelah handler selesai dan c sudah kembali ke pool,
+	// jadi logger request diambil sekarang
+	l := logger.From(c)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := writeExportArchive(w, entries); err != nil {
+			l.Warn("Export archive aborted", "file_name", fileName, "error", err)
+		}
+	})
+	return nil
+}
+
+// writeExportArchive writes every entry followed by manifest.csv. Files that
+// can no longer be read are listed in the manifest with status "missing".
+func writeExportArchive(w io.Writer, entries []exportEntry) error {
+	zw := zip.NewWriter(w)
+	rows := [][]string{exportManifestHeader}
+
+	for _, e := range entries {
+		status, checksum := "included", ""
+
+		src, err := os.Open(e.File.FilePath)
+		if err != nil {
+			status = "missing"
+		} else {
+			// Dokumen (PDF/gambar) sudah terkompresi, jadi disimpan tanpa kompresi
+			dst, err := zw.CreateHeader(&zip.FileHeader{Name: e.ArchivePath, Method: zip.Store, Modified: e.File.UploadedAt})
+			if err != nil {
+				src.Close()
+				return err
+			}
+			hash := sha256.New()
+			_, err = io.Copy(io.MultiWriter(dst, hash), src)
+			src.Close()
+			if err != nil {
+				return err
+			}
+			checksum = hex.EncodeToString(hash.Sum(nil))
+		}
+
+		rows = append(rows, []string{
+			e.Alumni.NIM, e.Alumni.Nama, e.Alumni.Jurusan, strconv.Itoa(e.Alumni.Angkatan), strconv.Itoa(e.Alumni.TahunLulus),
+			e.File.Category, strconv.Itoa(e.File.Version), e.File.OriginalName, e.ArchivePath, e.File.FileType,
+			strconv.FormatInt(e.File.FileSize, 10), e.File.UploadedAt.Format(time.RFC3339), e.File.VerificationStatus,
+			checksum, status,
+		})
+	}
+
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: exportManifestName, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(mw)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// queueExportJob stores a job for a large export and builds it in the background
+func queueExportJob(c *fiber.Ctx, db *sql.DB, req model.FileExportRequest, entries []exportEntry, totalBytes int64) error {
+	requester, _ := currentFileOwner(c)
+	job := &model.FileExportJob{
+		ID:          uuid.New().String(),
+		Status:      model.ExportJobQueued,
+		RequestedBy: requester.ID,
+		Filter:      req,
+		FileCount:   len(entries),
+		TotalBytes:  totalBytes,
+	}
+
+	if err := repository.CreateFileExportJob(c.UserContext(), db, job); err != nil {
+		return apperror.Internal(err, "export.create")
+	}
+
+	go runExportJob(context.WithoutCancel(c.UserContext()), db, *job, entries)
+
+	c.Location("/api/files/export/jobs/" + job.ID)
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"message": "Export is too large to stream and has been queued",
+		"data":    job,
+	})
+}
+
+// runExportJob builds the archive of a queued job. ctx membawa logger dari
+// request yang membuat job.
+func runExportJob(ctx context.Context, db *sql.DB, job model.FileExportJob, entries []exportEntry) {
+	l := logger.FromContext(ctx).With("job_id", job.ID)
+	exportJobSlots <- struct{}{}
+	defer func() { <-exportJobSlots }()
+
+	startedAt := time.Now()
+	job.Status = model.ExportJobRunning
+	job.StartedAt = &startedAt
+	if err := repository.UpdateFileExportJob(ctx, db, &job); err != nil {
+		l.Error("Failed to update export job", "error", err)
+	}
+
+	err := buildExportArtifact(&job, entries)
+
+	finishedAt := time.Now()
+	expiresAt := finishedAt.Add(env.Duration("EXPORT_ARTIFACT_TTL", defaultExportArtifactTTL))
+	job.FinishedAt = &finishedAt
+	job.ExpiresAt = &expiresAt
+	job.Status = model.ExportJobCompleted
+	if err != nil {
+		// Penyebab hanya dicatat di log. Job menyimpan key katalog yang
+		// diterjemahkan saat statusnya dibaca.
+		message := "export.failed"
+		job.Status = model.ExportJobFailed
+		job.Error = &message
+		l.Error("Export job failed", "error", err)
+	}
+
+	if err := repository.UpdateFileExportJob(ctx, db, &job); err != nil {
+		l.Error("Failed to update export job", "error", err)
+	}
+}
+
+// buildExportArtifact writes the archive of a job into the export directory
+func buildExportArtifact(job *model.FileExportJob, entries []exportEntry) error {
+	dir := exportBaseDir()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, job.ID+".zip")
+	partPath := path + ".part"
+
+	out, err := os.Create(partPath)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(out)
+	err = writeExportArchive(bw, entries)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(partPath)
+		return err
+	}
+
+	if err := os.Rename(partPath, path); err != nil {
+		os.Remove(partPath)
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	job.ArtifactPath = path
+	job.ArtifactSize = info.Size()
+	return nil
+}
+
+// exportBaseDir returns EXPORT_DIR, default ./exports. Artifact sengaja
+// disimpan di luar uploads agar tidak dianggap orphan oleh file reconciler.
+func exportBaseDir() string {
+	if dir := os.Getenv("EXPORT_DIR"); dir != "" {
+		return dir
+	}
+	return defaultExportDir
+}
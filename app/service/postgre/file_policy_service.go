@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"clean-arch/app/model/postgre"
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultUploadPolicy returns the built-in policy for a category. Values can be
+// overridden with UPLOAD_<CATEGORY>_MAX_SIZE, _MAX_FILES, _MAX_TOTAL_BYTES,
+// _ALLOWED_TYPES and _ALLOWED_EXTENSIONS; a row in upload_policies
+// takes precedence over both.
+func defaultUploadPolicy(category string) (*model.UploadPolicy, bool) {
+	var policy model.UploadPolicy
+
+	switch category {
+	case "photo":
+		policy = model.UploadPolicy{
+			Category:          category,
+			MaxFileSize:       maxPhotoSize,
+			AllowedTypes:      []string{"image/jpeg", "image/png", "image/jpg"},
+			AllowedExtensions: []string{".jpg", ".jpeg", ".png"},
+		}
+	case "certificate":
+		policy = model.UploadPolicy{
+			Category:          category,
+			MaxFileSize:       maxCertificateSize,
+			AllowedTypes:      []string{"application/pdf"},
+			AllowedExtensions: []string{".pdf"},
+		}
+	case "transcript", "portfolio":
+		policy = model.UploadPolicy{
+			Category:          category,
+			MaxFileSize:       maxTranscriptSize,
+			AllowedTypes:      []string{"application/pdf"},
+			AllowedExtensions: []string{".pdf"},
+		}
+		if category == "portfolio" {
+			policy.MaxFileSize = maxPortfolioSize
+		}
+	default:
+		return nil, false
+	}
+
+	prefix := "UPLOAD_" + strings.ToUpper(category) + "_"
+	if v, err := strconv.ParseInt(os.Getenv(prefix+"MAX_SIZE"), 10, 64); err == nil && v > 0 {
+		policy.MaxFileSize = v
+	}
+	if v, err := strconv.Atoi(os.Getenv(prefix + "MAX_FILES")); err == nil && v >= 0 {
+		policy.MaxFiles = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv(prefix+"MAX_TOTAL_BYTES"), 10, 64); err == nil && v >= 0 {
+		policy.MaxTotalBytes = v
+	}
+	if v := os.Getenv(prefix + "ALLOWED_TYPES"); v != "" {
+		policy.AllowedTypes = splitList(v)
+	}
+	if v := os.Getenv(prefix + "ALLOWED_EXTENSIONS"); v != "" {
+		policy.AllowedExtensions = splitList(v)
+	}
+
+	return &policy, true
+}
+
+// getUploadPolicy resolves the effective policy for a category
+func getUploadPolicy(ctx context.Context, db *sql.DB, category string) (*model.UploadPolicy, bool) {
+	if policy, err := repository.GetUploadPolicy(ctx, db, category); err == nil {
+		return policy, true
+	}
+	return defaultUploadPolicy(category)
+}
+
+// checkUploadPolicy validates a single upload against the category policy and
+// the owner's current usage
+func checkUploadPolicy(ctx context.Context, db *sql.DB, policy *model.UploadPolicy, owner fileOwner, fileName, contentType string, size int64) (*apperror.Error, error) {
+	if violation := checkUploadFile(policy, fileName, contentType, size); violation != nil {
+		return violation, nil
+	}
+
+	if policy.MaxFiles == 0 && policy.MaxTotalBytes == 0 {
+		return nil, nil
+	}
+
+	usage, err := repository.GetFileUsageForCategory(ctx, db, owner.Type, owner.ID, policy.Category)
+	if err != nil {
+		return nil, err
+	}
+	return checkUploadQuota(policy, usage, size), nil
+}
+
+// checkUploadFile checks the size and type of one file against the policy
+func checkUploadFile(policy *model.UploadPolicy, fileName, contentType string, size int64) *apperror.Error {
+	if policy.MaxFileSize > 0 && size > policy.MaxFileSize {
+		return apperror.New(fiber.StatusRequestEntityTooLarge, "upload.file_too_large", policy.Category, formatBytes(policy.MaxFileSize))
+	}
+
+	if !isAllowedType(policy, fileName, contentType) {
+		return apperror.New(fiber.StatusUnprocessableEntity, "upload.type_not_allowed", policy.Category, strings.Join(policy.AllowedExtensions, ", "))
+	}
+
+	return nil
+}
+
+// checkUploadQuota checks a new file of size against the owner's usage of
+// the category
+func checkUploadQuota(policy *model.UploadPolicy, usage *model.CategoryUsage, size int64) *apperror.Error {
+	if policy.MaxFiles > 0 && usage.FileCount >= policy.MaxFiles {
+		return apperror.New(fiber.StatusUnprocessableEntity, "upload.max_files", policy.Category, policy.MaxFiles)
+	}
+
+	if policy.MaxTotalBytes > 0 && usage.TotalBytes+size > policy.MaxTotalBytes {
+		return apperror.New(fiber.StatusRequestEntityTooLarge, "upload.quota_exceeded", policy.Category, formatBytes(usage.TotalBytes), formatBytes(policy.MaxTotalBytes))
+	}
+
+	return nil
+}
+
+// enforceUploadPolicy is used by the multipart upload handlers. It returns the
+// error to send when the upload must be refused.
+func enforceUploadPolicy(ctx context.Context, db *sql.DB, category string, owner fileOwner, fileHeader *multipart.FileHeader) error {
+	policy, ok := getUploadPolicy(ctx, db, category)
+	if !ok {
+		return apperror.BadRequest("file.unknown_category")
+	}
+
+	violation, err := checkUploadPolicy(ctx, db, policy, owner, fileHeader.Filename, fileHeader.Header.Get("Content-Type"), fileHeader.Size)
+	if err != nil {
+		return apperror.Internal(err, "upload.check_quota")
+	}
+	if violation != nil {
+		return violation
+	}
+
+	return nil
+}
+
+func isAllowedType(policy *model.UploadPolicy, fileName, contentType string) bool {
+	if len(policy.AllowedTypes) == 0 && len(policy.AllowedExtensions) == 0 {
+		return true
+	}
+
+	for _, t := range policy.AllowedTypes {
+		if strings.EqualFold(t, contentType) {
+			return true
+		}
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	for _, e := range policy.AllowedExtensions {
+		if strings.EqualFold(e, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetUploadPoliciesService returns the effective policy for every category
+func GetUploadPoliciesService(c *fiber.Ctx, db *sql.DB) error {
+	var policies []model.UploadPolicy
+	for _, category := range fileCategories {
+		if policy, ok := getUploadPolicy(c.UserContext(), db, category); ok {
+			policies = append(policies, *policy)
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Upload policies retrieved successfully",
+		"data":    policies,
+	})
+}
+
+// UpdateUploadPolicyService stores a policy for a category (admin only)
+func UpdateUploadPolicyService(c *fiber.Ctx, db *sql.DB) error {
+	category := c.Params("category")
+	if _, ok := defaultUploadPolicy(category); !ok {
+		return apperror.BadRequest("file.unknown_category")
+	}
+
+	var req model.UpdateUploadPolicyRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	policy := &model.UploadPolicy{
+		Category:          category,
+		MaxFileSize:       req.MaxFileSize,
+		AllowedTypes:      req.AllowedTypes,
+		AllowedExtensions: req.AllowedExtensions,
+		MaxFiles:          req.MaxFiles,
+		MaxTotalBytes:     req.MaxTotalBytes,
+	}
+
+	if err := repository.UpsertUploadPolicy(c.UserContext(), db, policy); err != nil {
+		return apperror.Internal(err, "upload.update_policy")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Upload policy updated successfully",
+		"data":    policy,
+	})
+}
+
+// GetFileUsageService reports the current user's or alumni's usage against
+// quota. Admin can pass ?user_id= or ?alumni_id= to inspect another owner.
+func GetFileUsageService(c *fiber.Ctx, db *sql.DB) error {
+	owner, _ := currentFileOwner(c)
+	target := owner
+	if userID := c.QueryInt("user_id"); userID != 0 {
+		target = fileOwner{Type: model.OwnerTypeUser, ID: userID}
+	}
+	if alumniID := c.QueryInt("alumni_id"); alumniID != 0 {
+		target = fileOwner{Type: model.OwnerTypeAlumni, ID: alumniID}
+	}
+	if target != owner && c.Locals("role") != "admin" {
+		return apperror.Forbidden("file.usage_admin_only")
+	}
+
+	usage, err := repository.GetFileUsageByOwner(c.UserContext(), db, target.Type, target.ID)
+	if err != nil {
+		return apperror.Internal(err, "file.usage")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "File usage retrieved successfully",
+		"data":    buildUserFileUsage(c.UserContext(), db, model.UserFileUsage{UserID: target.ID, OwnerType: target.Type, Categories: usage}),
+	})
+}
+
+// GetAllUsersFileUsageService reports usage against quota for every user and alumni (admin only)
+func GetAllUsersFileUsageService(c *fiber.Ctx, db *sql.DB) error {
+	usageByOwner, err := repository.GetFileUsageAllOwners(c.UserContext(), db)
+	if err != nil {
+		return apperror.Internal(err, "file.usage")
+	}
+
+	responses := []model.UserFileUsage{}
+	for _, usage := range usageByOwner {
+		responses = append(responses, buildUserFileUsage(c.UserContext(), db, usage))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "File usage retrieved successfully",
+		"data":    responses,
+	})
+}
+
+// buildUserFileUsage merges raw usage with the effective policies so every
+// category is reported, including ones without files
+func buildUserFileUsage(ctx context.Context, db *sql.DB, usage model.UserFileUsage) model.UserFileUsage {
+	byCategory := make(map[string]model.CategoryUsage)
+	for _, u := range usage.Categories {
+		byCategory[u.Category] = u
+	}
+
+	result := model.UserFileUsage{UserID: usage.UserID, OwnerType: usage.OwnerType}
+	for _, category := range fileCategories {
+		u := byCategory[category]
+		u.Category = category
+		if policy, ok := getUploadPolicy(ctx, db, category); ok {
+			u.MaxFiles = policy.MaxFiles
+			u.MaxTotalBytes = policy.MaxTotalBytes
+		}
+		result.Categories = append(result.Categories, u)
+	}
+
+	return result
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1024*1024 && n%(1024*1024) == 0:
+		return fmt.Sprintf("%dMB", n/(1024*1024))
+	case n >= 1024 && n%1024 == 0:
+		return fmt.Sprintf("%dKB", n/1024)
+	default:
+		return fmt.Sprintf("%d bytes", n)
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"clean-arch/app/model/postgre"
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/scanner"
+)
+
+const (
+	quarantineDir       = "quarantine"
+	scanTimeout         = 2 * time.Minute
+	defaultRescanPeriod = time.Hour
+)
+
+var fileScanner scanner.Scanner = scanner.Noop{}
+
+// SetFileScanner sets the scanner used for uploaded files
+func SetFileScanner(s scanner.Scanner) {
+	if s == nil {
+		s = scanner.Noop{}
+	}
+	fileScanner = s
+}
+
+// scanStoredFile scans a file already written to disk and fills the scan
+// fields on fileModel. Infected files are moved into the quarantine directory.
+func scanStoredFile(ctx context.Context, fileModel *model.File) {
+	now := time.Now()
+	fileModel.ScannedAt = &now
+
+	if !fileScanner.Enabled() {
+		fileModel.ScanStatus = model.ScanStatusSkipped
+		return
+	}
+
+	l := logger.FromContext(ctx)
+	// Scan tetap selesai walaupun klien memutus request upload
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), scanTimeout)
+	defer cancel()
+
+	version, err := fileScanner.Version(ctx)
+	if err != nil {
+		l.Warn("Malware scanner unavailable, file queued for rescan", "file_name", fileModel.FileName, "error", err)
+		fileModel.ScanStatus = model.ScanStatusPending
+		return
+	}
+
+	f, err := os.Open(fileModel.FilePath)
+	if err != nil {
+		l.Error("Failed to open file for scanning", "path", fileModel.FilePath, "error", err)
+		fileModel.ScanStatus = model.ScanStatusPending
+		return
+	}
+	defer f.Close()
+
+	result, err := fileScanner.Scan(ctx, f)
+	if err != nil {
+		l.Warn("Malware scan failed, file queued for rescan", "file_name", fileModel.FileName, "error", err)
+		fileModel.ScanStatus = model.ScanStatusPending
+		return
+	}
+
+	fileModel.ScannerVersion = version
+	fileModel.ScanSignature = nil
+	fileModel.ScanStatus = model.ScanStatusClean
+
+	if result.Infected {
+		f.Close()
+		signature := result.Signature
+		fileModel.ScanSignature = &signature
+		fileModel.ScanStatus = model.ScanStatusQuarantined
+
+		quarantinePath, err := moveToQuarantine(fileModel.FilePath)
+		if err != nil {
+			l.Error("Failed to quarantine file", "path", fileModel.FilePath, "error", err)
+			return
+		}
+		fileModel.FilePath = quarantinePath
+		l.Warn("File quarantined", "file_name", fileModel.FileName, "signature", signature)
+	}
+}
+
+// moveToQuarantine moves a file out of the served upload directories
+func moveToQuarantine(filePath string) (string, error) {
+	dir := filepath.Join(uploadBasePath, quarantineDir)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	target := filepath.Join(dir, filepath.Base(filePath))
+	if err := os.Rename(filePath, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// StartFileRescanWorker periodically checks the scanner signature version and
+// rescans stored files when it changes or when earlier scans did not complete.
+// Interval is read from CLAMD_RESCAN_INTERVAL (e.g. "30m"), default 1h.
+func StartFileRescanWorker(db *sql.DB) {
+	if !fileScanner.Enabled() {
+		return
+	}
+
+	interval := defaultRescanPeriod
+	if v := os.Getenv("CLAMD_RESCAN_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			interval = d
+		}
+	}
+
+	ctx := logger.Background("file_rescan")
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			rescanFiles(ctx, db)
+			<-ticker.C
+		}
+	}()
+}
+
+func rescanFiles(ctx context.Context, db *sql.DB) {
+	l := logger.FromContext(ctx)
+	versionCtx, cancel := context.WithTimeout(ctx, scanTimeout)
+	version, err := fileScanner.Version(versionCtx)
+	cancel()
+	if err != nil {
+		l.Warn("Rescan skipped, malware scanner unavailable", "error", err)
+		return
+	}
+
+	files, err := repository.GetFilesForRescan(ctx, db, version)
+	if err != nil {
+		l.Error("Rescan skipped, failed to list files", "error", err)
+		return
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	l.Info("Rescanning files", "count", len(files), "signature_version", version)
+	for i := range files {
+		file := &files[i]
+		scanStoredFile(ctx, file)
+		if file.ScanStatus == model.ScanStatusPending {
+			continue
+		}
+		if err := repository.UpdateFileScanResult(ctx, db, file.ID, file.ScanStatus, file.ScanSignature, file.ScannerVersion, file.FilePath); err != nil {
+			l.Error("Failed to store rescan result", "file_id", strconv.Itoa(file.ID), "error", err)
+		}
+	}
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"clean-arch/app/model/postgre"
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/metrics"
+	"context"
+	"database/sql"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// Default file size limits, see defaultUploadPolicy
+	maxPhotoSize       = 1 * 1024 * 1024  // 1MB
+	maxCertificateSize = 2 * 1024 * 1024  // 2MB
+	maxTranscriptSize  = 10 * 1024 * 1024 // 10MB
+	maxPortfolioSize   = 25 * 1024 * 1024 // 25MB
+	uploadBasePath     = "./uploads"
+	photosDir          = "photos"
+	certificatesDir    = "certificates"
+)
+
+// fileCategories lists the supported upload categories
+var fileCategories = []string{"photo", "certificate", "transcript", "portfolio"}
+
+// fileOwner identifies a user or alumni that owns or uploads a file
+type fileOwner struct {
+	Type string
+	ID   int
+}
+
+// UploadPhotoService handles photo upload for users and, on the alumni
+// routes, for the logged in alumni
+func UploadPhotoService(c *fiber.Ctx, db *sql.DB) error {
+	return uploadCategoryFile(c, db, "photo", "Photo uploaded successfully")
+}
+
+// UploadCertificateService handles certificate/diploma upload
+func UploadCertificateService(c *fiber.Ctx, db *sql.DB) error {
+	return uploadCategoryFile(c, db, "certificate", "Certificate uploaded successfully")
+}
+
+func uploadCategoryFile(c *fiber.Ctx, db *sql.DB, category, successMessage string) error {
+	uploader, ok := currentFileOwner(c)
+	if !ok {
+		return apperror.Unauthorized("auth.user_id_missing")
+	}
+
+	owner := uploader
+	targetUserID := c.FormValue("user_id")
+	targetAlumniID := c.FormValue("alumni_id")
+	if targetUserID != "" || targetAlumniID != "" {
+		// Only admin can upload for another user or alumni
+		if c.Locals("role") != "admin" {
+			return apperror.Forbidden("upload.admin_only")
+		}
+		owner = fileOwner{Type: model.OwnerTypeUser}
+		target := targetUserID
+		if targetAlumniID != "" {
+			owner.Type = model.OwnerTypeAlumni
+			target = targetAlumniID
+		}
+		id, err := strconv.Atoi(target)
+		if err != nil {
+			return apperror.BadRequest("file.invalid_owner_id")
+		}
+		owner.ID = id
+	}
+
+	// Get file from form
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return apperror.BadRequest("file.required").Wrap(err)
+	}
+
+	if !fileOwnerExists(c.UserContext(), db, owner) {
+		key := "user.not_found"
+		if owner.Type == model.OwnerTypeAlumni {
+			key = "alumni.not_found"
+		}
+		return apperror.NotFound(key)
+	}
+
+	if err := enforceUploadPolicy(c.UserContext(), db, category, owner, fileHeader); err != nil {
+		return err
+	}
+
+	uploadedFile, err := saveFile(c.UserContext(), db, fileHeader, category, owner, uploader)
+	if err != nil {
+		return apperror.Internal(err, "upload.failed")
+	}
+
+	if uploadedFile.ScanStatus == model.ScanStatusQuarantined {
+		return rejectQuarantinedFile(c, uploadedFile)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": successMessage,
+		"data":    toFileResponse(c.UserContext(), uploadedFile, db),
+	})
+}
+
+// GetFilesService retrieves files for specific user or alumni
+func GetFilesService(c *fiber.Ctx, db *sql.DB) error {
+	owner := fileOwner{Type: model.OwnerTypeUser, ID: c.QueryInt("user_id")}
+	if alumniID := c.QueryInt("alumni_id"); alumniID != 0 {
+		owner = fileOwner{Type: model.OwnerTypeAlumni, ID: alumniID}
+	}
+	category := c.Query("category") // "photo" atau "certificate"
+
+	if owner.ID == 0 || category == "" {
+		return apperror.BadRequest("file.owner_category_required")
+	}
+
+	return respondOwnerFiles(c, db, owner, category)
+}
+
+// GetOwnFilesService retrieves files of the logged in alumni
+func GetOwnFilesService(c *fiber.Ctx, db *sql.DB) error {
+	owner, ok := currentFileOwner(c)
+	if !ok {
+		return apperror.Unauthorized("auth.user_id_missing")
+	}
+
+	category := c.Query("category")
+	if category == "" {
+		return apperror.BadRequest("file.category_required")
+	}
+
+	return respondOwnerFiles(c, db, owner, category)
+}
+
+// respondOwnerFiles lists the current version of an owner's files, or every
+// version with ?all_versions=true
+func respondOwnerFiles(c *fiber.Ctx, db *sql.DB, owner fileOwner, category string) error {
+	var files []model.File
+	var err error
+	if c.QueryBool("all_versions") {
+		files, err = repository.GetFileVersions(c.UserContext(), db, owner.Type, owner.ID, category)
+	} else {
+		files, err = repository.GetFilesByOwner(c.UserContext(), db, owner.Type, owner.ID, category)
+	}
+	if err != nil {
+		return apperror.Internal(err, "file.get")
+	}
+
+	var responses []model.FileResponse
+	for _, file := range files {
+		responses = append(responses, *toFileResponse(c.UserContext(), &file, db))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Files retrieved successfully",
+		"data":    responses,
+	})
+}
+
+// DeleteFileService soft deletes a file
+func DeleteFileService(c *fiber.Ctx, db *sql.DB) error {
+	fileID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.BadRequest("file.invalid_id")
+	}
+
+	file, err := repository.GetFileByID(c.UserContext(), db, fileID)
+	if err != nil {
+		return apperror.NotFound("file.not_found")
+	}
+
+	current, _ := currentFileOwner(c)
+	if c.Locals("role") != "admin" && !ownsFile(current, file) {
+		return apperror.Forbidden("file.delete_own_only")
+	}
+
+	if err := deleteFile(c.UserContext(), db, file); err != nil {
+		return apperror.Internal(err, "file.delete")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "File deleted successfully",
+	})
+}
+
+// deleteFile soft deletes file and keeps its version chain and the alumni
+// verification badge consistent. Dipakai oleh DeleteFileService dan file GC.
+func deleteFile(ctx context.Context, db *sql.DB, file *model.File) error {
+	if err := repository.DeleteFile(ctx, db, file.ID); err != nil {
+		return err
+	}
+
+	// Versi sebelumnya menjadi aktif kembali jika versi aktif dihapus
+	if file.SupersededAt == nil {
+		promoteLatestVersion(ctx, db, file)
+	}
+
+	// Badge verified ikut berubah jika sertifikat yang disetujui dihapus
+	if file.Category == "certificate" && file.OwnerType == model.OwnerTypeAlumni {
+		refreshAlumniVerification(ctx, db, file.UserID)
+	}
+	return nil
+}
+
+// DownloadFileService streams a stored file to its owner or an admin.
+// Files that are quarantined or still waiting for a scan are not served.
+func DownloadFileService(c *fiber.Ctx, db *sql.DB) error {
+	fileID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.BadRequest("file.invalid_id")
+	}
+
+	file, err := repository.GetFileByID(c.UserContext(), db, fileID)
+	if err != nil {
+		return apperror.NotFound("file.not_found")
+	}
+
+	current, _ := currentFileOwner(c)
+	if c.Locals("role") != "admin" && !ownsFile(current, file) {
+		return apperror.Forbidden("file.download_own_only")
+	}
+
+	switch file.ScanStatus {
+	case model.ScanStatusQuarantined:
+		return apperror.Forbidden("file.quarantined")
+	case model.ScanStatusPending:
+		return apperror.Conflict("file.scan_pending")
+	}
+
+	return c.Download(file.FilePath, file.OriginalName)
+}
+
+// rejectQuarantinedFile responds to an upload whose content was flagged by the scanner
+func rejectQuarantinedFile(c *fiber.Ctx, file *model.File) error {
+	signature := ""
+	if file.ScanSignature != nil {
+		signature = *file.ScanSignature
+	}
+
+	return apperror.New(fiber.StatusUnprocessableEntity, "file.malware").WithDetails(fiber.Map{
+		"signature": signature,
+		"file_id":   file.ID,
+	})
+}
+
+// currentFileOwner returns the user or alumni behind the request token
+func currentFileOwner(c *fiber.Ctx) (fileOwner, bool) {
+	if alumniID, ok := c.Locals("alumni_id").(int); ok && alumniID != 0 {
+		return fileOwner{Type: model.OwnerTypeAlumni, ID: alumniID}, true
+	}
+	if userID, ok := c.Locals("user_id").(int); ok && userID != 0 {
+		return fileOwner{Type: model.OwnerTypeUser, ID: userID}, true
+	}
+	return fileOwner{}, false
+}
+
+// ownsFile reports whether owner is the owner of file
+func ownsFile(owner fileOwner, file *model.File) bool {
+	return owner.ID == file.UserID && owner.Type == file.OwnerType
+}
+
+func fileOwnerExists(ctx context.Context, db *sql.DB, owner fileOwner) bool {
+	if owner.Type == model.OwnerTypeAlumni {
+		_, err := repository.GetAlumniByID(ctx, db, owner.ID)
+		return err == nil
+	}
+	_, err := repository.GetUserByID(ctx, db, owner.ID)
+	return err == nil
+}
+
+// Helper function to save file to disk and database
+func saveFile(ctx context.Context, db *sql.DB, fileHeader *multipart.FileHeader, category string, owner, uploadedBy fileOwner) (*model.File, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return storeFile(ctx, db, file, fileHeader.Filename, fileHeader.Header.Get("Content-Type"), category, owner, uploadedBy)
+}
+
+// storeFile writes src into the category upload directory, scans it and
+// creates the file record. Used by both multipart and resumable uploads.
+func storeFile(ctx context.Context, db *sql.DB, src io.Reader, originalName, contentType, category string, owner, uploadedBy fileOwner) (*model.File, error) {
+	uploadDir := filepath.Join(uploadBasePath, category)
+	if err := os.MkdirAll(uploadDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	ext := filepath.Ext(originalName)
+	newFileName := uuid.New().String() + ext
+	filePath := filepath.Join(uploadDir, newFileName)
+
+	out, err := os.Create(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := out.ReadFrom(src)
+	if err != nil {
+		out.Close()
+		os.Remove(filePath)
+		return nil, err
+	}
+	out.Close()
+
+	fileModel := &model.File{
+		UserID:       owner.ID,
+		OwnerType:    owner.Type,
+		FileName:     newFileName,
+		OriginalName: originalName,
+		FilePath:     filePath,
+		FileSize:     size,
+		FileType:     contentType,
+		Category:     category,
+		UploadedAt:   time.Now(),
+		UploadedBy:   uploadedBy.ID,
+		UploaderType: uploadedBy.Type,
+	}
+	if category == "certificate" {
+		fileModel.VerificationStatus = model.VerificationPending
+	}
+
+	// Scan sebelum metadata disimpan; file terinfeksi tetap dicatat dengan status karantina
+	scanStoredFile(ctx, fileModel)
+
+	version, err := repository.NextFileVersion(ctx, db, owner.Type, owner.ID, category)
+	if err != nil {
+		os.Remove(fileModel.FilePath)
+		return nil, err
+	}
+	fileModel.Version = version
+	if fileModel.ScanStatus == model.ScanStatusQuarantined {
+		// File karantina masuk riwayat tetapi tidak pernah menjadi versi aktif
+		supersededAt := fileModel.UploadedAt
+		fileModel.SupersededAt = &supersededAt
+	}
+
+	if err := repository.CreateFile(ctx, db, fileModel); err != nil {
+		os.Remove(fileModel.FilePath)
+		return nil, err
+	}
+
+	if fileModel.SupersededAt == nil {
+		if err := repository.SupersedeFileVersions(ctx, db, fileModel); err != nil {
+			logger.FromContext(ctx).Error("Failed to supersede previous versions", "category", category, "owner_id", owner.ID, "error", err)
+		}
+		// Sertifikat lama yang disetujui tidak lagi aktif
+		if category == "certificate" && owner.Type == model.OwnerTypeAlumni {
+			refreshAlumniVerification(ctx, db, owner.ID)
+		}
+	}
+
+	metrics.ObserveUpload(category, size)
+	return fileModel, nil
+}
+
+// toFileResponse converts File model to FileResponse
+func toFileResponse(ctx context.Context, file *model.File, db *sql.DB) *model.FileResponse {
+	// Fetch uploader info from users or alumni collection
+	return newFileResponse(file, getUserInfo(ctx, db, file.UploaderType, file.UploadedBy))
+}
+
+// newFileResponse builds the response of a file whose uploader is already known
+func newFileResponse(file *model.File, userInfo model.UserInfo) *model.FileResponse {
+
+	return &model.FileResponse{
+		ID:           file.ID,
+		UserID:       file.UserID,
+		OwnerType:    file.OwnerType,
+		FileName:     file.FileName,
+		OriginalName: file.OriginalName,
+		FilePath:     file.FilePath,
+		FileSize:     file.FileSize,
+		FileType:     file.FileType,
+		Category:     file.Category,
+		UploadedAt:   file.UploadedAt,
+		UploadedBy:   userInfo,
+		ScanStatus:   file.ScanStatus,
+		CreatedAt:    file.CreatedAt,
+		UpdatedAt:    file.UpdatedAt,
+
+		VerificationStatus: file.VerificationStatus,
+		RejectionReason:    file.RejectionReason,
+		VerifiedAt:         file.VerifiedAt,
+
+		Version:      file.Version,
+		IsCurrent:    file.SupersededAt == nil,
+		SupersededAt: file.SupersededAt,
+	}
+}
+
+// getUserInfo fetches uploader info with username, email, and role.
+// Untuk alumni, username diisi dengan NIM.
+func getUserInfo(ctx context.Context, db *sql.DB, uploaderType string, userID int) model.UserInfo {
+	if uploaderType == model.OwnerTypeAlumni {
+		alumni, err := repository.GetAlumniByID(ctx, db, userID)
+		if err == nil {
+			return model.UserInfo{
+				Username: alumni.NIM,
+				Email:    alumni.Email,
+				Role:     alumni.Role,
+			}
+		}
+	} else if user, err := repository.GetUserByID(ctx, db, userID); err == nil {
+		return model.UserInfo{
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
+		}
+	}
+
+	return unknownUserInfo
+}
+
+// unknownUserInfo is shown when the uploader no longer exists
+var unknownUserInfo = model.UserInfo{
+	Username: "Unknown",
+	Email:    "unknown@example.com",
+	Role:     "unknown",
+}
+
+// getAlumniFiles returns the current photo and the certificates of an alumni.
+// File yang dikarantina tidak ditampilkan.
+func getAlumniFiles(ctx context.Context, db *sql.DB, alumniID int) model.AlumniFiles {
+	relations, err := repository.GetAlumniRelations(ctx, db, []int{alumniID}, []string{model.IncludeFiles})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to load files of alumni", "alumni_id", alumniID, "error", err)
+	}
+	return buildAlumniFiles(relations[alumniID].Files)
+}
+
+// buildAlumniFiles groups the files loaded with the alumni relations,
+// terbaru lebih dulu sehingga foto pertama adalah foto aktif
+func buildAlumniFiles(files []model.FileWithUploader) model.AlumniFiles {
+	result := model.AlumniFiles{Certificates: []model.FileResponse{}}
+	for i := range files {
+		userInfo := unknownUserInfo
+		if files[i].Uploader != nil {
+			userInfo = *files[i].Uploader
+		}
+		response := newFileResponse(&files[i].File, userInfo)
+
+		switch files[i].File.Category {
+		case "photo":
+			if result.Photo == nil {
+				result.Photo = response
+			}
+		case "certificate":
+			result.Certificates = append(result.Certificates, *response)
+		}
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"clean-arch/app/model/postgre"
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetFileVersionsService returns the version history of the owner and
+// category of a file, highest version first
+func GetFileVersionsService(c *fiber.Ctx, db *sql.DB) error {
+	fileID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.BadRequest("file.invalid_id")
+	}
+
+	file, err := repository.GetFileByID(c.UserContext(), db, fileID)
+	if err != nil {
+		return apperror.NotFound("file.not_found")
+	}
+
+	current, _ := currentFileOwner(c)
+	if c.Locals("role") != "admin" && !ownsFile(current, file) {
+		return apperror.Forbidden("file.versions_own_only")
+	}
+
+	versions, err := repository.GetFileVersions(c.UserContext(), db, file.OwnerType, file.UserID, file.Category)
+	if err != nil {
+		return apperror.Internal(err, "file.get_versions")
+	}
+
+	responses := []model.FileResponse{}
+	for i := range versions {
+		responses = append(responses, *toFileResponse(c.UserContext(), &versions[i], db))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "File versions retrieved successfully",
+		"data":    responses,
+	})
+}
+
+// RestoreFileVersionService makes an earlier version the current one again.
+// The version that was current stays in the history.
+func RestoreFileVersionService(c *fiber.Ctx, db *sql.DB) error {
+	fileID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.BadRequest("file.invalid_id")
+	}
+
+	file, err := repository.GetFileByID(c.UserContext(), db, fileID)
+	if err != nil {
+		return apperror.NotFound("file.not_found")
+	}
+
+	current, _ := currentFileOwner(c)
+	if c.Locals("role") != "admin" && !ownsFile(current, file) {
+		return apperror.Forbidden("file.restore_own_only")
+	}
+
+	if err := checkRestorable(file); err != nil {
+		return err
+	}
+
+	if err := repository.RestoreFileVersion(c.UserContext(), db, file); err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.NotFound("file.not_found")
+		}
+		return apperror.Internal(err, "file.restore_version")
+	}
+
+	if file.Category == "certificate" && file.OwnerType == model.OwnerTypeAlumni {
+		refreshAlumniVerification(c.UserContext(), db, file.UserID)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "File version restored successfully",
+		"data":    toFileResponse(c.UserContext(), file, db),
+	})
+}
+
+// promoteLatestVersion makes the highest remaining version current after the
+// current version of a chain was deleted. File karantina dilewati.
+func promoteLatestVersion(ctx context.Context, db *sql.DB, deleted *model.File) {
+	versions, err := repository.GetFileVersions(ctx, db, deleted.OwnerType, deleted.UserID, deleted.Category)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to load versions after deleting file", "file_id", deleted.ID, "error", err)
+		return
+	}
+
+	candidate := promotionCandidate(versions, deleted)
+	if candidate == nil {
+		return
+	}
+
+	if err := repository.RestoreFileVersion(ctx, db, candidate); err != nil {
+		logger.FromContext(ctx).Error("Failed to promote file to current version", "file_id", candidate.ID, "error", err)
+	}
+}
+
+// promotionCandidate picks the version to make current after deleted was
+// removed from versions, highest version first. Nil jika masih ada versi
+// aktif lain atau tidak ada versi yang bisa dipakai.
+func promotionCandidate(versions []model.File, deleted *model.File) *model.File {
+	var candidate *model.File
+	for i := range versions {
+		if versions[i].ID == deleted.ID || versions[i].ScanStatus == model.ScanStatusQuarantined {
+			continue
+		}
+		// Masih ada versi aktif lain, misalnya record lama sebelum versioning
+		if versions[i].SupersededAt == nil {
+			return nil
+		}
+		if candidate == nil {
+			candidate = &versions[i]
+		}
+	}
+	return candidate
+}
+
+// checkRestorable returns the conflict when file cannot become the current
+// version again
+func checkRestorable(file *model.File) error {
+	if file.ScanStatus == model.ScanStatusQuarantined {
+		return apperror.Conflict("file.restore_quarantined")
+	}
+	if file.SupersededAt == nil {
+		return apperror.Conflict("file.already_current")
+	}
+	return nil
+}
@@ -1,25 +1,127 @@
 package service
 
 import (
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/health"
+	"clean-arch/utils/mailer"
+	"context"
 	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func CheckpointService(c *fiber.Ctx, db *sql.DB) error {
-	var currentDB string
-	if err := db.QueryRow("SELECT current_database()").Scan(&currentDB); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal cek database: " + err.Error(),
-			"success": false,
-		})
+const (
+	// Hasil readiness dipakai ulang selama HEALTH_CACHE_TTL
+	defaultHealthCacheTTL = 5 * time.Second
+	// Batas waktu setiap check, diatur dengan HEALTH_CHECK_TIMEOUT
+	defaultHealthCheckTimeout = 2 * time.Second
+)
+
+// readiness is set by InitReadiness; sebelum itu /readyz menjawab 503
+var readiness *health.Checker
+
+// InitReadiness registers the dependency checks behind /readyz. pending
+// adalah database.PendingMigrations, diteruskan dari main agar service tidak
+// bergantung pada package database.
+func InitReadiness(db *sql.DB, pending func(*sql.DB) ([]string, error)) {
+	readiness = health.NewChecker(
+		env.Duration("HEALTH_CACHE_TTL", defaultHealthCacheTTL),
+		env.Duration("HEALTH_CHECK_TIMEOUT", defaultHealthCheckTimeout),
+		health.Check{Name: "database", Run: func(ctx context.Context) error {
+			return db.PingContext(ctx)
+		}},
+		health.Check{Name: "migrations", Run: func(ctx context.Context) error {
+			versions, err := pending(db)
+			if err != nil {
+				return err
+			}
+			if len(versions) > 0 {
+				return fmt.Errorf("%d pending migrations: %s", len(versions), strings.Join(versions, ", "))
+			}
+			return nil
+		}},
+		health.Check{Name: "storage", Run: checkStorageWritable},
+		health.Check{Name: "mailer", Run: checkMailerReachable},
+	)
+}
+
+// DrainReadiness makes /readyz report draining, dipanggil saat shutdown agar
+// load balancer berhenti mengirim request baru
+func DrainReadiness() {
+	if readiness != nil {
+		readiness.Drain()
 	}
+}
+
+// checkStorageWritable writes and removes a probe file in the upload directory
+func checkStorageWritable(ctx context.Context) error {
+	if err := os.MkdirAll(uploadBasePath, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(uploadBasePath, ".readyz-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString("ok"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message":  "OK",
-		"success":  true,
-		"database": currentDB,
-		"expected": "alumni_db",
-		"matches":  currentDB == "alumni_db",
+// checkMailerReachable pings the SMTP server; mailer Noop dilaporkan disabled
+func checkMailerReachable(ctx context.Context) error {
+	if !notificationMailer.Enabled() {
+		return health.ErrDisabled
+	}
+	if p, ok := notificationMailer.(mailer.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// LivenessService reports that the process is running, tanpa memeriksa
+// dependensi
+func LivenessService(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "OK",
+		"data":    fiber.Map{"status": health.StatusOK},
 	})
 }
+
+// CheckpointService is the old liveness endpoint. Dipertahankan sebagai alias
+// /healthz selama satu rilis.
+func CheckpointService(c *fiber.Ctx) error {
+	c.Set("Deprecation", "true")
+	c.Set(fiber.HeaderLink, `</healthz>; rel="successor-version"`)
+	return LivenessService(c)
+}
+
+// ReadinessService reports the status and latency of every dependency
+// check, atau 503 bila ada yang gagal, masih startup atau sedang drain
+func ReadinessService(c *fiber.Ctx) error {
+	if readiness == nil {
+		return apperror.New(fiber.StatusServiceUnavailable, "health.starting")
+	}
+
+	report := readiness.Check(c.UserContext())
+	switch report.Status {
+	case health.StatusOK:
+		return c.JSON(fiber.Map{
+			"success": true,
+			"message": "OK",
+			"data":    report,
+		})
+	case health.StatusDraining:
+		return apperror.New(fiber.StatusServiceUnavailable, "health.draining")
+	}
+	return apperror.New(fiber.StatusServiceUnavailable, "health.not_ready").WithDetails(report)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/metrics"
+	"context"
+	"database/sql"
+)
+
+// RegisterRecordMetrics exposes the number of alumni and trashed records on
+// /metrics. Nilai dihitung ulang setiap kali Prometheus melakukan scrape.
+func RegisterRecordMetrics(db *sql.DB) {
+	metrics.RegisterRecordCounts(func(ctx context.Context) (metrics.RecordCounts, error) {
+		active, trashedAlumni, err := repository.CountAlumniRecords(ctx, db)
+		if err != nil {
+			return metrics.RecordCounts{}, err
+		}
+		trashedPekerjaan, err := repository.CountTrashedPekerjaan(ctx, db)
+		if err != nil {
+			return metrics.RecordCounts{}, err
+		}
+		return metrics.RecordCounts{
+			Alumni:  active,
+			Trashed: map[string]int{"alumni": trashedAlumni, "pekerjaan": trashedPekerjaan},
+		}, nil
+	})
+}
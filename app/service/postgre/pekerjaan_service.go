@@ -2,27 +2,25 @@ package service
 
 import (
 	"database/sql"
-	"log"
+	"errors"
 	"strconv"
 	"strings"
 
 	"clean-arch/app/model/postgre"
 	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/postgre"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func GetAllPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/pekerjaan", username)
-
-	pekerjaan, err := repository.GetAllPekerjaan(db)
+	pekerjaan, err := repository.GetAllPekerjaan(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
+	hideGajiEach(gajiViewerOf(c), pekerjaan)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data pekerjaan",
@@ -32,32 +30,21 @@ func GetAllPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func GetPekerjaanByIDService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
-	log.Printf("User %s mengakses GET /api/pekerjaan/%d", username, idInt)
-
-	pekerjaan, err := repository.GetPekerjaanByID(db, idInt)
+	pekerjaan, err := repository.GetPekerjaanByID(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
+	hideGaji(gajiViewerOf(c), pekerjaan)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data pekerjaan",
@@ -67,26 +54,18 @@ func GetPekerjaanByIDService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func GetPekerjaanByAlumniIDService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
 	alumniID := c.Params("alumni_id")
 
 	alumniIDInt, err := strconv.Atoi(alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Alumni ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("alumni.invalid_id")
 	}
 
-	log.Printf("Admin %s mengakses GET /api/pekerjaan/alumni/%d", username, alumniIDInt)
-
-	pekerjaan, err := repository.GetPekerjaanByAlumniID(db, alumniIDInt)
+	pekerjaan, err := repository.GetPekerjaanByAlumniID(c.UserContext(), db, alumniIDInt)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
+	hideGajiEach(gajiViewerOf(c), pekerjaan)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Berhasil mengambil data pekerjaan alumni",
@@ -97,143 +76,160 @@ func GetPekerjaanByAlumniIDService(c *fiber.Ctx, db *sql.DB) error {
 
 func CreatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	alumniID := c.Locals("alumni_id").(int)
-	nama := c.Locals("nama").(string)
-	log.Printf("Alumni %s (ID: %d) menambah pekerjaan baru", nama, alumniID)
+	logger.From(c).Info("Alumni menambah pekerjaan", "alumni_id", alumniID)
 
 	var req model.CreatePekerjaanRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Format data tidak valid: " + err.Error(),
-			"success": false,
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
 	}
 
 	req.AlumniID = alumniID
 
-	// Validasi input
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Nama perusahaan dan posisi jabatan wajib diisi",
-			"success": false,
-		})
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyStatusPekerjaan, &req.StatusPekerjaan); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyBidangIndustri, &req.BidangIndustri); err != nil {
+		return taxonomyErrorResponse(c, err)
 	}
 
-	pekerjaan, err := repository.CreatePekerjaan(db, req)
+	if err := prepareGaji(&req.Gaji, &req.GajiRange); err != nil {
+		return apperror.BadRequest("pekerjaan.invalid_gaji").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	violations, err := validatePekerjaanConsistency(c.UserContext(), db, alumniID, 0, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, req.StatusPekerjaan)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menambah pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.check_history")
+	}
+	if len(violations) > 0 {
+		localizePekerjaanViolations(apperror.Language(c), violations)
+		return apperror.BadRequest("pekerjaan.inconsistent").WithDetails(fiber.Map{"violations": violations})
+	}
+
+	pendingCompany, suggestions, err := resolvePekerjaanCompany(c.UserContext(), db, &req.CompanyID, &req.NamaPerusahaan, req.NewCompany, req.BidangIndustri, req.LokasiKerja)
+	if err == errCompanyNotFound {
+		return apperror.BadRequest("pekerjaan.company_not_found")
+	}
+	if err == errCompanyUnconfirmed {
+		return apperror.Conflict("pekerjaan.company_unconfirmed", req.NamaPerusahaan).WithDetails(fiber.Map{"company_suggestions": suggestions})
+	}
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.match_company")
+	}
+
+	pekerjaan, err := repository.CreatePekerjaan(c.UserContext(), db, req)
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.create")
+	}
+
+	if pendingCompany != nil {
+		linkNewCompany(c.UserContext(), db, pekerjaan, pendingCompany)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+	response := fiber.Map{
 		"message": "Pekerjaan berhasil ditambahkan",
 		"success": true,
 		"data":    pekerjaan,
-	})
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
 func UpdatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	alumniID := c.Locals("alumni_id").(int)
-	nama := c.Locals("nama").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
-	log.Printf("Alumni %s (ID: %d) mengupdate pekerjaan ID %d", nama, alumniID, idInt)
+	logger.From(c).Info("Alumni mengupdate pekerjaan", "alumni_id", alumniID, "pekerjaan_id", idInt)
 
-	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(db, idInt)
+	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
 	if ownerAlumniID != alumniID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Anda hanya dapat mengupdate riwayat pekerjaan milik Anda sendiri",
-			"success": false,
-		})
+		return apperror.Forbidden("pekerjaan.update_own_only")
 	}
 
 	var req model.UpdatePekerjaanRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Format data tidak valid: " + err.Error(),
-			"success": false,
-		})
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyStatusPekerjaan, &req.StatusPekerjaan); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	if err := canonicalizeTaxonomy(c.UserContext(), db, model.TaxonomyBidangIndustri, &req.BidangIndustri); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+
+	if err := prepareGaji(&req.Gaji, &req.GajiRange); err != nil {
+		return apperror.BadRequest("pekerjaan.invalid_gaji").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	violations, err := validatePekerjaanConsistency(c.UserContext(), db, alumniID, idInt, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, req.StatusPekerjaan)
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.check_history")
+	}
+	if len(violations) > 0 {
+		localizePekerjaanViolations(apperror.Language(c), violations)
+		return apperror.BadRequest("pekerjaan.inconsistent").WithDetails(fiber.Map{"violations": violations})
 	}
 
-	// Validasi input
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Nama perusahaan dan posisi jabatan wajib diisi",
-			"success": false,
-		})
+	pendingCompany, suggestions, err := resolvePekerjaanCompany(c.UserContext(), db, &req.CompanyID, &req.NamaPerusahaan, req.NewCompany, req.BidangIndustri, req.LokasiKerja)
+	if err == errCompanyNotFound {
+		return apperror.BadRequest("pekerjaan.company_not_found")
+	}
+	if err == errCompanyUnconfirmed {
+		return apperror.Conflict("pekerjaan.company_unconfirmed", req.NamaPerusahaan).WithDetails(fiber.Map{"company_suggestions": suggestions})
+	}
+	if err != nil {
+		return apperror.Internal(err, "pekerjaan.match_company")
 	}
 
-	pekerjaan, err := repository.UpdatePekerjaan(db, idInt, req)
+	pekerjaan, err := repository.UpdatePekerjaan(c.UserContext(), db, idInt, req)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengupdate pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.update")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+	if pendingCompany != nil {
+		linkNewCompany(c.UserContext(), db, pekerjaan, pendingCompany)
+	}
+
+	response := fiber.Map{
 		"message": "Pekerjaan berhasil diupdate",
 		"success": true,
 		"data":    pekerjaan,
-	})
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 func DeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
-	log.Printf("Admin %s menghapus pekerjaan ID %d", username, idInt)
+	logger.From(c).Info("Admin menghapus pekerjaan", "pekerjaan_id", idInt)
 
-	err = repository.DeletePekerjaan(db, idInt)
+	err = repository.DeletePekerjaan(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -243,9 +239,6 @@ func DeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *sql.DB) error {
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/pekerjaan dengan pagination", username)
-
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
@@ -261,6 +254,11 @@ func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *sql.DB) error {
 		limit = 10
 	}
 
+	searchMode, err := utils.ParseSearchMode(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
 	// Create pagination params
 	params := model.PaginationParams{
 		Page:   page,
@@ -268,24 +266,69 @@ func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *sql.DB) error {
 		SortBy: sortBy,
 		Order:  strings.ToLower(order),
 		Search: search,
+
+		SearchMode: searchMode,
 	}
 
+	cursorMode := utils.ParseCursorParams(c, &params)
+	if cursorMode && searchMode == model.SearchModeFullText && search != "" {
+		return apperror.BadRequest("request.cursor_fulltext")
+	}
+
+	sel, err := utils.ParseFieldSelection(c, pekerjaanFields, nil)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_parameter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+	params.Fields = sel.Fields
+
 	// Get data with pagination
-	pekerjaan, total, err := repository.GetAllPekerjaanWithPagination(db, params)
+	var data interface{}
+	var total int
+	var cursorPage model.CursorPage
+	if searchMode == model.SearchModeFullText && search != "" {
+		data, total, err = repository.SearchPekerjaanFullText(c.UserContext(), db, params)
+	} else if cursorMode {
+		data, total, cursorPage, err = repository.GetAllPekerjaanByCursor(c.UserContext(), db, params)
+	} else {
+		data, total, err = repository.GetAllPekerjaanWithPagination(c.UserContext(), db, params)
+	}
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return apperror.BadRequest("request.invalid_cursor")
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
-	// Calculate total pages
-	totalPages := (total + limit - 1) / limit
+	switch list := data.(type) {
+	case []model.PekerjaanAlumni:
+		hideGajiEach(gajiViewerOf(c), list)
+	case []model.PekerjaanSearchHit:
+		canView := gajiViewerOf(c)
+		for i := range list {
+			hideGaji(canView, &list[i].PekerjaanAlumni)
+		}
+	}
+
+	if len(sel.Fields) > 0 {
+		switch list := data.(type) {
+		case []model.PekerjaanAlumni:
+			data, err = selectEach(list, sel.Fields)
+		case []model.PekerjaanSearchHit:
+			data, err = selectEach(list, append(sel.Fields, "score", "highlights"))
+		}
+		if err != nil {
+			return apperror.Internal(err, "pekerjaan.get")
+		}
+	}
 
-	// Create response with pagination metadata
-	response := model.PekerjaanResponse{
-		Data: pekerjaan,
-		Meta: model.MetaInfo{
+	// Calculate total pages, -1 bila count=false
+	totalPages := utils.CalculateTotalPages(total, limit)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil data pekerjaan",
+		"success": true,
+		"data":    data,
+		"meta": model.MetaInfo{
 			Page:   page,
 			Limit:  limit,
 			Total:  total,
@@ -293,63 +336,44 @@ func GetAllPekerjaanWithPaginationService(c *fiber.Ctx, db *sql.DB) error {
 			SortBy: sortBy,
 			Order:  order,
 			Search: search,
-		},
-	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Berhasil mengambil data pekerjaan",
-		"success": true,
-		"data":    response.Data,
-		"meta":    response.Meta,
+			SearchMode: searchMode,
+			NextCursor: cursorPage.Next,
+			PrevCursor: cursorPage.Prev,
+		},
 	})
 }
 
 func SoftDeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	alumniID, isAlumni := c.Locals("alumni_id").(int)
 	role := c.Locals("role").(string)
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
 	// Get the owner of this job record
-	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(db, idInt)
+	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
 	if isAlumni && role == "alumni" {
 		// Alumni trying to delete - can only delete their own
 		if ownerAlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"message": "Maaf tidak bisa mengubah atau mengedit data selain diri sendiri",
-				"success": false,
-			})
+			return apperror.Forbidden("pekerjaan.own_data_only")
 		}
-		log.Printf("Alumni ID %d menghapus pekerjaan ID %d miliknya sendiri", alumniID, idInt)
+		logger.From(c).Info("Alumni menghapus pekerjaan miliknya", "alumni_id", alumniID, "pekerjaan_id", idInt)
 	} else if role == "admin" {
 		// Admin can delete any
-		log.Printf("Admin %s menghapus pekerjaan ID %d", username, idInt)
+		logger.From(c).Info("Admin menghapus pekerjaan", "pekerjaan_id", idInt)
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Anda tidak memiliki akses untuk menghapus pekerjaan",
-			"success": false,
-		})
+		return apperror.Forbidden("pekerjaan.delete_forbidden")
 	}
 
 	var deletedBy int
@@ -364,18 +388,12 @@ func SoftDeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 		deletedBy = alumniID
 	}
 
-	err = repository.SoftDeletePekerjaan(db, idInt, deletedBy)
+	err = repository.SoftDeletePekerjaan(c.UserContext(), db, idInt, deletedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan atau sudah dihapus",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found_or_deleted")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -387,63 +405,41 @@ func SoftDeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 func RestorePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	alumniID, isAlumni := c.Locals("alumni_id").(int)
 	role := c.Locals("role").(string)
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
 	// Get the owner of this job record
-	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(db, idInt)
+	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
 	if isAlumni && role == "alumni" {
 		// Alumni trying to restore - can only restore their own
 		if ownerAlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"message": "Maaf tidak bisa mengubah atau mengedit data selain diri sendiri",
-				"success": false,
-			})
+			return apperror.Forbidden("pekerjaan.own_data_only")
 		}
-		log.Printf("Alumni ID %d merestorasi pekerjaan ID %d miliknya sendiri", alumniID, idInt)
+		logger.From(c).Info("Alumni merestorasi pekerjaan miliknya", "alumni_id", alumniID, "pekerjaan_id", idInt)
 	} else if role == "admin" {
 		// Admin can restore any
-		log.Printf("Admin %s merestorasi pekerjaan ID %d", username, idInt)
+		logger.From(c).Info("Admin merestorasi pekerjaan", "pekerjaan_id", idInt)
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Anda tidak memiliki akses untuk merestorasi pekerjaan",
-			"success": false,
-		})
+		return apperror.Forbidden("pekerjaan.restore_forbidden")
 	}
 
-	err = repository.RestorePekerjaan(db, idInt)
+	err = repository.RestorePekerjaan(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan atau tidak terhapus",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_in_trash")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal merestorasi pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.restore")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -455,63 +451,41 @@ func RestorePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 func HardDeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	alumniID, isAlumni := c.Locals("alumni_id").(int)
 	role := c.Locals("role").(string)
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return apperror.BadRequest("request.invalid_id")
 	}
 
 	// Get the owner of this job record
-	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(db, idInt)
+	ownerAlumniID, err := repository.GetAlumniIDByPekerjaanID(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.get")
 	}
 
 	if isAlumni && role == "alumni" {
 		// Alumni trying to hard delete - can only delete their own
 		if ownerAlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"message": "Maaf tidak bisa mengubah atau mengedit data selain diri sendiri",
-				"success": false,
-			})
+			return apperror.Forbidden("pekerjaan.own_data_only")
 		}
-		log.Printf("Alumni ID %d hard delete pekerjaan ID %d miliknya sendiri", alumniID, idInt)
+		logger.From(c).Info("Alumni menghapus permanen pekerjaan miliknya", "alumni_id", alumniID, "pekerjaan_id", idInt)
 	} else if role == "admin" {
 		// Admin can hard delete any
-		log.Printf("Admin %s hard delete pekerjaan ID %d", username, idInt)
+		logger.From(c).Info("Admin menghapus permanen pekerjaan", "pekerjaan_id", idInt)
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Anda tidak memiliki akses untuk menghapus pekerjaan",
-			"success": false,
-		})
+		return apperror.Forbidden("pekerjaan.delete_forbidden")
 	}
 
-	err = repository.HardDeletePekerjaan(db, idInt)
+	err = repository.HardDeletePekerjaan(c.UserContext(), db, idInt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Pekerjaan tidak ditemukan",
-				"success": false,
-			})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus pekerjaan: " + err.Error(),
-			"success": false,
-		})
+		return apperror.Internal(err, "pekerjaan.delete")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
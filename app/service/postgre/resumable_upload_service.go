@@ -0,0 +1,482 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"clean-arch/app/model/postgre"
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/postgre"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Resumable upload protocol (headers follow tus.io naming):
+//
+//	POST   /api/files/uploads               create session, returns Location
+//	PATCH  /api/files/uploads/:id           send chunk at Upload-Offset, optional Upload-Checksum
+//	HEAD   /api/files/uploads/:id           current Upload-Offset / Upload-Length / Upload-Expires
+//	POST   /api/files/uploads/:id/complete  assemble into storage and create the file record
+//	DELETE /api/files/uploads/:id           abort
+//
+// Alumni memakai endpoint yang sama di bawah /api/files/alumni/uploads.
+const (
+	uploadTempDir              = "tmp"
+	defaultUploadSessionTTL    = 24 * time.Hour
+	uploadSessionCleanupPeriod = 15 * time.Minute
+
+	// defaultUploadCompletingTimeout is how long a session may stay in
+	// completing before cleanup treats the completion as crashed
+	defaultUploadCompletingTimeout = time.Hour
+
+	// statusChecksumMismatch is returned when a chunk does not match its checksum (tus)
+	statusChecksumMismatch = 460
+)
+
+var errUnsupportedChecksum = errors.New("unsupported checksum algorithm")
+
+// CreateUploadSessionService starts a resumable upload
+func CreateUploadSessionService(c *fiber.Ctx, db *sql.DB) error {
+	uploader, ok := currentFileOwner(c)
+	if !ok {
+		return apperror.Unauthorized("auth.user_id_missing")
+	}
+
+	var req model.CreateUploadSessionRequest
+	if err := utils.ParseBody(c, &req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	// Sama seperti upload multipart: hanya admin yang boleh mengunggah untuk
+	// user atau alumni lain
+	owner := uploader
+	if req.AlumniID != 0 {
+		owner = fileOwner{Type: model.OwnerTypeAlumni, ID: req.AlumniID}
+	} else if req.UserID != 0 {
+		owner = fileOwner{Type: model.OwnerTypeUser, ID: req.UserID}
+	}
+	if owner != uploader && c.Locals("role") != "admin" {
+		return apperror.Forbidden("upload.admin_only")
+	}
+
+	if !fileOwnerExists(c.UserContext(), db, owner) {
+		key := "user.not_found"
+		if owner.Type == model.OwnerTypeAlumni {
+			key = "alumni.not_found"
+		}
+		return apperror.NotFound(key)
+	}
+
+	policy, ok := getUploadPolicy(c.UserContext(), db, req.Category)
+	if !ok {
+		return apperror.BadRequest("file.unknown_category")
+	}
+
+	violation, err := checkUploadPolicy(c.UserContext(), db, policy, owner, req.FileName, req.FileType, req.FileSize)
+	if err != nil {
+		return apperror.Internal(err, "upload.check_quota")
+	}
+	if violation != nil {
+		return violation
+	}
+
+	tempDir := filepath.Join(uploadBasePath, uploadTempDir)
+	if err := os.MkdirAll(tempDir, os.ModePerm); err != nil {
+		return apperror.Internal(err, "upload.prepare")
+	}
+
+	sessionID := uuid.New().String()
+	tempPath := filepath.Join(tempDir, sessionID+".part")
+	tempFile, err := os.Create(tempPath)
+	if err != nil {
+		return apperror.Internal(err, "upload.prepare")
+	}
+	tempFile.Close()
+
+	session := &model.UploadSession{
+		ID:           sessionID,
+		UserID:       owner.ID,
+		OwnerType:    owner.Type,
+		UploadedBy:   uploader.ID,
+		UploaderType: uploader.Type,
+		Category:     req.Category,
+		OriginalName: filepath.Base(req.FileName),
+		FileType:     req.FileType,
+		FileSize:     req.FileSize,
+		TempPath:     tempPath,
+		ExpiresAt:    time.Now().Add(uploadSessionTTL()),
+	}
+
+	if err := repository.CreateUploadSession(c.UserContext(), db, session); err != nil {
+		os.Remove(tempPath)
+		return apperror.Internal(err, "upload.create_session")
+	}
+
+	setUploadHeaders(c, session)
+	c.Set(fiber.HeaderLocation, uploadSessionLocation(session))
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "Upload session created",
+		"data":    session,
+	})
+}
+
+// PatchUploadSessionService writes one chunk at the offset given in Upload-Offset
+func PatchUploadSessionService(c *fiber.Ctx, db *sql.DB) error {
+	session, err := loadUploadSession(c, db)
+	if err != nil {
+		return err
+	}
+
+	// Chunk tidak boleh mengubah file yang sedang dirakit oleh complete.
+	if session.Status != model.UploadSessionUploading {
+		return apperror.Conflict("upload.completing")
+	}
+
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return apperror.BadRequest("upload.offset_required")
+	}
+
+	if offset != session.Offset {
+		setUploadHeaders(c, session)
+		return apperror.Conflict("upload.offset_mismatch").WithDetails(fiber.Map{
+			"offset": session.Offset,
+		})
+	}
+
+	chunk := c.Body()
+	if len(chunk) == 0 {
+		return apperror.BadRequest("upload.empty_chunk")
+	}
+
+	if offset+int64(len(chunk)) > session.FileSize {
+		return apperror.New(fiber.StatusRequestEntityTooLarge, "upload.chunk_too_large")
+	}
+
+	if header := c.Get("Upload-Checksum"); header != "" {
+		if err := verifyChecksum(header, bytes.NewReader(chunk)); err != nil {
+			status := statusChecksumMismatch
+			if errors.Is(err, errUnsupportedChecksum) {
+				status = fiber.StatusBadRequest
+			}
+			return apperror.New(status, "upload.chunk_checksum").WithDetails(fiber.Map{"reason": err.Error()})
+		}
+	}
+
+	tempFile, err := os.OpenFile(session.TempPath, os.O_WRONLY, 0)
+	if err != nil {
+		return apperror.Internal(err, "upload.write_chunk")
+	}
+
+	// WriteAt membuat retry chunk yang sama aman (idempotent)
+	_, err = tempFile.WriteAt(chunk, offset)
+	tempFile.Close()
+	if err != nil {
+		return apperror.Internal(err, "upload.write_chunk")
+	}
+
+	newOffset := offset + int64(len(chunk))
+	if err := repository.AdvanceUploadSessionOffset(c.UserContext(), db, session.ID, offset, newOffset); err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.Conflict("upload.offset_changed")
+		}
+		return apperror.Internal(err, "upload.update_session")
+	}
+
+	session.Offset = newOffset
+	setUploadHeaders(c, session)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// HeadUploadSessionService reports how many bytes were received so a client can resume
+func HeadUploadSessionService(c *fiber.Ctx, db *sql.DB) error {
+	session, err := loadUploadSession(c, db)
+	if err != nil {
+		return err
+	}
+
+	setUploadHeaders(c, session)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// CompleteUploadSessionService assembles the uploaded bytes into storage and
+// creates the file record
+func CompleteUploadSessionService(c *fiber.Ctx, db *sql.DB) error {
+	session, err := loadUploadSession(c, db)
+	if err != nil {
+		return err
+	}
+
+	if session.Offset != session.FileSize {
+		setUploadHeaders(c, session)
+		return apperror.Conflict("upload.incomplete").WithDetails(fiber.Map{
+			"offset": session.Offset,
+			"size":   session.FileSize,
+		})
+	}
+
+	var req model.CompleteUploadSessionRequest
+	if len(c.Body()) > 0 {
+		if err := utils.ParseBody(c, &req); err != nil {
+			return utils.ValidationErrorResponse(c, err)
+		}
+	}
+
+	// Klaim sesi sebelum file dirakit: request complete lain yang bersamaan
+	// mendapat 409 sehingga file, versi dan quota tidak tercatat dua kali
+	if err := repository.ClaimUploadSession(c.UserContext(), db, session.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.Conflict("upload.completing")
+		}
+		return apperror.Internal(err, "upload.update_session")
+	}
+	completed := false
+	defer func() {
+		if !completed {
+			if err := repository.ReleaseUploadSession(context.WithoutCancel(c.UserContext()), db, session.ID); err != nil {
+				logger.From(c).Error("Failed to release upload session", "session_id", session.ID, "error", err)
+			}
+		}
+	}()
+
+	if req.Checksum != "" {
+		tempFile, err := os.Open(session.TempPath)
+		if err != nil {
+			return apperror.Internal(err, "upload.read")
+		}
+		err = verifyChecksum(req.Checksum, tempFile)
+		tempFile.Close()
+		if err != nil {
+			status := statusChecksumMismatch
+			if errors.Is(err, errUnsupportedChecksum) {
+				status = fiber.StatusBadRequest
+			}
+			return apperror.New(status, "upload.file_checksum").WithDetails(fiber.Map{"reason": err.Error()})
+		}
+	}
+
+	// Quota dicek ulang karena bisa berubah selama upload berlangsung
+	policy, ok := getUploadPolicy(c.UserContext(), db, session.Category)
+	if !ok {
+		return apperror.BadRequest("file.unknown_category")
+	}
+	violation, err := checkUploadPolicy(c.UserContext(), db, policy, sessionOwner(session), session.OriginalName, session.FileType, session.FileSize)
+	if err != nil {
+		return apperror.Internal(err, "upload.check_quota")
+	}
+	if violation != nil {
+		return violation
+	}
+
+	tempFile, err := os.Open(session.TempPath)
+	if err != nil {
+		return apperror.Internal(err, "upload.read")
+	}
+	uploadedFile, err := storeFile(c.UserContext(), db, tempFile, session.OriginalName, session.FileType, session.Category,
+		sessionOwner(session), sessionUploader(session))
+	tempFile.Close()
+	if err != nil {
+		return apperror.Internal(err, "upload.failed")
+	}
+
+	completed = true
+	discardUploadSession(c.UserContext(), db, session)
+
+	if uploadedFile.ScanStatus == model.ScanStatusQuarantined {
+		return rejectQuarantinedFile(c, uploadedFile)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "File uploaded successfully",
+		"data":    toFileResponse(c.UserContext(), uploadedFile, db),
+	})
+}
+
+// AbortUploadSessionService cancels a resumable upload and removes received bytes
+func AbortUploadSessionService(c *fiber.Ctx, db *sql.DB) error {
+	session, err := loadUploadSession(c, db)
+	if err != nil {
+		return err
+	}
+
+	if session.Status == model.UploadSessionCompleting {
+		return apperror.Conflict("upload.completing")
+	}
+
+	discardUploadSession(c.UserContext(), db, session)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Upload session cancelled",
+	})
+}
+
+// StartUploadSessionCleanup periodically removes expired sessions and their temp files
+func StartUploadSessionCleanup(db *sql.DB) {
+	ctx := logger.Background("upload_session_cleanup")
+	l := logger.FromContext(ctx)
+	go func() {
+		ticker := time.NewTicker(uploadSessionCleanupPeriod)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			now := time.Now()
+			sessions, err := repository.GetExpiredUploadSessions(ctx, db, now)
+			if err != nil {
+				l.Error("Failed to list expired upload sessions", "error", err)
+				continue
+			}
+			removed := 0
+			for i := range sessions {
+				if !uploadSessionExpired(&sessions[i], now) {
+					continue
+				}
+				discardUploadSession(ctx, db, &sessions[i])
+				removed++
+			}
+			if removed > 0 {
+				l.Info("Removed expired upload sessions", "count", removed)
+			}
+		}
+	}()
+}
+
+// loadUploadSession fetches the session from :id and checks ownership and
+// expiry
+func loadUploadSession(c *fiber.Ctx, db *sql.DB) (*model.UploadSession, error) {
+	session, err := repository.GetUploadSessionByID(c.UserContext(), db, c.Params("id"))
+	if err != nil {
+		return nil, apperror.NotFound("upload.session_not_found")
+	}
+
+	if uploader, _ := currentFileOwner(c); c.Locals("role") != "admin" && uploader != sessionUploader(session) {
+		return nil, apperror.Forbidden("upload.own_only")
+	}
+
+	if uploadSessionExpired(session, time.Now()) {
+		discardUploadSession(c.UserContext(), db, session)
+		return nil, apperror.New(fiber.StatusGone, "upload.session_expired")
+	}
+
+	return session, nil
+}
+
+// sessionOwner returns the owner of the file being uploaded. Sesi yang
+// dibuat sebelum alumni bisa upload tidak punya owner_type dan milik user.
+func sessionOwner(session *model.UploadSession) fileOwner {
+	if session.OwnerType == "" {
+		return fileOwner{Type: model.OwnerTypeUser, ID: session.UserID}
+	}
+	return fileOwner{Type: session.OwnerType, ID: session.UserID}
+}
+
+// sessionUploader returns who created the session
+func sessionUploader(session *model.UploadSession) fileOwner {
+	if session.UploaderType == "" {
+		return fileOwner{Type: model.OwnerTypeUser, ID: session.UploadedBy}
+	}
+	return fileOwner{Type: session.UploaderType, ID: session.UploadedBy}
+}
+
+// uploadSessionLocation is the URL of the session on the route group of its uploader
+func uploadSessionLocation(session *model.UploadSession) string {
+	if sessionUploader(session).Type == model.OwnerTypeAlumni {
+		return "/api/files/alumni/uploads/" + session.ID
+	}
+	return "/api/files/uploads/" + session.ID
+}
+
+func discardUploadSession(ctx context.Context, db *sql.DB, session *model.UploadSession) {
+	if err := os.Remove(session.TempPath); err != nil && !os.IsNotExist(err) {
+		logger.FromContext(ctx).Error("Failed to remove temp upload", "path", session.TempPath, "error", err)
+	}
+	if err := repository.DeleteUploadSession(ctx, db, session.ID); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete upload session", "session_id", session.ID, "error", err)
+	}
+}
+
+func setUploadHeaders(c *fiber.Ctx, session *model.UploadSession) {
+	c.Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(session.FileSize, 10))
+	c.Set("Upload-Expires", session.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+// uploadSessionExpired reports whether session can be discarded. Sesi yang
+// sedang di-complete baru dihapus setelah macet lebih lama dari
+// UPLOAD_COMPLETING_TIMEOUT, agar file yang sedang dirakit tidak hilang.
+func uploadSessionExpired(session *model.UploadSession, now time.Time) bool {
+	if !now.After(session.ExpiresAt) {
+		return false
+	}
+	if session.Status == model.UploadSessionCompleting {
+		return now.Sub(session.UpdatedAt) > env.Duration("UPLOAD_COMPLETING_TIMEOUT", defaultUploadCompletingTimeout)
+	}
+	return true
+}
+
+// uploadSessionTTL is read from UPLOAD_SESSION_TTL (e.g. "12h"), default 24h
+func uploadSessionTTL() time.Duration {
+	if v := os.Getenv("UPLOAD_SESSION_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultUploadSessionTTL
+}
+
+// verifyChecksum checks r against a tus style checksum "<algorithm> <base64 digest>"
+func verifyChecksum(header string, r io.Reader) error {
+	parts := strings.SplitN(strings.TrimSpace(header), " ", 2)
+	if len(parts) != 2 {
+		return errors.New("checksum must be formatted as \"<algorithm> <base64 digest>\"")
+	}
+
+	var h hash.Hash
+	switch strings.ToLower(parts[0]) {
+	case "md5":
+		h = md5.New()
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	default:
+		return errUnsupportedChecksum
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return errors.New("checksum digest is not valid base64")
+	}
+
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(h.Sum(nil), expected) {
+		return errors.New("checksum mismatch")
+	}
+
+	return nil
+}
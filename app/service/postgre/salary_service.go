@@ -0,0 +1,148 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+
+	"clean-arch/app/model/postgre"
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultSalaryMinGroupSize is the smallest group whose salary percentiles are
+// shown, agar gaji individu tidak bisa ditebak dari grup kecil
+const defaultSalaryMinGroupSize = 5
+
+// gajiViewer reports whether the requester may see the salary of an alumni
+type gajiViewer func(alumniID int) bool
+
+// gajiViewerOf returns the salary visibility of the requester: admin melihat
+// semua gaji, alumni hanya gajinya sendiri, pengguna lain hanya statistik agregat.
+func gajiViewerOf(c *fiber.Ctx) gajiViewer {
+	if role, _ := c.Locals("role").(string); role == "admin" {
+		return func(int) bool { return true }
+	}
+	self, _ := c.Locals("alumni_id").(int)
+	return func(alumniID int) bool {
+		return self != 0 && self == alumniID
+	}
+}
+
+// hideGaji removes gaji and gaji_range of a job the viewer may not see
+func hideGaji(canView gajiViewer, p *model.PekerjaanAlumni) {
+	if p != nil && !canView(p.AlumniID) {
+		p.Gaji = nil
+		p.GajiRange = nil
+	}
+}
+
+// hideGajiEach is hideGaji for every job in list
+func hideGajiEach(canView gajiViewer, list []model.PekerjaanAlumni) {
+	for i := range list {
+		hideGaji(canView, &list[i])
+	}
+}
+
+// prepareGaji validates the salary of a create/update request. Jika hanya
+// gaji_range yang dikirim, gaji terstruktur di-parse dari teksnya; jika gaji
+// dikirim tanpa gaji_range, gaji_range diisi dari gaji agar tetap konsisten.
+func prepareGaji(gaji **model.Gaji, gajiRange **string) error {
+	if *gaji != nil {
+		if err := utils.NormalizeGaji(*gaji); err != nil {
+			return err
+		}
+		if *gajiRange == nil || strings.TrimSpace(**gajiRange) == "" {
+			text := utils.FormatGaji(**gaji)
+			*gajiRange = &text
+		}
+		return nil
+	}
+
+	if *gajiRange != nil && strings.TrimSpace(**gajiRange) != "" {
+		parsed, err := utils.ParseGajiRange(**gajiRange)
+		if err != nil {
+			return err
+		}
+		*gaji = parsed
+	}
+	return nil
+}
+
+// MigrateGajiRange converts the free-text gaji_range of existing jobs into
+// structured salary. Teks yang tidak dikenali dibiarkan dan dicatat di log.
+func MigrateGajiRange(db *sql.DB) {
+	ctx := logger.Background("gaji_migration")
+	l := logger.FromContext(ctx)
+
+	list, err := repository.GetPekerjaanWithoutGaji(ctx, db)
+	if err != nil {
+		l.Error("Gagal membaca gaji_range untuk migrasi gaji", "error", err)
+		return
+	}
+
+	converted, skipped := 0, 0
+	for _, p := range list {
+		gaji, err := utils.ParseGajiRange(*p.GajiRange)
+		if err != nil {
+			l.Warn("Migrasi gaji: pekerjaan dilewati", "pekerjaan_id", p.ID, "error", err)
+			skipped++
+			continue
+		}
+		if err := repository.SetPekerjaanGaji(ctx, db, p.ID, *gaji); err != nil {
+			l.Error("Migrasi gaji: gagal menyimpan pekerjaan", "pekerjaan_id", p.ID, "error", err)
+			skipped++
+			continue
+		}
+		converted++
+	}
+	if converted+skipped > 0 {
+		l.Info("Migrasi gaji_range selesai", "converted", converted, "skipped", skipped)
+	}
+}
+
+// suppressSmallGroups hides the percentiles of groups smaller than minSize
+func suppressSmallGroups(stats *model.SalaryStatistics, minSize int) {
+	stats.MinGroupSize = minSize
+	suppress := func(g *model.SalaryGroup) {
+		if g.Count > 0 && g.Count < minSize {
+			g.Suppressed = true
+			g.P25, g.P50, g.P75, g.P90 = nil, nil, nil, nil
+		}
+	}
+
+	suppress(&stats.Overall)
+	for _, groups := range [][]model.SalaryGroup{stats.ByJurusan, stats.ByBidangIndustri, stats.ByTahunLulus} {
+		for i := range groups {
+			suppress(&groups[i])
+		}
+	}
+}
+
+func GetSalaryStatisticsService(c *fiber.Ctx, db *sql.DB) error {
+	alumniFilter, err := utils.ParseAlumniFilter(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_filter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(c.Query("currency", model.DefaultGajiCurrency)))
+	if len(currency) != 3 {
+		return apperror.BadRequest("salary.invalid_currency")
+	}
+
+	stats, err := repository.GetSalaryStatistics(c.UserContext(), db, alumniFilter, currency)
+	if err != nil {
+		return apperror.Internal(err, "salary.statistics")
+	}
+	suppressSmallGroups(stats, int(env.Int64("SALARY_MIN_GROUP_SIZE", defaultSalaryMinGroupSize)))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil statistik gaji",
+		"success": true,
+		"data":    stats,
+	})
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"database/sql"
+
+	"clean-arch/app/repository/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/env"
+	"clean-arch/utils/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultTracerGraduationMonth is the assumed graduation month, karena data
+// alumni hanya menyimpan tahun lulus
+const defaultTracerGraduationMonth = 7
+
+// tracerGraduationMonth returns TRACER_GRADUATION_MONTH (1-12), default Juli
+func tracerGraduationMonth() int {
+	m := int(env.Int64("TRACER_GRADUATION_MONTH", defaultTracerGraduationMonth))
+	if m > 12 {
+		return defaultTracerGraduationMonth
+	}
+	return m
+}
+
+func GetTracerStatisticsService(c *fiber.Ctx, db *sql.DB) error {
+	alumniFilter, err := utils.ParseAlumniFilter(c)
+	if err != nil {
+		return apperror.BadRequest("request.invalid_filter").WithDetails(fiber.Map{"reason": err.Error()})
+	}
+
+	stats, err := repository.GetTracerStatistics(c.UserContext(), db, alumniFilter, tracerGraduationMonth())
+	if err != nil {
+		return apperror.Internal(err, "tracer.statistics")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Berhasil mengambil statistik tracer study",
+		"success": true,
+		"data":    stats,
+	})
+}
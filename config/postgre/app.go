@@ -6,16 +6,31 @@ import (
 
 	"clean-arch/app/service/postgre"
 	"clean-arch/middleware/postgre"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/metrics"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
 func NewApp(db *sql.DB) *fiber.App {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		// Semua error dirender sebagai envelope {success, message, error{code, details}}
+		ErrorHandler: apperror.Handler,
+	})
 	
 	app.Use(cors.New())
+	app.Use(middleware.RequestID)
 	app.Use(middleware.LoggerMiddleware)
+	app.Use(middleware.Metrics)
+	app.Use(middleware.Tracing)
+
+	// Metrics Prometheus (latency HTTP, query database, login, upload)
+	app.Get("/metrics", metrics.Handler())
+
+	// Probe liveness (proses hidup) dan readiness (database, storage, mailer)
+	app.Get("/healthz", service.LivenessService)
+	app.Get("/readyz", service.ReadinessService)
 
 	app.Static("/", "./public")
 
@@ -24,11 +39,10 @@ func NewApp(db *sql.DB) *fiber.App {
 	})
 
 	api := app.Group("/api")
-	
-	api.Get("/checkpoint", func(c *fiber.Ctx) error {
-		return service.CheckpointService(c, db)
-	})
 
+	// Deprecated: alias /healthz untuk klien lama, hapus setelah satu rilis
+	api.Get("/checkpoint", service.CheckpointService)
+	
 	api.Post("/login", func(c *fiber.Ctx) error {
 		return service.LoginService(c, db)
 	})
@@ -106,6 +120,9 @@ func NewApp(db *sql.DB) *fiber.App {
 	})
 
 	log.Println("All routes registered successfully:")
+	log.Println("- GET /healthz")
+	log.Println("- GET /readyz")
+	log.Println("- GET /api/checkpoint (deprecated, alias /healthz)")
 	log.Println("- POST /api/login")
 	log.Println("- GET /api/profile (protected)")
 	log.Println("- GET /api/alumni (protected)")
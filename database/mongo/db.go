@@ -6,8 +6,12 @@ import (
 	"os"
 	"time"
 
+	"clean-arch/utils/metrics"
+
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
 func ConnectDB() *mongo.Client {
@@ -19,7 +23,13 @@ func ConnectDB() *mongo.Client {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	// Setiap command menjadi span dan tercatat di /metrics bersama statistik pool
+	opts := options.Client().
+		ApplyURI(mongoURI).
+		SetMonitor(commandMonitors(otelmongo.NewMonitor(), metrics.MongoCommandMonitor())).
+		SetPoolMonitor(metrics.MongoPoolMonitor())
+
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		log.Fatal("Failed to connect to MongoDB:", err)
 	}
@@ -33,6 +43,34 @@ func ConnectDB() *mongo.Client {
 	return client
 }
 
+// commandMonitors fans the command events out to every monitor, karena
+// client MongoDB hanya menerima satu CommandMonitor
+func commandMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, evt)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, evt)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, evt)
+				}
+			}
+		},
+	}
+}
+
 func GetDatabase(client *mongo.Client) *mongo.Database {
 	dbName := os.Getenv("MONGODB_DB")
 	if dbName == "" {
@@ -5,7 +5,9 @@ import (
 	"log"
 	"os"
 
-	_ "github.com/lib/pq"
+	"clean-arch/utils/metrics"
+
+	"github.com/lib/pq"
 )
 
 func ConnectDB() *sql.DB {
@@ -14,10 +16,13 @@ func ConnectDB() *sql.DB {
 		log.Fatal("DB_DSN environment variable is not set")
 	}
 
-	db, err := sql.Open("postgres", dsn)
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
 		log.Fatal("Failed to open database connection:", err)
 	}
+	// Durasi query dan statistik pool diekspos di /metrics
+	db := sql.OpenDB(instrumentedConnector{connector})
+	metrics.RegisterSQLStats(db, "postgres")
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
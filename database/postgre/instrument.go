@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"clean-arch/utils/metrics"
+	"clean-arch/utils/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentedConnector wraps the pq connector so that every query, exec
+// dan transaksi tercatat di metrics dan menjadi span tanpa mengubah kode
+// repository
+type instrumentedConnector struct {
+	driver.Connector
+}
+
+func (ic instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := ic.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{conn: conn}, nil
+}
+
+// instrument starts the span of one database call. Fungsi yang dikembalikan
+// mengakhiri span dan mencatat durasinya di metrics.
+func instrument(ctx context.Context, operation, query string) func(error) {
+	attrs := []attribute.KeyValue{semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)}
+	if query != "" {
+		attrs = append(attrs, semconv.DBQueryText(query))
+	}
+	start := time.Now()
+	_, span := tracing.Start(ctx, "postgres."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return func(err error) {
+		metrics.ObserveQuery("postgres", operation, time.Since(start), err)
+		tracing.End(span, err)
+	}
+}
+
+// sqlOperation returns the lower-cased leading keyword of query (select,
+// insert, ...) agar label metrics tidak bergantung pada isi query
+func sqlOperation(query string) string {
+	query = strings.TrimSpace(query)
+	if i := strings.IndexFunc(query, func(r rune) bool { return r == ' ' || r == '\n' || r == '\t' || r == '(' }); i >= 0 {
+		query = query[:i]
+	}
+	switch op := strings.ToLower(query); op {
+	case "select", "insert", "update", "delete", "with", "create", "alter", "drop":
+		return op
+	}
+	return "other"
+}
+
+// instrumentedConn forwards to the pq connection. Prepared statement tidak
+// dibungkus karena repository hanya memakai Query/Exec dengan argumen.
+type instrumentedConn struct {
+	conn driver.Conn
+}
+
+func (ic *instrumentedConn) Prepare(query string) (driver.Stmt, error) {
+	return ic.conn.Prepare(query)
+}
+
+func (ic *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := ic.conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return ic.conn.Prepare(query)
+}
+
+func (ic *instrumentedConn) Close() error {
+	return ic.conn.Close()
+}
+
+func (ic *instrumentedConn) Begin() (driver.Tx, error) {
+	return ic.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (ic *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (tx driver.Tx, err error) {
+	done := instrument(ctx, "begin", "")
+	if b, ok := ic.conn.(driver.ConnBeginTx); ok {
+		tx, err = b.BeginTx(ctx, opts)
+	} else {
+		tx, err = ic.conn.Begin()
+	}
+	done(err)
+	if err != nil {
+		return nil, err
+	}
+	return instrumentedTx{ctx: ctx, tx: tx}, nil
+}
+
+func (ic *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := ic.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	done := instrument(ctx, sqlOperation(query), query)
+	rows, err := q.QueryContext(ctx, query, args)
+	done(err)
+	return rows, err
+}
+
+func (ic *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := ic.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	done := instrument(ctx, sqlOperation(query), query)
+	res, err := e.ExecContext(ctx, query, args)
+	done(err)
+	return res, err
+}
+
+func (ic *instrumentedConn) Ping(ctx context.Context) error {
+	if p, ok := ic.conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (ic *instrumentedConn) ResetSession(ctx context.Context) error {
+	if r, ok := ic.conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (ic *instrumentedConn) IsValid() bool {
+	if v, ok := ic.conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// instrumentedTx keeps the context of BeginTx so commit and rollback become
+// child span dari request yang sama
+type instrumentedTx struct {
+	ctx context.Context
+	tx  driver.Tx
+}
+
+func (it instrumentedTx) Commit() error {
+	done := instrument(it.ctx, "commit", "")
+	err := it.tx.Commit()
+	done(err)
+	return err
+}
+
+func (it instrumentedTx) Rollback() error {
+	done := instrument(it.ctx, "rollback", "")
+	err := it.tx.Rollback()
+	done(err)
+	return err
+}
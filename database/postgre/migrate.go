@@ -0,0 +1,93 @@
+package database
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate applies every embedded migration that is not yet recorded in
+// schema_migrations. Each file runs in its own transaction, in name order.
+func Migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    VARCHAR(255) PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+	)`); err != nil {
+		return err
+	}
+
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range pending {
+		content, err := migrationFiles.ReadFile(path.Join("migrations", version+".sql"))
+		if err != nil {
+			return err
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(string(content)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s: %w", version, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		log.Printf("Applied migration %s", version)
+	}
+
+	return nil
+}
+
+// PendingMigrations lists embedded migrations not yet applied
+func PendingMigrations(db *sql.DB) ([]string, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]bool)
+	rows, err := db.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, entry := range entries {
+		version := strings.TrimSuffix(entry.Name(), ".sql")
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+	sort.Strings(pending)
+
+	return pending, nil
+}
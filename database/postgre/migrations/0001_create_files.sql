@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS files (
+    id              SERIAL PRIMARY KEY,
+    user_id         INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name       VARCHAR(255) NOT NULL,
+    original_name   VARCHAR(255) NOT NULL,
+    file_path       VARCHAR(500) NOT NULL,
+    file_size       BIGINT NOT NULL,
+    file_type       VARCHAR(100) NOT NULL DEFAULT '',
+    category        VARCHAR(50) NOT NULL,
+    uploaded_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    uploaded_by     INTEGER NOT NULL,
+    scan_status     VARCHAR(20) NOT NULL DEFAULT 'skipped',
+    scan_signature  VARCHAR(255),
+    scanner_version VARCHAR(100) NOT NULL DEFAULT '',
+    scanned_at      TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at      TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_files_user_category ON files (user_id, category) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_files_deleted_at ON files (deleted_at);
+
+CREATE TABLE IF NOT EXISTS upload_policies (
+    category           VARCHAR(50) PRIMARY KEY,
+    max_file_size      BIGINT NOT NULL,
+    allowed_types      TEXT[] NOT NULL DEFAULT '{}',
+    allowed_extensions TEXT[] NOT NULL DEFAULT '{}',
+    max_files          INTEGER NOT NULL DEFAULT 0,
+    max_total_bytes    BIGINT NOT NULL DEFAULT 0,
+    updated_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS upload_sessions (
+    id            UUID PRIMARY KEY,
+    user_id       INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    uploaded_by   INTEGER NOT NULL,
+    category      VARCHAR(50) NOT NULL,
+    original_name VARCHAR(255) NOT NULL,
+    file_type     VARCHAR(100) NOT NULL DEFAULT '',
+    file_size     BIGINT NOT NULL,
+    upload_offset BIGINT NOT NULL DEFAULT 0,
+    temp_path     VARCHAR(500) NOT NULL,
+    expires_at    TIMESTAMP NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_expires_at ON upload_sessions (expires_at);
@@ -0,0 +1,8 @@
+-- File dapat dimiliki user atau alumni, sehingga user_id tidak lagi selalu
+-- mengacu ke tabel users
+ALTER TABLE files DROP CONSTRAINT IF EXISTS files_user_id_fkey;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS owner_type VARCHAR(20) NOT NULL DEFAULT 'user';
+ALTER TABLE files ADD COLUMN IF NOT EXISTS uploader_type VARCHAR(20) NOT NULL DEFAULT 'user';
+
+DROP INDEX IF EXISTS idx_files_user_category;
+CREATE INDEX IF NOT EXISTS idx_files_owner_category ON files (owner_type, user_id, category) WHERE deleted_at IS NULL;
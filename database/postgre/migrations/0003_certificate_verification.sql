@@ -0,0 +1,26 @@
+ALTER TABLE files ADD COLUMN IF NOT EXISTS verification_status VARCHAR(20);
+ALTER TABLE files ADD COLUMN IF NOT EXISTS rejection_reason TEXT;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS verified_by INTEGER;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP;
+
+-- Sertifikat yang sudah ada masuk antrian verifikasi
+UPDATE files SET verification_status = 'pending' WHERE category = 'certificate' AND verification_status IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_files_verification ON files (verification_status, uploaded_at)
+    WHERE category = 'certificate' AND deleted_at IS NULL;
+
+ALTER TABLE alumni ADD COLUMN IF NOT EXISTS is_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id         SERIAL PRIMARY KEY,
+    owner_type VARCHAR(20) NOT NULL,
+    owner_id   INTEGER NOT NULL,
+    type       VARCHAR(50) NOT NULL,
+    title      VARCHAR(255) NOT NULL,
+    message    TEXT NOT NULL,
+    file_id    INTEGER REFERENCES files(id) ON DELETE SET NULL,
+    read_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_owner ON notifications (owner_type, owner_id, created_at DESC);
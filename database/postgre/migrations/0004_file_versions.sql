@@ -0,0 +1,24 @@
+ALTER TABLE files ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS superseded_at TIMESTAMP;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS superseded_by INTEGER REFERENCES files(id) ON DELETE SET NULL;
+
+-- Beri nomor versi file yang sudah ada per pemilik dan kategori, urut waktu upload
+UPDATE files f SET version = v.version
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY owner_type, user_id, category ORDER BY uploaded_at, id) AS version
+    FROM files
+) v
+WHERE f.id = v.id;
+
+-- Hanya upload terbaru yang tidak dihapus dan tidak dikarantina tetap menjadi versi aktif
+UPDATE files f SET superseded_at = NOW(), superseded_by = c.id
+FROM (
+    SELECT DISTINCT ON (owner_type, user_id, category) id, owner_type, user_id, category
+    FROM files
+    WHERE deleted_at IS NULL AND scan_status <> 'quarantined'
+    ORDER BY owner_type, user_id, category, version DESC
+) c
+WHERE f.owner_type = c.owner_type AND f.user_id = c.user_id AND f.category = c.category
+    AND f.id <> c.id AND f.deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_files_versions ON files (owner_type, user_id, category, version DESC);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS file_export_jobs (
+    id            UUID PRIMARY KEY,
+    status        VARCHAR(20) NOT NULL,
+    requested_by  INTEGER NOT NULL,
+    filter        JSONB NOT NULL DEFAULT '{}',
+    file_count    INTEGER NOT NULL DEFAULT 0,
+    total_bytes   BIGINT NOT NULL DEFAULT 0,
+    artifact_path VARCHAR(500) NOT NULL DEFAULT '',
+    artifact_size BIGINT NOT NULL DEFAULT 0,
+    error         TEXT,
+    started_at    TIMESTAMP,
+    finished_at   TIMESTAMP,
+    expires_at    TIMESTAMP,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_file_export_jobs_expires_at ON file_export_jobs (expires_at);
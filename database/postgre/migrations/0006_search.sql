@@ -0,0 +1,27 @@
+-- Full-text search alumni dan pekerjaan. Konfigurasi "simple" dipakai karena
+-- tidak ada stemmer bahasa Indonesia; toleransi typo lewat pg_trgm.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE alumni ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', COALESCE(nama, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(nim, '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(jurusan, '')), 'C') ||
+        setweight(to_tsvector('simple', COALESCE(email, '')), 'D')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_alumni_search ON alumni USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_alumni_nama_trgm ON alumni USING GIN (nama gin_trgm_ops);
+
+ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', COALESCE(nama_perusahaan, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(posisi_jabatan, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(bidang_industri, '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(lokasi_kerja, '')), 'C') ||
+        setweight(to_tsvector('simple', COALESCE(deskripsi_pekerjaan, '')), 'D')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_search ON pekerjaan_alumni USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_perusahaan_trgm ON pekerjaan_alumni USING GIN (nama_perusahaan gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_posisi_trgm ON pekerjaan_alumni USING GIN (posisi_jabatan gin_trgm_ops);
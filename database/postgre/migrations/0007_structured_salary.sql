@@ -0,0 +1,16 @@
+-- Gaji terstruktur, gaji_range lama dikonversi saat aplikasi start
+ALTER TABLE pekerjaan_alumni
+    ADD COLUMN IF NOT EXISTS gaji_min      BIGINT,
+    ADD COLUMN IF NOT EXISTS gaji_max      BIGINT,
+    ADD COLUMN IF NOT EXISTS gaji_currency VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS gaji_period   VARCHAR(10);
+
+ALTER TABLE pekerjaan_alumni
+    ADD CONSTRAINT chk_pekerjaan_alumni_gaji CHECK (
+        (gaji_min IS NULL AND gaji_max IS NULL AND gaji_currency IS NULL AND gaji_period IS NULL)
+        OR (gaji_min >= 0 AND gaji_max >= gaji_min AND gaji_currency IS NOT NULL
+            AND gaji_period IN ('monthly', 'yearly'))
+    );
+
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_gaji_currency ON pekerjaan_alumni (gaji_currency)
+    WHERE gaji_currency IS NOT NULL AND deleted_at IS NULL;
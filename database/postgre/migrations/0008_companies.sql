@@ -0,0 +1,19 @@
+-- Direktori perusahaan, pekerjaan lama ditautkan saat aplikasi start
+CREATE TABLE IF NOT EXISTS companies (
+    id              SERIAL PRIMARY KEY,
+    nama            VARCHAR(200) NOT NULL,
+    aliases         TEXT[] NOT NULL DEFAULT '{}',
+    bidang_industri VARCHAR(100) NOT NULL DEFAULT '',
+    lokasi_kerja    VARCHAR(100) NOT NULL DEFAULT '',
+    website         VARCHAR(255),
+    keys            TEXT[] NOT NULL DEFAULT '{}', -- Nama dan alias yang sudah dinormalisasi
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_companies_keys ON companies USING GIN (keys);
+
+ALTER TABLE pekerjaan_alumni
+    ADD COLUMN IF NOT EXISTS company_id INTEGER REFERENCES companies(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_company_id ON pekerjaan_alumni (company_id);
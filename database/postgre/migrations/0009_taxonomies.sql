@@ -0,0 +1,14 @@
+-- Taksonomi jurusan, bidang industri dan status pekerjaan yang dikelola admin.
+-- Term bawaan dan nilai yang sudah tersimpan diisi saat aplikasi start
+CREATE TABLE IF NOT EXISTS taxonomy_terms (
+    id         SERIAL PRIMARY KEY,
+    kind       VARCHAR(30) NOT NULL,
+    code       VARCHAR(100) NOT NULL,
+    label      VARCHAR(255) NOT NULL,
+    aliases    TEXT[] NOT NULL DEFAULT '{}',
+    active     BOOLEAN NOT NULL DEFAULT TRUE,
+    source     VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, code)
+);
@@ -0,0 +1,3 @@
+-- Status sesi resumable upload; complete mengklaim sesi (uploading -> completing)
+-- agar dua request complete yang bersamaan tidak menyimpan file dua kali
+ALTER TABLE upload_sessions ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'uploading';
@@ -0,0 +1,4 @@
+-- Sesi upload resumable dapat dimiliki user atau alumni, sama seperti files
+ALTER TABLE upload_sessions DROP CONSTRAINT IF EXISTS upload_sessions_user_id_fkey;
+ALTER TABLE upload_sessions ADD COLUMN IF NOT EXISTS owner_type VARCHAR(20) NOT NULL DEFAULT 'user';
+ALTER TABLE upload_sessions ADD COLUMN IF NOT EXISTS uploader_type VARCHAR(20) NOT NULL DEFAULT 'user';
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	// Import library eksternal
 	"github.com/gofiber/fiber/v2"
@@ -23,6 +27,15 @@ import (
 	// Import Routes
 	mongoRoute "clean-arch/route/mongo"
 	postgreRoute "clean-arch/route/postgre"
+
+	// Import Services, Scanner, Mailer, Logger & Tracing
+	mongoService "clean-arch/app/service/mongo"
+	postgreService "clean-arch/app/service/postgre"
+	"clean-arch/utils/env"
+	"clean-arch/utils/logger"
+	"clean-arch/utils/mailer"
+	"clean-arch/utils/scanner"
+	"clean-arch/utils/tracing"
 )
 
 // @title Alumni Management API
@@ -41,6 +54,15 @@ func main() {
 		log.Println("⚠️ .env file not found, using system environment variables")
 	}
 
+	// Logger terstruktur, diatur lewat LOG_LEVEL dan LOG_FORMAT
+	logger.Init()
+
+	// Tracing OpenTelemetry, exporter dipilih lewat OTEL_TRACES_EXPORTER (otlp/stdout/none)
+	shutdownTracing, err := tracing.Init(context.Background())
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+
 	// Ambil konfigurasi port dan driver database
 	port := os.Getenv("APP_PORT")
 	if port == "" {
@@ -51,6 +73,8 @@ func main() {
 	dbDriver := os.Getenv("DB_DRIVER")
 	
 	var app *fiber.App
+	// Membuat /readyz gagal saat shutdown, diisi sesuai driver
+	var drainReadiness func()
 
 	// 2. Inisialisasi berdasarkan Driver Database
 	if dbDriver == "postgres" {
@@ -60,6 +84,11 @@ func main() {
 		db := postgreDB.ConnectDB()
 		// defer db.Close() // Opsional: tergantung lifecycle aplikasi
 
+		// Jalankan migrasi skema (tabel files, upload_policies, upload_sessions)
+		if err := postgreDB.Migrate(db); err != nil {
+			log.Fatal("Failed to run database migrations:", err)
+		}
+
 		// b. Setup App (Middleware, Static files, dll khusus Postgre config)
 		app = postgreConfig.NewApp(db)
 
@@ -69,6 +98,30 @@ func main() {
 		// d. Register Routes khusus PostgreSQL
 		postgreRoute.RegisterRoutes(app, db)
 
+		// e. Malware scanner untuk file upload (CLAMD_ADDRESS) dan mailer notifikasi (SMTP_HOST)
+		postgreService.SetFileScanner(scanner.NewFromEnv())
+		postgreService.SetMailer(mailer.NewFromEnv())
+		postgreService.StartFileRescanWorker(db)
+		postgreService.StartUploadSessionCleanup(db)
+		postgreService.StartFileGarbageCollector(db)
+		postgreService.StartFileExportCleanup(db)
+
+		// f. Konversi gaji_range lama ke gaji terstruktur
+		postgreService.MigrateGajiRange(db)
+
+		// g. Tautkan riwayat pekerjaan ke direktori perusahaan
+		postgreService.InitCompanyDirectory(db)
+
+		// h. Taksonomi jurusan, bidang industri dan status pekerjaan
+		postgreService.InitTaxonomies(db)
+
+		// i. Gauge jumlah alumni dan record di trash untuk /metrics
+		postgreService.RegisterRecordMetrics(db)
+
+		// j. Readiness: database, migrasi, storage upload dan mailer
+		postgreService.InitReadiness(db, postgreDB.PendingMigrations)
+		drainReadiness = postgreService.DrainReadiness
+
 	} else {
 		// Default: MongoDB
 		log.Println("🍃 Starting application with MongoDB...")
@@ -86,11 +139,60 @@ func main() {
 
 		// d. Register Routes khusus MongoDB
 		mongoRoute.RegisterRoutes(app, db)
+
+		// e. Malware scanner untuk file upload (CLAMD_ADDRESS) dan mailer notifikasi (SMTP_HOST)
+		mongoService.SetFileScanner(scanner.NewFromEnv())
+		mongoService.SetMailer(mailer.NewFromEnv())
+		mongoService.StartFileRescanWorker(db)
+		mongoService.StartUploadSessionCleanup(db)
+		mongoService.StartFileGarbageCollector(db)
+		mongoService.StartFileExportCleanup(db)
+
+		// f. Text index untuk pencarian full-text
+		mongoService.EnsureSearchIndexes(db)
+
+		// g. Konversi gaji_range lama ke gaji terstruktur
+		mongoService.MigrateGajiRange(db)
+
+		// h. Tautkan riwayat pekerjaan ke direktori perusahaan
+		mongoService.InitCompanyDirectory(db)
+
+		// i. Taksonomi jurusan, bidang industri dan status pekerjaan
+		mongoService.InitTaxonomies(db)
+
+		// j. Gauge jumlah alumni dan record di trash untuk /metrics
+		mongoService.RegisterRecordMetrics(db)
+
+		// k. Readiness: database, storage upload dan mailer
+		mongoService.InitReadiness(db)
+		drainReadiness = mongoService.DrainReadiness
 	}
 
-	// 3. Jalankan Server
+	// 3. Graceful shutdown: saat SIGINT/SIGTERM readiness gagal lebih dulu,
+	// tunggu SHUTDOWN_DRAIN_DELAY agar load balancer berhenti mengirim request,
+	// lalu selesaikan request yang sedang berjalan
+	shutdownCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-shutdownCtx.Done()
+
+		log.Println("🛑 Shutting down, draining readiness...")
+		drainReadiness()
+		time.Sleep(env.Duration("SHUTDOWN_DRAIN_DELAY", 5*time.Second))
+		if err := app.ShutdownWithTimeout(env.Duration("SHUTDOWN_TIMEOUT", 30*time.Second)); err != nil {
+			log.Println("Failed to shut down gracefully:", err)
+		}
+	}()
+
+	// 4. Jalankan Server
 	log.Printf("🚀 Server running on port %s using %s driver", port, dbDriver)
-	if err := app.Listen(":" + port); err != nil {
+	err = app.Listen(":" + port)
+
+	// Kirim span yang masih tertahan di batcher sebelum keluar
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		log.Println("Failed to flush traces:", shutdownErr)
+	}
+	if err != nil {
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
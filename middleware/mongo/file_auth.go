@@ -3,7 +3,10 @@ package middleware
 import (
 	"strings"
 
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
 	"clean-arch/utils/mongo"
+	"clean-arch/utils/tracing"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,32 +17,26 @@ func FileAuthRequired() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(401).JSON(fiber.Map{
-				"success": false,
-				"message": "Authorization token is required",
-			})
+			return apperror.Unauthorized("auth.token_required")
 		}
 
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return c.Status(401).JSON(fiber.Map{
-				"success": false,
-				"message": "Invalid token format",
-			})
+			return apperror.Unauthorized("auth.token_format")
 		}
 
 		// Try to validate as user token (works for both admin and regular users)
+		_, span := tracing.Start(c.UserContext(), "auth.ValidateToken")
 		userClaims, err := utils.ValidateToken(tokenParts[1])
+		tracing.End(span, err)
 		if err != nil {
-			return c.Status(401).JSON(fiber.Map{
-				"success": false,
-				"message": "Token is invalid or expired",
-			})
+			return apperror.Unauthorized("auth.token_invalid")
 		}
 
 		c.Locals("user_id", userClaims.UserID)
 		c.Locals("username", userClaims.Username)
 		c.Locals("role", userClaims.Role)
+		logger.With(c, "actor_id", userClaims.UserID, "role", userClaims.Role)
 
 		return c.Next()
 	}
@@ -1,12 +1,87 @@
 package middleware
 
 import (
-	"fmt"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"clean-arch/utils/logger"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func LoggerMiddleware(c *fiber.Ctx) error {
-	fmt.Println("Request:", c.Method(), c.Path())
+// HeaderRequestID carries the ID of a request in both directions
+const HeaderRequestID = "X-Request-ID"
+
+// validRequestID limits the IDs accepted from clients so they are safe to log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID takes X-Request-ID from the client or generates one, returns it
+// in the response and puts a logger carrying it in the request context
+func RequestID(c *fiber.Ctx) error {
+	id := c.Get(HeaderRequestID)
+	if !validRequestID.MatchString(id) {
+		id = newRequestID()
+	}
+
+	c.Locals("request_id", id)
+	c.Set(HeaderRequestID, id)
+	logger.With(c, "request_id", id)
 	return c.Next()
 }
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// LoggerMiddleware writes one access log line per request with status,
+// latency, ukuran response dan actor yang terautentikasi. Error dirender di
+// sini lewat ErrorHandler agar status yang dicatat sesuai response.
+func LoggerMiddleware(c *fiber.Ctx) error {
+	start := time.Now()
+	if err := c.Next(); err != nil {
+		if herr := c.App().ErrorHandler(c, err); herr != nil {
+			c.Status(fiber.StatusInternalServerError)
+		}
+	}
+
+	status := c.Response().StatusCode()
+	level := slog.LevelInfo
+	switch {
+	case status >= fiber.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= fiber.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", c.Method()),
+		slog.String("path", c.Path()),
+		slog.String("route", c.Route().Path),
+		slog.Int("status", status),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.Int("bytes", len(c.Response().Body())),
+		slog.String("ip", c.IP()),
+	}
+	if role, ok := c.Locals("role").(string); ok {
+		attrs = append(attrs, slog.String("role", role))
+	}
+	if actor := actorID(c); actor != nil {
+		attrs = append(attrs, slog.Any("actor_id", actor))
+	}
+
+	logger.From(c).LogAttrs(c.UserContext(), level, "request", attrs...)
+	return nil
+}
+
+// actorID returns the user or alumni ID set by the auth middleware
+func actorID(c *fiber.Ctx) interface{} {
+	if id := c.Locals("alumni_id"); id != nil {
+		return id
+	}
+	return c.Locals("user_id")
+}
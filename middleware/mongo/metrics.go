@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics records the latency of every request under its route template.
+// Dipasang setelah LoggerMiddleware sehingga menerima error asli dari handler.
+func Metrics(c *fiber.Ctx) error {
+	start := time.Now()
+	err := c.Next()
+
+	route, status := requestOutcome(c, err)
+	metrics.ObserveRequest(c.Method(), route, status, time.Since(start))
+	return err
+}
+
+// requestOutcome returns the matched route template and the final status of
+// a request whose handler returned err. Status dihitung dengan pemetaan yang
+// sama seperti ErrorHandler; 404/405 dari router dilabeli sebagai unmatched.
+func requestOutcome(c *fiber.Ctx, err error) (string, int) {
+	if err == nil {
+		return c.Route().Path, c.Response().StatusCode()
+	}
+
+	route := c.Route().Path
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && (fiberErr.Code == fiber.StatusNotFound || fiberErr.Code == fiber.StatusMethodNotAllowed) {
+		route = metrics.RouteUnmatched
+	}
+	return route, apperror.From(err).Status
+}
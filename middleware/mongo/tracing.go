@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"clean-arch/utils/logger"
+	"clean-arch/utils/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts the server span of a request, melanjutkan trace dari header
+// traceparent bila ada. Span diteruskan lewat UserContext sehingga query
+// repository menjadi child span, dan trace_id ikut tercatat di log request.
+func Tracing(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(tracing.Extract(c), c.Method(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(c.Method()),
+			semconv.URLPath(c.Path()),
+			semconv.ClientAddress(c.IP()),
+			semconv.UserAgentOriginal(c.Get(fiber.HeaderUserAgent)),
+		),
+	)
+	defer span.End()
+
+	c.SetUserContext(ctx)
+	if sc := span.SpanContext(); sc.IsValid() {
+		logger.With(c, "trace_id", sc.TraceID().String())
+	}
+
+	err := c.Next()
+
+	route, status := requestOutcome(c, err)
+	span.SetName(c.Method() + " " + route)
+	span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+	if status >= fiber.StatusInternalServerError {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.SetStatus(codes.Error, "")
+	}
+	return err
+}
@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/logger"
 	"clean-arch/utils/postgre"
+	"clean-arch/utils/tracing"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,31 +16,28 @@ func AuthRequired() fiber.Handler {
 		// Ambil token dari header Authorization
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Token akses diperlukan",
-			})
+			return apperror.Unauthorized("auth.token_required")
 		}
 
 		// Extract token dari "Bearer TOKEN"
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Format token tidak valid",
-			})
+			return apperror.Unauthorized("auth.token_format")
 		}
 
 		// Validasi token
+		_, span := tracing.Start(c.UserContext(), "auth.ValidateToken")
 		claims, err := utils.ValidateToken(tokenParts[1])
+		tracing.End(span, err)
 		if err != nil {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Token tidak valid atau expired",
-			})
+			return apperror.Unauthorized("auth.token_invalid")
 		}
 
 		// Simpan informasi user di context
 		c.Locals("user_id", claims.UserID)
 		c.Locals("username", claims.Username)
 		c.Locals("role", claims.Role)
+		logger.With(c, "actor_id", claims.UserID, "role", claims.Role)
 
 		return c.Next()
 	}
@@ -48,25 +48,21 @@ func AlumniAuthRequired() fiber.Handler {
 		// Ambil token dari header Authorization
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Token akses diperlukan",
-			})
+			return apperror.Unauthorized("auth.token_required")
 		}
 
 		// Extract token dari "Bearer TOKEN"
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Format token tidak valid",
-			})
+			return apperror.Unauthorized("auth.token_format")
 		}
 
 		// Validasi alumni token
+		_, span := tracing.Start(c.UserContext(), "auth.ValidateAlumniToken")
 		claims, err := utils.ValidateAlumniToken(tokenParts[1])
+		tracing.End(span, err)
 		if err != nil {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Token tidak valid atau expired",
-			})
+			return apperror.Unauthorized("auth.token_invalid")
 		}
 
 		// Simpan informasi alumni di context
@@ -75,6 +71,7 @@ func AlumniAuthRequired() fiber.Handler {
 		c.Locals("nama", claims.Nama)
 		c.Locals("email", claims.Email)
 		c.Locals("role", "alumni")
+		logger.With(c, "actor_id", claims.AlumniID, "role", "alumni")
 		c.Locals("is_alumni", true)
 
 		return c.Next()
@@ -86,15 +83,22 @@ func AdminOnly() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role := c.Locals("role")
 		if role == nil {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Akses ditolak. Hanya admin yang diizinkan",
-			})
+			return apperror.Forbidden("auth.admin_only")
 		}
 		roleStr := role.(string)
 		if roleStr != "admin" {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Akses ditolak. Hanya admin yang diizinkan",
-			})
+			return apperror.Forbidden("auth.admin_only")
+		}
+		return c.Next()
+	}
+}
+
+// Middleware untuk verifier sertifikat (role verifier atau admin)
+func VerifierOnly() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		if role != "verifier" && role != "admin" {
+			return apperror.Forbidden("auth.verifier_only")
 		}
 		return c.Next()
 	}
@@ -104,15 +108,11 @@ func AlumniOrAdminOnly() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role := c.Locals("role")
 		if role == nil {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Akses ditolak. Hanya alumni atau admin yang diizinkan",
-			})
+			return apperror.Forbidden("auth.alumni_only")
 		}
 		roleStr := role.(string)
 		if roleStr != "alumni" && roleStr != "admin" {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Akses ditolak. Hanya alumni atau admin yang diizinkan",
-			})
+			return apperror.Forbidden("auth.alumni_only")
 		}
 		return c.Next()
 	}
@@ -122,15 +122,11 @@ func UserOrAdminOnly() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role := c.Locals("role")
 		if role == nil {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Akses ditolak. Hanya user atau admin yang diizinkan",
-			})
+			return apperror.Forbidden("auth.user_only")
 		}
 		roleStr := role.(string)
 		if roleStr != "user" && roleStr != "admin" {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Akses ditolak. Hanya user atau admin yang diizinkan",
-			})
+			return apperror.Forbidden("auth.user_only")
 		}
 		return c.Next()
 	}
@@ -142,31 +138,28 @@ func UserAuthRequired() fiber.Handler {
 		// Ambil token dari header Authorization
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Token akses diperlukan",
-			})
+			return apperror.Unauthorized("auth.token_required")
 		}
 
 		// Extract token dari "Bearer TOKEN"
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Format token tidak valid",
-			})
+			return apperror.Unauthorized("auth.token_format")
 		}
 
 		// Validasi token
+		_, span := tracing.Start(c.UserContext(), "auth.ValidateToken")
 		claims, err := utils.ValidateToken(tokenParts[1])
+		tracing.End(span, err)
 		if err != nil {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Token tidak valid atau expired",
-			})
+			return apperror.Unauthorized("auth.token_invalid")
 		}
 
 		// Simpan informasi user di context
 		c.Locals("user_id", claims.UserID)
 		c.Locals("username", claims.Username)
 		c.Locals("role", claims.Role)
+		logger.With(c, "actor_id", claims.UserID, "role", claims.Role)
 		c.Locals("is_user", true)
 
 		return c.Next()
@@ -0,0 +1,53 @@
+package route
+
+import (
+	"clean-arch/app/service/mongo"
+	"clean-arch/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RegisterCompanyRoutes registers the company directory routes
+func RegisterCompanyRoutes(app *fiber.App, db *mongo.Database) {
+	companies := app.Group("/companies")
+
+	// GET /companies?search=telkom
+	companies.Get("/", func(c *fiber.Ctx) error {
+		return service.GetCompaniesService(c, db)
+	})
+
+	// GET /companies/suggest?q=telkom%20indo
+	// Saran saat mengisi riwayat pekerjaan
+	companies.Get("/suggest", func(c *fiber.Ctx) error {
+		return service.SuggestCompaniesService(c, db)
+	})
+
+	companies.Get("/:id", func(c *fiber.Ctx) error {
+		return service.GetCompanyByIDService(c, db)
+	})
+
+	// GET /companies/:id/alumni?current=true
+	companies.Get("/:id/alumni", func(c *fiber.Ctx) error {
+		return service.GetCompanyAlumniService(c, db)
+	})
+
+	// Requires: admin token
+	companies.Post("/", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.CreateCompanyService(c, db)
+	})
+
+	companies.Put("/:id", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.UpdateCompanyService(c, db)
+	})
+
+	companies.Delete("/:id", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.DeleteCompanyService(c, db)
+	})
+
+	// POST /companies/:id/merge {"source_ids": [...]}
+	// Pekerjaan perusahaan sumber dipindahkan ke :id, perusahaan sumber dihapus
+	companies.Post("/:id/merge", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.MergeCompaniesService(c, db)
+	})
+}
@@ -14,26 +14,233 @@ func RegisterFileRoutes(app *fiber.App, db *mongo.Database) {
 
 	// POST /api/files/upload-photo
 	// Requires: user token (admin or regular user)
-	// Body: form-data with file (max 1MB) and user_id
+	// Body: form-data with file and user_id or alumni_id (admin only), limits from the photo upload policy
 	files.Post("/upload-photo", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
 		return service.UploadPhotoService(c, db)
 	})
 
 	// POST /api/files/upload-certificate
 	// Requires: user token (admin or regular user)
-	// Body: form-data with file (max 2MB PDF) and user_id
+	// Body: form-data with file and user_id or alumni_id (admin only), limits from the certificate upload policy
 	files.Post("/upload-certificate", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
 		return service.UploadCertificateService(c, db)
 	})
 
-	// GET /api/files?user_id=xxx&category=photo|certificate
+	// Alumni file routes, authenticated with the alumni token. Files are owned
+	// by the logged in alumni and shown on GET /alumni/profile and /alumni/:id.
+	// POST /api/files/alumni/upload-photo
+	// Body: form-data with file
+	files.Post("/alumni/upload-photo", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.UploadPhotoService(c, db)
+	})
+
+	// POST /api/files/alumni/upload-certificate
+	// Body: form-data with file
+	files.Post("/alumni/upload-certificate", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.UploadCertificateService(c, db)
+	})
+
+	// GET /api/files/alumni?category=photo|certificate&all_versions=true
+	// Only current versions unless all_versions=true
+	files.Get("/alumni", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetOwnFilesService(c, db)
+	})
+
+	// GET /api/files/alumni/usage
+	files.Get("/alumni/usage", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetFileUsageService(c, db)
+	})
+
+	// GET /api/files/alumni/:id/download
+	files.Get("/alumni/:id/download", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.DownloadFileService(c, db)
+	})
+
+	// GET /api/files/alumni/export?category=certificate
+	// ZIP of the logged in alumni's current documents with manifest.csv
+	files.Get("/alumni/export", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.ExportOwnFilesService(c, db)
+	})
+
+	// Resumable uploads of the logged in alumni, same protocol as /api/files/uploads
+	// POST /api/files/alumni/uploads
+	// Body: {"category", "file_name", "file_type", "file_size"}
+	files.Post("/alumni/uploads", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.CreateUploadSessionService(c, db)
+	})
+
+	// PATCH /api/files/alumni/uploads/:id
+	files.Patch("/alumni/uploads/:id", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.PatchUploadSessionService(c, db)
+	})
+
+	// HEAD /api/files/alumni/uploads/:id
+	files.Head("/alumni/uploads/:id", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.HeadUploadSessionService(c, db)
+	})
+
+	// POST /api/files/alumni/uploads/:id/complete
+	files.Post("/alumni/uploads/:id/complete", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.CompleteUploadSessionService(c, db)
+	})
+
+	// DELETE /api/files/alumni/uploads/:id
+	files.Delete("/alumni/uploads/:id", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.AbortUploadSessionService(c, db)
+	})
+
+	// GET /api/files/alumni/:id/versions
+	files.Get("/alumni/:id/versions", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetFileVersionsService(c, db)
+	})
+
+	// POST /api/files/alumni/:id/restore
+	files.Post("/alumni/:id/restore", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.RestoreFileVersionService(c, db)
+	})
+
+	// DELETE /api/files/alumni/:id
+	files.Delete("/alumni/:id", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.DeleteFileService(c, db)
+	})
+
+	// GET /api/files?user_id=xxx|alumni_id=xxx&category=photo|certificate&all_versions=true
 	// Requires: user token (admin or regular user)
+	// Only current versions unless all_versions=true
 	files.Get("/", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
 		return service.GetFilesService(c, db)
 	})
 
+	// GET /api/files/policies
+	// Requires: user token; returns effective upload policy per category
+	files.Get("/policies", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetUploadPoliciesService(c, db)
+	})
+
+	// PUT /api/files/policies/:category
+	// Requires: admin token
+	// Body: max_file_size, allowed_types, allowed_extensions, max_files, max_total_bytes
+	files.Put("/policies/:category", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.UpdateUploadPolicyService(c, db)
+	})
+
+	// GET /api/files/usage?user_id=xxx|alumni_id=xxx
+	// Requires: user token; user_id and alumni_id only for admin
+	files.Get("/usage", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetFileUsageService(c, db)
+	})
+
+	// GET /api/files/usage/users
+	// Requires: admin token; usage against quota for every user
+	files.Get("/usage/users", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.GetAllUsersFileUsageService(c, db)
+	})
+
+	// GET /api/files/admin?category=&owner_type=&owner_id=&uploader_type=&uploaded_by=&file_type=image/*
+	//     &min_size=&max_size=&uploaded_from=2024-01-01&uploaded_to=2024-12-31&deleted=exclude|include|only
+	//     &current_only=true&search=&page=1&limit=10&sortBy=uploaded_at&order=desc
+	// Requires: admin token; paginated file browser with totals per category
+	files.Get("/admin", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.GetAdminFilesService(c, db)
+	})
+
+	// POST /api/files/export
+	// Requires: admin token
+	// Body: {"alumni_ids": [], "angkatan": [2019], "jurusan": ["Informatika"], "category": "certificate", "async": false}
+	// Streams a ZIP with manifest.csv; large exports return 202 with a background job
+	files.Post("/export", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ExportFilesService(c, db)
+	})
+
+	// GET /api/files/export/jobs/:id
+	// Requires: admin token; status of a background export
+	files.Get("/export/jobs/:id", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.GetExportJobService(c, db)
+	})
+
+	// GET /api/files/export/jobs/:id/download
+	// Requires: admin token; ZIP artifact of a completed export, kept for EXPORT_ARTIFACT_TTL
+	files.Get("/export/jobs/:id/download", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.DownloadExportJobService(c, db)
+	})
+
+	// Resumable uploads for large documents (transcript, portfolio, ...)
+	// POST /api/files/uploads
+	// Body: {"category", "file_name", "file_type", "file_size", "user_id" or "alumni_id" (admin only)}
+	files.Post("/uploads", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.CreateUploadSessionService(c, db)
+	})
+
+	// PATCH /api/files/uploads/:id
+	// Headers: Upload-Offset, optional Upload-Checksum "sha256 <base64>"
+	// Body: raw chunk bytes (keep chunks under the server body limit)
+	files.Patch("/uploads/:id", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.PatchUploadSessionService(c, db)
+	})
+
+	// HEAD /api/files/uploads/:id
+	// Returns Upload-Offset, Upload-Length and Upload-Expires headers
+	files.Head("/uploads/:id", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.HeadUploadSessionService(c, db)
+	})
+
+	// POST /api/files/uploads/:id/complete
+	// Body: optional {"checksum": "sha256 <base64>"} for the whole file
+	files.Post("/uploads/:id/complete", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.CompleteUploadSessionService(c, db)
+	})
+
+	// DELETE /api/files/uploads/:id
+	files.Delete("/uploads/:id", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.AbortUploadSessionService(c, db)
+	})
+
+	// POST /api/files/gc?dry_run=true&retention=720h
+	// Requires: admin token; purges expired soft-deleted files and reports or
+	// repairs orphaned records/objects. Dry run unless dry_run=false.
+	files.Post("/gc", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.RunFileGCService(c, db)
+	})
+
+	// Certificate verification, requires verifier or admin token
+	// GET /api/files/certificates/reviews?status=pending|approved|rejected|all&owner_type=alumni
+	//     &uploaded_from=2024-01-01&uploaded_to=2024-12-31&search=&page=1&limit=10
+	files.Get("/certificates/reviews", middleware.FileAuthRequired(), middleware.VerifierOnly(), func(c *fiber.Ctx) error {
+		return service.GetCertificateReviewQueueService(c, db)
+	})
+
+	// POST /api/files/certificates/:id/approve
+	files.Post("/certificates/:id/approve", middleware.FileAuthRequired(), middleware.VerifierOnly(), func(c *fiber.Ctx) error {
+		return service.ApproveCertificateService(c, db)
+	})
+
+	// POST /api/files/certificates/:id/reject
+	// Body: {"reason": "..."}
+	files.Post("/certificates/:id/reject", middleware.FileAuthRequired(), middleware.VerifierOnly(), func(c *fiber.Ctx) error {
+		return service.RejectCertificateService(c, db)
+	})
+
+	// GET /api/files/:id/download
+	// Requires: user token (owner or admin); quarantined files are refused
+	files.Get("/:id/download", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.DownloadFileService(c, db)
+	})
+
+	// GET /api/files/:id/versions
+	// Requires: user token (owner or admin); every version of the file's owner and category
+	files.Get("/:id/versions", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetFileVersionsService(c, db)
+	})
+
+	// POST /api/files/:id/restore
+	// Requires: user token (owner or admin); makes an earlier version current again
+	files.Post("/:id/restore", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.RestoreFileVersionService(c, db)
+	})
+
 	// DELETE /api/files/:id
 	// Requires: user token (admin or regular user)
+	// Deleting the current version makes the previous version current
 	files.Delete("/:id", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
 		return service.DeleteFileService(c, db)
 	})
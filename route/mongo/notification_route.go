@@ -0,0 +1,36 @@
+package route
+
+import (
+	"clean-arch/app/service/mongo"
+	"clean-arch/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RegisterNotificationRoutes registers in-app notification routes
+func RegisterNotificationRoutes(app *fiber.App, db *mongo.Database) {
+	notifications := app.Group("/api/notifications")
+
+	// GET /api/notifications/alumni?unread=true
+	// Requires: alumni token
+	notifications.Get("/alumni", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetNotificationsService(c, db)
+	})
+
+	// PATCH /api/notifications/alumni/:id/read
+	notifications.Patch("/alumni/:id/read", middleware.AlumniAuthRequired(), func(c *fiber.Ctx) error {
+		return service.MarkNotificationReadService(c, db)
+	})
+
+	// GET /api/notifications?unread=true
+	// Requires: user token (admin or regular user)
+	notifications.Get("/", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetNotificationsService(c, db)
+	})
+
+	// PATCH /api/notifications/:id/read
+	notifications.Patch("/:id/read", middleware.FileAuthRequired(), func(c *fiber.Ctx) error {
+		return service.MarkNotificationReadService(c, db)
+	})
+}
@@ -0,0 +1,38 @@
+package route
+
+import (
+	"clean-arch/app/service/mongo"
+	"clean-arch/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RegisterTaxonomyRoutes registers the jurusan, bidang industri and status
+// pekerjaan reference data routes
+func RegisterTaxonomyRoutes(app *fiber.App, db *mongo.Database) {
+	taxonomies := app.Group("/taxonomies")
+
+	// GET /taxonomies?include_inactive=true
+	taxonomies.Get("/", func(c *fiber.Ctx) error {
+		return service.GetTaxonomiesService(c, db)
+	})
+
+	// GET /taxonomies/jurusan
+	taxonomies.Get("/:kind", func(c *fiber.Ctx) error {
+		return service.GetTaxonomyTermsService(c, db)
+	})
+
+	// Requires: admin token
+	taxonomies.Post("/:kind", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.CreateTaxonomyTermService(c, db)
+	})
+
+	taxonomies.Put("/:kind/:id", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.UpdateTaxonomyTermService(c, db)
+	})
+
+	taxonomies.Delete("/:kind/:id", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.DeleteTaxonomyTermService(c, db)
+	})
+}
@@ -0,0 +1,54 @@
+package route
+
+import (
+	"database/sql"
+
+	"clean-arch/app/service/postgre"
+	"clean-arch/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterCompanyRoutes registers the company directory routes
+func RegisterCompanyRoutes(app *fiber.App, db *sql.DB) {
+	companies := app.Group("/companies")
+
+	// GET /companies?search=telkom
+	companies.Get("/", func(c *fiber.Ctx) error {
+		return service.GetCompaniesService(c, db)
+	})
+
+	// GET /companies/suggest?q=telkom%20indo
+	// Saran saat mengisi riwayat pekerjaan
+	companies.Get("/suggest", func(c *fiber.Ctx) error {
+		return service.SuggestCompaniesService(c, db)
+	})
+
+	companies.Get("/:id", func(c *fiber.Ctx) error {
+		return service.GetCompanyByIDService(c, db)
+	})
+
+	// GET /companies/:id/alumni?current=true
+	companies.Get("/:id/alumni", func(c *fiber.Ctx) error {
+		return service.GetCompanyAlumniService(c, db)
+	})
+
+	// Requires: admin token
+	companies.Post("/", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.CreateCompanyService(c, db)
+	})
+
+	companies.Put("/:id", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.UpdateCompanyService(c, db)
+	})
+
+	companies.Delete("/:id", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.DeleteCompanyService(c, db)
+	})
+
+	// POST /companies/:id/merge {"source_ids": [...]}
+	// Pekerjaan perusahaan sumber dipindahkan ke :id, perusahaan sumber dihapus
+	companies.Post("/:id/merge", middleware.FileAuthRequired(), middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.MergeCompaniesService(c, db)
+	})
+}
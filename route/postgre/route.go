@@ -11,6 +11,11 @@ import (
 
 func RegisterRoutes(app *fiber.App, db *sql.DB) {
 
+	RegisterFileRoutes(app, db)
+	RegisterNotificationRoutes(app, db)
+	RegisterCompanyRoutes(app, db)
+	RegisterTaxonomyRoutes(app, db)
+
 	// Alumni Auth routes
 	app.Post("/alumni/register", func(c *fiber.Ctx) error {
 		return service.RegisterAlumniService(c, db)
@@ -37,10 +42,26 @@ func RegisterRoutes(app *fiber.App, db *sql.DB) {
 		return service.GetAlumniStatisticsService(c, db)
 	})
 
+	app.Get("/alumni/statistics/tracer", func(c *fiber.Ctx) error {
+		return service.GetTracerStatisticsService(c, db)
+	})
+
+	app.Get("/alumni/statistics/salary", func(c *fiber.Ctx) error {
+		return service.GetSalaryStatisticsService(c, db)
+	})
+
+	app.Get("/alumni/statistics/taxonomy", func(c *fiber.Ctx) error {
+		return service.GetTaxonomyStatisticsService(c, db)
+	})
+
 	app.Get("/alumni/:id", func(c *fiber.Ctx) error {
 		return service.GetAlumniByIDService(c, db)
 	})
 
+	app.Get("/alumni/:id/timeline", middleware.UserAuthRequired(), func(c *fiber.Ctx) error {
+		return service.GetCareerTimelineService(c, db)
+	})
+
 	app.Post("/alumni", func(c *fiber.Ctx) error {
 		return service.CreateAlumniService(c, db)
 	})
@@ -0,0 +1,84 @@
+package apperror
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CodeInternal is the code of every 5xx response. Penyebab aslinya hanya
+// dicatat ke log, tidak pernah dikirim ke klien.
+const CodeInternal = "INTERNAL_ERROR"
+
+// Error is an API error. Handler merender Status, Code, pesan dari katalog
+// (Key dan Args) sesuai Accept-Language, serta Details bila ada.
+type Error struct {
+	Status  int
+	Code    string
+	Key     string
+	Args    []interface{}
+	Details interface{}
+	Err     error
+}
+
+func (e *Error) Error() string {
+	msg := Message(DefaultLanguage(), e.Key, e.Args...)
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithDetails returns a copy of e carrying structured details for the client
+func (e *Error) WithDetails(details interface{}) *Error {
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
+// Wrap returns a copy of e whose cause is err
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+// New builds an error whose code is derived from the message key, misalnya
+// "alumni.not_found" menjadi ALUMNI_NOT_FOUND
+func New(status int, key string, args ...interface{}) *Error {
+	return &Error{Status: status, Code: codeFromKey(key), Key: key, Args: args}
+}
+
+func BadRequest(key string, args ...interface{}) *Error {
+	return New(fiber.StatusBadRequest, key, args...)
+}
+
+func Unauthorized(key string, args ...interface{}) *Error {
+	return New(fiber.StatusUnauthorized, key, args...)
+}
+
+func Forbidden(key string, args ...interface{}) *Error {
+	return New(fiber.StatusForbidden, key, args...)
+}
+
+func NotFound(key string, args ...interface{}) *Error {
+	return New(fiber.StatusNotFound, key, args...)
+}
+
+func Conflict(key string, args ...interface{}) *Error {
+	return New(fiber.StatusConflict, key, args...)
+}
+
+// Internal reports a server-side failure. err dicatat oleh Handler, klien
+// hanya menerima pesan dari key.
+func Internal(err error, key string, args ...interface{}) *Error {
+	return &Error{Status: fiber.StatusInternalServerError, Code: CodeInternal, Key: key, Args: args, Err: err}
+}
+
+func codeFromKey(key string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
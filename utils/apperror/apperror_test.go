@@ -0,0 +1,135 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	verbs := regexp.MustCompile(`%(\[\d\])?[a-z]`)
+	for key, id := range messagesID {
+		en, ok := messagesEN[key]
+		if !ok {
+			t.Errorf("%s missing from English catalog", key)
+			continue
+		}
+		if got, want := len(verbs.FindAllString(en, -1)), len(verbs.FindAllString(id, -1)); got != want {
+			t.Errorf("%s: English has %d verbs, Indonesian %d", key, got, want)
+		}
+	}
+	for key := range messagesEN {
+		if _, ok := messagesID[key]; !ok {
+			t.Errorf("%s missing from Indonesian catalog", key)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                            "",
+		"en":                          "en",
+		"en-US,en;q=0.9,id;q=0.8":     "en",
+		"id-ID;q=0.5,en;q=0.9":        "en",
+		"fr,id;q=0.3":                 "id",
+		"de,fr":                       "",
+		"en;q=0,id;q=0.1":             "id",
+		"  ID , en ; q=0.2 ":          "id",
+		"en;q=abc,id;q=0.5":           "en",
+		"ja-JP,en-GB;q=0.7,id;q=0.71": "id",
+	}
+	for header, want := range tests {
+		if got := parseAcceptLanguage(header); got != want {
+			t.Errorf("parseAcceptLanguage(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	if got := Message("en", "company.name_taken", "Acme"); got != `Name or alias "Acme" is already used by another company, use merge instead` {
+		t.Errorf("en message = %q", got)
+	}
+	if got := Message("fr", "alumni.not_found"); got != messagesID["alumni.not_found"] {
+		t.Errorf("unknown language should fall back to default, got %q", got)
+	}
+	if got := Message("id", "no.such_key"); got != "no.such_key" {
+		t.Errorf("unknown key = %q", got)
+	}
+}
+
+type envelope struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Error   struct {
+		Code    string                 `json:"code"`
+		Details map[string]interface{} `json:"details"`
+	} `json:"error"`
+}
+
+func request(t *testing.T, app *fiber.App, path, lang string) (int, envelope) {
+	t.Helper()
+	req := httptest.NewRequest("GET", path, nil)
+	if lang != "" {
+		req.Header.Set("Accept-Language", lang)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body envelope
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: Handler})
+	app.Get("/not-found", func(c *fiber.Ctx) error {
+		return NotFound("alumni.not_found")
+	})
+	app.Get("/conflict", func(c *fiber.Ctx) error {
+		return Conflict("upload.incomplete").WithDetails(fiber.Map{"offset": 10})
+	})
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return Internal(errors.New("connection refused by 10.0.0.5"), "alumni.get")
+	})
+	app.Get("/plain", func(c *fiber.Ctx) error {
+		return errors.New("driver: bad connection")
+	})
+
+	status, body := request(t, app, "/not-found", "en-US,en;q=0.9")
+	if status != fiber.StatusNotFound || body.Success || body.Error.Code != "ALUMNI_NOT_FOUND" || body.Message != "Alumni not found" {
+		t.Errorf("not found: %d %+v", status, body)
+	}
+
+	status, body = request(t, app, "/not-found", "")
+	if status != fiber.StatusNotFound || body.Message != "Alumni tidak ditemukan" {
+		t.Errorf("default language: %d %+v", status, body)
+	}
+
+	status, body = request(t, app, "/conflict", "id")
+	if status != fiber.StatusConflict || body.Error.Code != "UPLOAD_INCOMPLETE" || body.Error.Details["offset"] != float64(10) {
+		t.Errorf("conflict: %d %+v", status, body)
+	}
+
+	for _, path := range []string{"/internal", "/plain"} {
+		status, body = request(t, app, path, "en")
+		if status != fiber.StatusInternalServerError || body.Error.Code != CodeInternal {
+			t.Errorf("%s: %d %+v", path, status, body)
+		}
+		if strings.Contains(body.Message, "connection") {
+			t.Errorf("%s leaked the cause: %q", path, body.Message)
+		}
+	}
+
+	status, body = request(t, app, "/missing", "en")
+	if status != fiber.StatusNotFound || body.Error.Code != "HTTP_NOT_FOUND" {
+		t.Errorf("unknown route: %d %+v", status, body)
+	}
+}
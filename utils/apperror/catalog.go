@@ -0,0 +1,86 @@
+package apperror
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var catalogs = map[string]map[string]string{
+	"id": messagesID,
+	"en": messagesEN,
+}
+
+// DefaultLanguage is used when Accept-Language names no supported language.
+// Dapat diubah dengan DEFAULT_LANGUAGE (id atau en).
+func DefaultLanguage() string {
+	if lang := strings.ToLower(os.Getenv("DEFAULT_LANGUAGE")); catalogs[lang] != nil {
+		return lang
+	}
+	return "id"
+}
+
+// Language picks the supported language with the highest q-value in the
+// Accept-Language header, misalnya "en-US,en;q=0.9,id;q=0.8" menjadi en
+func Language(c *fiber.Ctx) string {
+	if lang := parseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage)); lang != "" {
+		return lang
+	}
+	return DefaultLanguage()
+}
+
+func parseAcceptLanguage(header string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if i := strings.IndexByte(tag, '-'); i >= 0 {
+			tag = tag[:i]
+		}
+		if catalogs[tag] == nil {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{lang: tag, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+// Message formats the message of key in lang. Key yang tidak ada di katalog
+// bahasa tersebut memakai bahasa default, lalu key itu sendiri.
+func Message(lang, key string, args ...interface{}) string {
+	format, ok := catalogs[lang][key]
+	if !ok {
+		if format, ok = catalogs[DefaultLanguage()][key]; !ok {
+			format = key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
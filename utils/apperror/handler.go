@@ -0,0 +1,85 @@
+package apperror
+
+import (
+	"errors"
+
+	"clean-arch/utils/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Localizer is implemented by details that carry their own message keys,
+// seperti daftar field dari validasi request
+type Localizer interface {
+	Localize(lang string) interface{}
+}
+
+// Handler is the Fiber ErrorHandler. Semua error yang dikembalikan handler
+// dan middleware dirender dengan bentuk yang sama:
+//
+//	{"success": false, "message": "...", "error": {"code": "...", "details": ...}}
+func Handler(c *fiber.Ctx, err error) error {
+	appErr := From(err)
+	// Penyebab error internal hanya dicatat di log; 503 tanpa penyebab
+	// (misalnya readiness) bukan kegagalan yang perlu dicatat
+	if appErr.Status >= fiber.StatusInternalServerError && appErr.Err != nil {
+		logger.From(c).Error("internal error", "code", appErr.Code, "key", appErr.Key, "error", appErr.Err)
+	}
+	return c.Status(appErr.Status).JSON(Body(c, appErr))
+}
+
+// From converts any error to *Error. Error dari Fiber (route tidak ada, body
+// terlalu besar, dll.) dipetakan ke katalog, selain itu dianggap internal.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		switch fiberErr.Code {
+		case fiber.StatusNotFound:
+			return NotFound("http.not_found")
+		case fiber.StatusMethodNotAllowed:
+			return New(fiber.StatusMethodNotAllowed, "http.method_not_allowed")
+		case fiber.StatusRequestEntityTooLarge:
+			return New(fiber.StatusRequestEntityTooLarge, "http.body_too_large")
+		}
+		if fiberErr.Code < fiber.StatusInternalServerError {
+			return New(fiberErr.Code, "http.bad_request")
+		}
+	}
+	return Internal(err, "internal")
+}
+
+// Body builds the response envelope of e in the language of the request
+func Body(c *fiber.Ctx, e *Error) fiber.Map {
+	lang := Language(c)
+	body := fiber.Map{"code": e.Code}
+	if e.Details != nil {
+		body["details"] = localizeDetails(e.Details, lang)
+	}
+	return fiber.Map{
+		"success": false,
+		"message": Message(lang, e.Key, e.Args...),
+		"error":   body,
+	}
+}
+
+func localizeDetails(details interface{}, lang string) interface{} {
+	switch d := details.(type) {
+	case Localizer:
+		return d.Localize(lang)
+	case fiber.Map:
+		localized := make(fiber.Map, len(d))
+		for k, v := range d {
+			if l, ok := v.(Localizer); ok {
+				v = l.Localize(lang)
+			}
+			localized[k] = v
+		}
+		return localized
+	}
+	return details
+}
@@ -0,0 +1,227 @@
+package apperror
+
+// messagesEN is the English catalog
+var messagesEN = map[string]string{
+	"alumni.check":                   "Failed to check alumni",
+	"alumni.create":                  "Failed to create alumni",
+	"alumni.delete":                  "Failed to delete alumni",
+	"alumni.get":                     "Failed to retrieve alumni data",
+	"alumni.get_relations":           "Failed to retrieve alumni relations",
+	"alumni.get_trash":               "Failed to retrieve trash",
+	"alumni.hard_delete":             "Failed to permanently delete alumni",
+	"alumni.hard_delete_not_trashed": "Only trashed alumni can be permanently deleted",
+	"alumni.invalid_id":              "Invalid alumni ID",
+	"alumni.invalid_key":             "Invalid key",
+	"alumni.nim_required":            "NIM is required",
+	"alumni.not_found":               "Alumni not found",
+	"alumni.not_found_or_deleted":    "Alumni not found or already deleted",
+	"alumni.not_in_trash":            "Alumni not found or not in trash",
+	"alumni.restore":                 "Failed to restore alumni",
+	"alumni.soft_delete":             "Failed to soft delete alumni",
+	"alumni.statistics":              "Failed to retrieve alumni statistics",
+	"alumni.update":                  "Failed to update alumni",
+
+	"auth.admin_only":                 "Access denied. Admin only",
+	"auth.alumni_id_missing":          "Alumni ID not found in token",
+	"auth.alumni_only":                "Access denied. Alumni or admin only",
+	"auth.create_alumni_account":      "Failed to create alumni account",
+	"auth.database":                   "Database error",
+	"auth.generate_token":             "Failed to generate token",
+	"auth.get_profile":                "Failed to retrieve profile",
+	"auth.hash_password":              "Failed to hash password",
+	"auth.invalid_alumni_credentials": "Invalid NIM or password",
+	"auth.invalid_credentials":        "Invalid username or password",
+	"auth.token_format":               "Invalid token format",
+	"auth.token_invalid":              "Token is invalid or expired",
+	"auth.token_required":             "Access token is required",
+	"auth.user_id_missing":            "User ID not found in token",
+	"auth.user_only":                  "Access denied. User or admin only",
+	"auth.verifier_only":              "Access denied. Verifier or admin only",
+
+	"certificate.already_reviewed": "Certificate has already been reviewed",
+	"certificate.get":              "Failed to retrieve certificates",
+	"certificate.not_found":        "Certificate not found",
+	"certificate.not_scanned":      "Certificate must pass the malware scan before it can be reviewed",
+	"certificate.reason_required":  "reason is required",
+	"certificate.update":           "Failed to update certificate",
+
+	"company.create":              "Failed to create company",
+	"company.delete":              "Failed to delete company",
+	"company.get":                 "Failed to retrieve company data",
+	"company.get_alumni":          "Failed to retrieve company alumni",
+	"company.in_use":              "Company is still linked to %d jobs, merge it into another company instead of deleting it",
+	"company.merge":               "Failed to merge companies",
+	"company.merge_self":          "A company cannot be merged into itself",
+	"company.name_taken":          "Name or alias \"%s\" is already used by another company, use merge instead",
+	"company.not_found":           "Company not found",
+	"company.query_required":      "Parameter q is required",
+	"company.source_ids_required": "source_ids is required",
+	"company.source_not_found":    "Source company %v not found",
+	"company.update":              "Failed to update company",
+
+	"export.collect":   "Failed to collect files for export",
+	"export.create":    "Failed to create export job",
+	"export.empty":     "No files to export",
+	"export.expired":   "Export artifact has expired",
+	"export.failed":    "Export failed, please try again or contact the administrator",
+	"export.no_match":  "No files match the export filter",
+	"export.not_found": "Export job not found",
+	"export.not_ready": "Export job is %s",
+
+	"file.already_current":         "File is already the current version",
+	"file.category_required":       "category is required",
+	"file.delete":                  "Failed to delete file",
+	"file.delete_own_only":         "You can only delete your own files",
+	"file.download_own_only":       "You can only download your own files",
+	"file.gc_running":              "File garbage collection is already running",
+	"file.get":                     "Failed to retrieve files",
+	"file.get_versions":            "Failed to retrieve file versions",
+	"file.invalid_id":              "Invalid file ID",
+	"file.invalid_owner_id":        "Invalid user_id or alumni_id",
+	"file.invalid_retention":       "retention must be a duration such as 720h",
+	"file.malware":                 "File rejected: malware detected",
+	"file.not_found":               "File not found",
+	"file.owner_category_required": "user_id or alumni_id and category are required",
+	"file.quarantined":             "File is quarantined because malware was detected",
+	"file.reconcile":               "Failed to reconcile files",
+	"file.required":                "File is required",
+	"file.restore_own_only":        "You can only restore your own files",
+	"file.restore_quarantined":     "Quarantined files cannot be restored",
+	"file.restore_version":         "Failed to restore file version",
+	"file.scan_pending":            "File is waiting for malware scan, please try again later",
+	"file.totals":                  "Failed to calculate file totals",
+	"file.unknown_category":        "Unknown file category",
+	"file.usage":                   "Failed to retrieve file usage",
+	"file.usage_admin_only":        "Only admin can view usage of other users",
+	"file.versions_own_only":       "You can only view versions of your own files",
+
+	"health.draining":  "Application is shutting down",
+	"health.not_ready": "Application is not ready to serve requests",
+	"health.starting":  "Application is still starting",
+
+	"http.bad_request":        "Bad request",
+	"http.body_too_large":     "Request body is too large",
+	"http.method_not_allowed": "Method not allowed",
+	"http.not_found":          "Endpoint not found",
+
+	"internal": "Internal server error",
+
+	"notification.certificate_approved_body":  "Certificate %s has been verified and approved.",
+	"notification.certificate_approved_title": "Certificate approved",
+	"notification.certificate_rejected_body":  "Certificate %s was rejected for the following reason: %s. Please upload the correct certificate.",
+	"notification.certificate_rejected_title": "Certificate rejected",
+	"notification.get":                        "Failed to retrieve notifications",
+	"notification.invalid_id":                 "Invalid notification ID",
+	"notification.not_found":                  "Notification not found",
+	"notification.update":                     "Failed to mark notification as read",
+
+	"pekerjaan.check_history":           "Failed to check job history",
+	"pekerjaan.company_not_found":       "No company matches the given company_id",
+	"pekerjaan.company_unconfirmed":     "Company \"%s\" is not in the directory yet and resembles other companies, pick a company_id from the suggestions or send new_company=true",
+	"pekerjaan.create":                  "Failed to create job",
+	"pekerjaan.delete":                  "Failed to delete job",
+	"pekerjaan.delete_forbidden":        "You are not allowed to delete this job",
+	"pekerjaan.get":                     "Failed to retrieve job data",
+	"pekerjaan.hard_delete_not_trashed": "Only trashed jobs can be permanently deleted",
+	"pekerjaan.inconsistent":            "Inconsistent job data",
+	"pekerjaan.invalid_gaji":            "Invalid salary data",
+	"pekerjaan.match_company":           "Failed to match company",
+	"pekerjaan.not_found":               "Job not found",
+	"pekerjaan.not_found_or_deleted":    "Job not found or already deleted",
+	"pekerjaan.not_in_trash":            "Job not found or not deleted",
+	"pekerjaan.own_data_only":           "You can only change your own data",
+	"pekerjaan.restore":                 "Failed to restore job",
+	"pekerjaan.restore_forbidden":       "You are not allowed to restore this job",
+	"pekerjaan.update":                  "Failed to update job",
+	"pekerjaan.update_own_only":         "You can only update your own job history",
+
+	"pekerjaan_rule.active_with_end_date":      "An active job must not have an end date in the past",
+	"pekerjaan_rule.end_before_start":          "The end date must not be before the start date",
+	"pekerjaan_rule.finished_without_end_date": "A finished or resigned job must have an end date",
+	"pekerjaan_rule.future_start":              "The start date must not be in the future",
+	"pekerjaan_rule.overlap":                   "The period overlaps the job at %s (%s to %s)",
+
+	"request.cursor_fulltext":   "Pagination cursor is not supported with search_mode=fulltext",
+	"request.invalid_cursor":    "Cursor is invalid or does not match sortBy/order",
+	"request.invalid_data":      "Invalid data",
+	"request.invalid_filter":    "Invalid filter",
+	"request.invalid_id":        "Invalid ID",
+	"request.invalid_parameter": "Invalid parameter",
+
+	"salary.invalid_currency": "Invalid filter: currency must be an ISO 4217 currency code, e.g. IDR",
+	"salary.statistics":       "Failed to retrieve salary statistics",
+
+	"taxonomy.check":             "Failed to check taxonomy",
+	"taxonomy.create":            "Failed to create taxonomy term",
+	"taxonomy.delete":            "Failed to delete taxonomy term",
+	"taxonomy.get":               "Failed to retrieve taxonomy data",
+	"taxonomy.get_term":          "Failed to retrieve taxonomy term",
+	"taxonomy.invalid_term":      "Label is required and code may only contain letters, digits, dots, underscores or hyphens",
+	"taxonomy.key_taken":         "%q is already used by another term",
+	"taxonomy.label_required":    "Label is required",
+	"taxonomy.statistics":        "Failed to calculate taxonomy statistics",
+	"taxonomy.status_readonly":   "Job statuses drive application logic, only labels and aliases can be changed",
+	"taxonomy.system_deactivate": "System terms cannot be deactivated",
+	"taxonomy.system_delete":     "System terms cannot be deleted",
+	"taxonomy.term_not_found":    "Taxonomy term not found",
+	"taxonomy.unknown_kind":      "Unknown taxonomy kind",
+	"taxonomy.unknown_value":     "Value %[2]q is not registered in the %[1]s taxonomy",
+	"taxonomy.update":            "Failed to update taxonomy term",
+
+	"timeline.active_with_end_date":      "The job at %s is active but already ended on %s",
+	"timeline.end_before_start":          "The end date of the job at %s is before its start date",
+	"timeline.finished_without_end_date": "The job at %s has status %s but no end date",
+	"timeline.gap":                       "%d days without a job between %s and %s",
+	"timeline.overlap":                   "The jobs at %s and %s overlap",
+
+	"tracer.statistics": "Failed to retrieve tracer study statistics",
+
+	"upload.admin_only":        "Only admin can upload for other users",
+	"upload.check_quota":       "Failed to check upload quota",
+	"upload.chunk_checksum":    "Chunk checksum verification failed",
+	"upload.chunk_too_large":   "Chunk exceeds declared file size",
+	"upload.create_session":    "Failed to create upload session",
+	"upload.empty_chunk":       "Chunk body is empty",
+	"upload.failed":            "Failed to upload file",
+	"upload.file_checksum":     "File checksum verification failed",
+	"upload.file_too_large":    "%s size must not exceed %s",
+	"upload.completing":        "Upload is already being completed by another request",
+	"upload.incomplete":        "Upload is not complete",
+	"upload.max_files":         "Maximum number of %s files (%d) reached",
+	"upload.offset_changed":    "Upload offset changed by another request",
+	"upload.offset_mismatch":   "Upload-Offset does not match current offset",
+	"upload.offset_required":   "Valid Upload-Offset header is required",
+	"upload.own_only":          "You can only access your own uploads",
+	"upload.prepare":           "Failed to prepare upload",
+	"upload.quota_exceeded":    "Storage quota for %s exceeded (%s of %s used)",
+	"upload.read":              "Failed to read uploaded data",
+	"upload.session_expired":   "Upload session has expired",
+	"upload.session_not_found": "Upload session not found",
+	"upload.type_not_allowed":  "File type is not allowed for %s, allowed: %s",
+	"upload.update_policy":     "Failed to update upload policy",
+	"upload.update_session":    "Failed to update upload session",
+	"upload.write_chunk":       "Failed to write chunk",
+
+	"user.not_found": "User not found",
+
+	"validation.email":            "must be a valid email address",
+	"validation.failed":           "Invalid request data",
+	"validation.gtefield":         "must not be less than %s",
+	"validation.invalid":          "is invalid",
+	"validation.malformed":        "Malformed request body",
+	"validation.max":              "must be at most %s",
+	"validation.max_items":        "must have at most %s items",
+	"validation.max_string":       "must be at most %s characters",
+	"validation.min":              "must be at least %s",
+	"validation.min_items":        "must have at least %s items",
+	"validation.min_string":       "must be at least %s characters",
+	"validation.nim":              "is not a valid NIM",
+	"validation.oneof":            "must be one of: %s",
+	"validation.required":         "is required",
+	"validation.required_without": "is required when %s is empty",
+	"validation.tahun":            "must be a year between %d and %d",
+	"validation.tanggal":          "must be a date between %s and %s",
+	"validation.taxonomy":         "is not registered in the %s taxonomy",
+	"validation.type":             "has the wrong type",
+	"validation.url":              "must be an http or https URL",
+}
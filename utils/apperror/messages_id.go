@@ -0,0 +1,227 @@
+package apperror
+
+// messagesID is the Indonesian catalog, bahasa default aplikasi
+var messagesID = map[string]string{
+	"alumni.check":                   "Gagal cek alumni karena",
+	"alumni.create":                  "Gagal menambah alumni",
+	"alumni.delete":                  "Gagal menghapus alumni",
+	"alumni.get":                     "Gagal mengambil data alumni",
+	"alumni.get_relations":           "Gagal mengambil relasi alumni",
+	"alumni.get_trash":               "Gagal mengambil trash",
+	"alumni.hard_delete":             "Gagal hapus permanen",
+	"alumni.hard_delete_not_trashed": "Hapus permanen hanya untuk data alumni yang ada di trash",
+	"alumni.invalid_id":              "Alumni ID tidak valid",
+	"alumni.invalid_key":             "Key tidak valid",
+	"alumni.nim_required":            "NIM wajib diisi",
+	"alumni.not_found":               "Alumni tidak ditemukan",
+	"alumni.not_found_or_deleted":    "Data alumni tidak ditemukan atau sudah dihapus",
+	"alumni.not_in_trash":            "Data tidak ditemukan atau belum di-trash",
+	"alumni.restore":                 "Gagal restore",
+	"alumni.soft_delete":             "Gagal soft delete",
+	"alumni.statistics":              "Gagal mengambil statistik alumni",
+	"alumni.update":                  "Gagal mengupdate alumni",
+
+	"auth.admin_only":                 "Akses ditolak. Hanya admin yang diizinkan",
+	"auth.alumni_id_missing":          "Alumni ID tidak ditemukan di token",
+	"auth.alumni_only":                "Akses ditolak. Hanya alumni atau admin yang diizinkan",
+	"auth.create_alumni_account":      "Gagal membuat akun alumni",
+	"auth.database":                   "Error database",
+	"auth.generate_token":             "Gagal generate token",
+	"auth.get_profile":                "Gagal mengambil data profile",
+	"auth.hash_password":              "Gagal hash password",
+	"auth.invalid_alumni_credentials": "NIM atau password salah",
+	"auth.invalid_credentials":        "Username atau password salah",
+	"auth.token_format":               "Format token tidak valid",
+	"auth.token_invalid":              "Token tidak valid atau expired",
+	"auth.token_required":             "Token akses diperlukan",
+	"auth.user_id_missing":            "User ID tidak ditemukan di token",
+	"auth.user_only":                  "Akses ditolak. Hanya user atau admin yang diizinkan",
+	"auth.verifier_only":              "Akses ditolak. Hanya verifier atau admin yang diizinkan",
+
+	"certificate.already_reviewed": "Sertifikat sudah direview",
+	"certificate.get":              "Gagal mengambil data sertifikat",
+	"certificate.not_found":        "Sertifikat tidak ditemukan",
+	"certificate.not_scanned":      "Sertifikat harus lolos pemindaian malware sebelum bisa direview",
+	"certificate.reason_required":  "reason wajib diisi",
+	"certificate.update":           "Gagal mengupdate sertifikat",
+
+	"company.create":              "Gagal menambah perusahaan",
+	"company.delete":              "Gagal menghapus perusahaan",
+	"company.get":                 "Gagal mengambil data perusahaan",
+	"company.get_alumni":          "Gagal mengambil data alumni perusahaan",
+	"company.in_use":              "Perusahaan masih tertaut ke %d riwayat pekerjaan, gabungkan (merge) ke perusahaan lain alih-alih menghapus",
+	"company.merge":               "Gagal menggabungkan perusahaan",
+	"company.merge_self":          "Perusahaan tidak dapat digabungkan dengan dirinya sendiri",
+	"company.name_taken":          "Nama atau alias \"%s\" sudah dipakai perusahaan lain, gunakan merge",
+	"company.not_found":           "Perusahaan tidak ditemukan",
+	"company.query_required":      "Parameter q wajib diisi",
+	"company.source_ids_required": "source_ids wajib diisi",
+	"company.source_not_found":    "Perusahaan sumber %v tidak ditemukan",
+	"company.update":              "Gagal mengupdate perusahaan",
+
+	"export.collect":   "Gagal mengumpulkan file untuk ekspor",
+	"export.create":    "Gagal membuat job ekspor",
+	"export.empty":     "Tidak ada file untuk diekspor",
+	"export.expired":   "Hasil ekspor sudah kedaluwarsa",
+	"export.failed":    "Ekspor gagal, silakan coba lagi atau hubungi administrator",
+	"export.no_match":  "Tidak ada file yang cocok dengan filter ekspor",
+	"export.not_found": "Job ekspor tidak ditemukan",
+	"export.not_ready": "Job ekspor berstatus %s",
+
+	"file.already_current":         "File sudah merupakan versi terkini",
+	"file.category_required":       "category wajib diisi",
+	"file.delete":                  "Gagal menghapus file",
+	"file.delete_own_only":         "Anda hanya bisa menghapus file milik sendiri",
+	"file.download_own_only":       "Anda hanya bisa mengunduh file milik sendiri",
+	"file.gc_running":              "Garbage collection file sedang berjalan",
+	"file.get":                     "Gagal mengambil data file",
+	"file.get_versions":            "Gagal mengambil versi file",
+	"file.invalid_id":              "ID file tidak valid",
+	"file.invalid_owner_id":        "user_id atau alumni_id tidak valid",
+	"file.invalid_retention":       "retention harus berupa durasi, contoh 720h",
+	"file.malware":                 "File ditolak: terdeteksi malware",
+	"file.not_found":               "File tidak ditemukan",
+	"file.owner_category_required": "user_id atau alumni_id dan category wajib diisi",
+	"file.quarantined":             "File dikarantina karena terdeteksi malware",
+	"file.reconcile":               "Gagal merekonsiliasi file",
+	"file.required":                "File wajib diisi",
+	"file.restore_own_only":        "Anda hanya bisa memulihkan file milik sendiri",
+	"file.restore_quarantined":     "File yang dikarantina tidak bisa dipulihkan",
+	"file.restore_version":         "Gagal memulihkan versi file",
+	"file.scan_pending":            "File menunggu pemindaian malware, coba lagi nanti",
+	"file.totals":                  "Gagal menghitung total file",
+	"file.unknown_category":        "Kategori file tidak dikenal",
+	"file.usage":                   "Gagal mengambil pemakaian file",
+	"file.usage_admin_only":        "Hanya admin yang bisa melihat pemakaian user lain",
+	"file.versions_own_only":       "Anda hanya bisa melihat versi file milik sendiri",
+
+	"health.draining":  "Aplikasi sedang dimatikan",
+	"health.not_ready": "Aplikasi belum siap menerima request",
+	"health.starting":  "Aplikasi masih dalam proses startup",
+
+	"http.bad_request":        "Request tidak valid",
+	"http.body_too_large":     "Ukuran request terlalu besar",
+	"http.method_not_allowed": "Metode tidak diizinkan",
+	"http.not_found":          "Endpoint tidak ditemukan",
+
+	"internal": "Terjadi kesalahan pada server",
+
+	"notification.certificate_approved_body":  "Sertifikat %s telah diverifikasi dan disetujui.",
+	"notification.certificate_approved_title": "Sertifikat disetujui",
+	"notification.certificate_rejected_body":  "Sertifikat %s ditolak dengan alasan: %s. Silakan unggah ulang sertifikat yang benar.",
+	"notification.certificate_rejected_title": "Sertifikat ditolak",
+	"notification.get":                        "Gagal mengambil notifikasi",
+	"notification.invalid_id":                 "ID notifikasi tidak valid",
+	"notification.not_found":                  "Notifikasi tidak ditemukan",
+	"notification.update":                     "Gagal menandai notifikasi sebagai dibaca",
+
+	"pekerjaan.check_history":           "Gagal memeriksa riwayat pekerjaan",
+	"pekerjaan.company_not_found":       "Perusahaan dengan company_id tersebut tidak ditemukan",
+	"pekerjaan.company_unconfirmed":     "Perusahaan \"%s\" belum ada di direktori dan mirip perusahaan lain, pilih company_id dari saran atau kirim new_company=true",
+	"pekerjaan.create":                  "Gagal menambah pekerjaan",
+	"pekerjaan.delete":                  "Gagal menghapus pekerjaan",
+	"pekerjaan.delete_forbidden":        "Anda tidak memiliki akses untuk menghapus pekerjaan",
+	"pekerjaan.get":                     "Gagal mengambil data pekerjaan",
+	"pekerjaan.hard_delete_not_trashed": "Hapus permanen hanya untuk data pekerjaan yang ada di trash",
+	"pekerjaan.inconsistent":            "Data pekerjaan tidak konsisten",
+	"pekerjaan.invalid_gaji":            "Data gaji tidak valid",
+	"pekerjaan.match_company":           "Gagal mencocokkan perusahaan",
+	"pekerjaan.not_found":               "Pekerjaan tidak ditemukan",
+	"pekerjaan.not_found_or_deleted":    "Pekerjaan tidak ditemukan atau sudah dihapus",
+	"pekerjaan.not_in_trash":            "Pekerjaan tidak ditemukan atau tidak terhapus",
+	"pekerjaan.own_data_only":           "Maaf tidak bisa mengubah atau mengedit data selain diri sendiri",
+	"pekerjaan.restore":                 "Gagal merestorasi pekerjaan",
+	"pekerjaan.restore_forbidden":       "Anda tidak memiliki akses untuk merestorasi pekerjaan",
+	"pekerjaan.update":                  "Gagal mengupdate pekerjaan",
+	"pekerjaan.update_own_only":         "Anda hanya dapat mengupdate riwayat pekerjaan milik Anda sendiri",
+
+	"pekerjaan_rule.active_with_end_date":      "Pekerjaan berstatus aktif tidak boleh memiliki tanggal selesai yang sudah lewat",
+	"pekerjaan_rule.end_before_start":          "Tanggal selesai kerja tidak boleh sebelum tanggal mulai kerja",
+	"pekerjaan_rule.finished_without_end_date": "Pekerjaan berstatus selesai atau resigned wajib memiliki tanggal selesai kerja",
+	"pekerjaan_rule.future_start":              "Tanggal mulai kerja tidak boleh di masa depan",
+	"pekerjaan_rule.overlap":                   "Periode kerja beririsan dengan pekerjaan di %s (%s s/d %s)",
+
+	"request.cursor_fulltext":   "Pagination cursor tidak didukung untuk search_mode=fulltext",
+	"request.invalid_cursor":    "Cursor tidak valid atau tidak sesuai dengan sortBy/order",
+	"request.invalid_data":      "Data tidak valid",
+	"request.invalid_filter":    "Filter tidak valid",
+	"request.invalid_id":        "ID tidak valid",
+	"request.invalid_parameter": "Parameter tidak valid",
+
+	"salary.invalid_currency": "Filter tidak valid: currency harus berupa kode mata uang ISO 4217, contoh IDR",
+	"salary.statistics":       "Gagal mengambil statistik gaji",
+
+	"taxonomy.check":             "Gagal memeriksa taksonomi",
+	"taxonomy.create":            "Gagal menambah term taksonomi",
+	"taxonomy.delete":            "Gagal menghapus term taksonomi",
+	"taxonomy.get":               "Gagal mengambil data taksonomi",
+	"taxonomy.get_term":          "Gagal mengambil term taksonomi",
+	"taxonomy.invalid_term":      "Label wajib diisi dan kode hanya boleh berisi huruf, angka, titik, garis bawah atau tanda hubung",
+	"taxonomy.key_taken":         "%q sudah dipakai term lain",
+	"taxonomy.label_required":    "Label wajib diisi",
+	"taxonomy.statistics":        "Gagal menghitung statistik taksonomi",
+	"taxonomy.status_readonly":   "Status pekerjaan dipakai logika aplikasi, hanya label dan alias yang bisa diubah",
+	"taxonomy.system_deactivate": "Term bawaan sistem tidak bisa dinonaktifkan",
+	"taxonomy.system_delete":     "Term bawaan sistem tidak bisa dihapus",
+	"taxonomy.term_not_found":    "Term taksonomi tidak ditemukan",
+	"taxonomy.unknown_kind":      "Jenis taksonomi tidak dikenal",
+	"taxonomy.unknown_value":     "Nilai %[2]q tidak terdaftar di taksonomi %[1]s",
+	"taxonomy.update":            "Gagal mengupdate term taksonomi",
+
+	"timeline.active_with_end_date":      "Pekerjaan di %s berstatus aktif tetapi sudah berakhir pada %s",
+	"timeline.end_before_start":          "Tanggal selesai pekerjaan di %s sebelum tanggal mulai",
+	"timeline.finished_without_end_date": "Pekerjaan di %s berstatus %s tetapi tidak memiliki tanggal selesai",
+	"timeline.gap":                       "Jeda %d hari tanpa pekerjaan antara %s dan %s",
+	"timeline.overlap":                   "Pekerjaan di %s dan %s beririsan",
+
+	"tracer.statistics": "Gagal mengambil statistik tracer study",
+
+	"upload.admin_only":        "Hanya admin yang bisa mengupload untuk user lain",
+	"upload.check_quota":       "Gagal memeriksa kuota upload",
+	"upload.chunk_checksum":    "Verifikasi checksum chunk gagal",
+	"upload.chunk_too_large":   "Chunk melebihi ukuran file yang dideklarasikan",
+	"upload.create_session":    "Gagal membuat sesi upload",
+	"upload.empty_chunk":       "Body chunk kosong",
+	"upload.failed":            "Gagal mengupload file",
+	"upload.file_checksum":     "Verifikasi checksum file gagal",
+	"upload.file_too_large":    "Ukuran file %s tidak boleh melebihi %s",
+	"upload.completing":        "Upload sedang diselesaikan oleh request lain",
+	"upload.incomplete":        "Upload belum selesai",
+	"upload.max_files":         "Jumlah maksimum file %s (%d) sudah tercapai",
+	"upload.offset_changed":    "Offset upload diubah oleh request lain",
+	"upload.offset_mismatch":   "Upload-Offset tidak sesuai dengan offset saat ini",
+	"upload.offset_required":   "Header Upload-Offset yang valid wajib diisi",
+	"upload.own_only":          "Anda hanya bisa mengakses upload milik sendiri",
+	"upload.prepare":           "Gagal menyiapkan upload",
+	"upload.quota_exceeded":    "Kuota penyimpanan %s terlampaui (%s dari %s terpakai)",
+	"upload.read":              "Gagal membaca data upload",
+	"upload.session_expired":   "Sesi upload sudah kedaluwarsa",
+	"upload.session_not_found": "Sesi upload tidak ditemukan",
+	"upload.type_not_allowed":  "Tipe file tidak diizinkan untuk %s, yang diizinkan: %s",
+	"upload.update_policy":     "Gagal mengupdate kebijakan upload",
+	"upload.update_session":    "Gagal mengupdate sesi upload",
+	"upload.write_chunk":       "Gagal menulis chunk",
+
+	"user.not_found": "User tidak ditemukan",
+
+	"validation.email":            "harus berupa alamat email yang valid",
+	"validation.failed":           "Data tidak valid",
+	"validation.gtefield":         "tidak boleh lebih kecil dari %s",
+	"validation.invalid":          "tidak valid",
+	"validation.malformed":        "Format data tidak valid",
+	"validation.max":              "maksimal %s",
+	"validation.max_items":        "maksimal %s item",
+	"validation.max_string":       "maksimal %s karakter",
+	"validation.min":              "minimal %s",
+	"validation.min_items":        "minimal %s item",
+	"validation.min_string":       "minimal %s karakter",
+	"validation.nim":              "format NIM tidak valid",
+	"validation.oneof":            "harus salah satu dari: %s",
+	"validation.required":         "wajib diisi",
+	"validation.required_without": "wajib diisi jika %s kosong",
+	"validation.tahun":            "harus tahun antara %d dan %d",
+	"validation.tanggal":          "harus tanggal antara %s dan %s",
+	"validation.taxonomy":         "tidak terdaftar di taksonomi %s",
+	"validation.type":             "tipe data tidak sesuai",
+	"validation.url":              "harus berupa URL http atau https",
+}
@@ -0,0 +1,38 @@
+// Package env reads typed configuration from environment variables. Nilai
+// yang kosong atau tidak valid selalu diganti dengan default, sehingga salah
+// ketik di .env tidak menghentikan aplikasi.
+package env
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Duration parses a duration such as "5s" or "24h" from key. Durasi negatif
+// dianggap tidak valid.
+func Duration(key string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d < 0 {
+		return def
+	}
+	return d
+}
+
+// Int64 parses a positive integer from key
+func Int64(key string, def int64) int64 {
+	v, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
+
+// Bool parses a boolean such as "true" or "0" from key
+func Bool(key string, def bool) bool {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
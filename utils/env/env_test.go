@@ -0,0 +1,45 @@
+package env
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	cases := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", time.Minute},
+		{"5s", 5 * time.Second},
+		{"0s", 0},
+		{"-1s", time.Minute},
+		{"lima detik", time.Minute},
+	}
+	for _, tc := range cases {
+		t.Setenv("TEST_DURATION", tc.value)
+		if got := Duration("TEST_DURATION", time.Minute); got != tc.want {
+			t.Errorf("Duration(%q) = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestInt64AndBool(t *testing.T) {
+	t.Setenv("TEST_INT", "0")
+	if got := Int64("TEST_INT", 90); got != 90 {
+		t.Errorf("Int64(0) = %d, want default", got)
+	}
+	t.Setenv("TEST_INT", "30")
+	if got := Int64("TEST_INT", 90); got != 30 {
+		t.Errorf("Int64(30) = %d", got)
+	}
+
+	t.Setenv("TEST_BOOL", "ya")
+	if got := Bool("TEST_BOOL", true); !got {
+		t.Errorf("Bool(ya) = false, want default")
+	}
+	t.Setenv("TEST_BOOL", "false")
+	if got := Bool("TEST_BOOL", true); got {
+		t.Errorf("Bool(false) = true")
+	}
+}
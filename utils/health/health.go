@@ -0,0 +1,139 @@
+// Package health runs the readiness checks of the application. Hasil
+// disimpan selama TTL agar probe yang sering tidak membebani database, dan
+// readiness langsung gagal begitu aplikasi mulai drain saat shutdown.
+package health
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status of a check or of the whole report
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusDisabled = "disabled"
+	StatusDraining = "draining"
+)
+
+// ErrDisabled is returned by checks of optional dependencies that are not
+// configured, misalnya mailer tanpa SMTP_HOST. Tidak membuat readiness gagal.
+var ErrDisabled = errors.New("dependency not configured")
+
+// Check is one named dependency check
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is the outcome of one check. Pesan error hanya dicatat di log agar
+// alamat dan detail infrastruktur tidak terlihat dari endpoint publik.
+type Result struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+// Report is the outcome of every check
+type Report struct {
+	Status    string            `json:"status"`
+	Checks    map[string]Result `json:"checks,omitempty"`
+	CheckedAt time.Time         `json:"checked_at"`
+}
+
+// Ready reports whether every check passed
+func (r Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+// Checker runs checks and caches their report
+type Checker struct {
+	checks   []Check
+	ttl      time.Duration
+	timeout  time.Duration
+	draining atomic.Bool
+
+	mu   sync.Mutex
+	last *Report
+}
+
+// NewChecker returns a checker that reuses a report for ttl and gives each
+// check at most timeout
+func NewChecker(ttl, timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, ttl: ttl, timeout: timeout}
+}
+
+// Drain marks the application as shutting down; semua laporan berikutnya
+// berstatus draining
+func (h *Checker) Drain() {
+	h.draining.Store(true)
+}
+
+// Check returns the cached report or runs every check concurrently. Probe
+// yang datang bersamaan menunggu satu putaran yang sama.
+func (h *Checker) Check(ctx context.Context) Report {
+	if h.draining.Load() {
+		return Report{Status: StatusDraining, CheckedAt: time.Now()}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.last != nil && time.Since(h.last.CheckedAt) < h.ttl {
+		return *h.last
+	}
+
+	// Probe yang terputus tidak boleh membatalkan putaran yang akan di-cache
+	ctx = context.WithoutCancel(ctx)
+
+	results := make([]Result, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = h.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(h.checks)), CheckedAt: time.Now()}
+	for i, check := range h.checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status == StatusFail {
+			report.Status = StatusFail
+		}
+	}
+	h.last = &report
+	return report
+}
+
+// run executes one check within the timeout. Check yang mengabaikan ctx
+// tetap dianggap gagal setelah timeout.
+func (h *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check.Run(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{Status: StatusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+	switch {
+	case errors.Is(err, ErrDisabled):
+		result.Status = StatusDisabled
+	case err != nil:
+		result.Status = StatusFail
+		slog.Warn("Readiness check failed", "check", check.Name, "error", err)
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckerReport(t *testing.T) {
+	var dbCalls atomic.Int32
+	h := NewChecker(time.Minute, 50*time.Millisecond,
+		Check{Name: "database", Run: func(ctx context.Context) error {
+			dbCalls.Add(1)
+			return nil
+		}},
+		Check{Name: "mailer", Run: func(ctx context.Context) error { return ErrDisabled }},
+	)
+
+	report := h.Check(context.Background())
+	if !report.Ready() {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.Checks["database"].Status != StatusOK || report.Checks["mailer"].Status != StatusDisabled {
+		t.Errorf("checks = %+v", report.Checks)
+	}
+
+	// Hasil di-cache selama TTL
+	h.Check(context.Background())
+	if n := dbCalls.Load(); n != 1 {
+		t.Errorf("database checked %d times, want 1", n)
+	}
+
+	h.Drain()
+	if report := h.Check(context.Background()); report.Ready() || report.Status != StatusDraining {
+		t.Errorf("draining report = %+v", report)
+	}
+}
+
+func TestCheckerFailures(t *testing.T) {
+	h := NewChecker(0, 20*time.Millisecond,
+		Check{Name: "storage", Run: func(ctx context.Context) error { return errors.New("read-only file system") }},
+		// Check yang mengabaikan ctx tetap dihentikan oleh timeout
+		Check{Name: "migrations", Run: func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		}},
+	)
+
+	start := time.Now()
+	report := h.Check(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("check took %v, timeout was not enforced", elapsed)
+	}
+	if report.Ready() || report.Status != StatusFail {
+		t.Fatalf("report = %+v", report)
+	}
+	for _, name := range []string{"storage", "migrations"} {
+		if report.Checks[name].Status != StatusFail {
+			t.Errorf("%s = %+v", name, report.Checks[name])
+		}
+	}
+}
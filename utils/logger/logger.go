@@ -0,0 +1,82 @@
+// Package logger configures the structured logger of the application and
+// carries the request-scoped logger (dengan request_id dan actor) through
+// context.Context ke service dan repository.
+package logger
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type contextKey struct{}
+
+// New builds a logger writing to w. level adalah debug, info, warn atau
+// error (default info); format adalah json (default) atau text.
+func New(w io.Writer, level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: ParseLevel(level)}
+	if strings.EqualFold(format, "text") {
+		return slog.New(slog.NewTextHandler(w, opts))
+	}
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+// Init installs the logger configured by LOG_LEVEL and LOG_FORMAT as the
+// default. Pemanggilan log.Printf yang tersisa ikut ditulis lewat logger ini.
+func Init() *slog.Logger {
+	l := New(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+	slog.SetDefault(l)
+	log.SetFlags(0)
+	return l
+}
+
+// ParseLevel maps a LOG_LEVEL value to a slog level
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+// WithContext returns a copy of ctx carrying l
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, atau logger default bila
+// ctx berasal dari luar request (worker, startup)
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+	return slog.Default()
+}
+
+// Background returns a context for work outside a request. Logger default
+// diberi atribut worker agar log worker mudah difilter.
+func Background(worker string) context.Context {
+	return WithContext(context.Background(), slog.Default().With("worker", worker))
+}
+
+// From returns the logger of the request
+func From(c *fiber.Ctx) *slog.Logger {
+	return FromContext(c.UserContext())
+}
+
+// With adds attributes to the logger of the request, misalnya actor setelah
+// token divalidasi
+func With(c *fiber.Ctx, args ...interface{}) {
+	c.SetUserContext(WithContext(c.UserContext(), From(c).With(args...)))
+}
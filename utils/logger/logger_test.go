@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"":        slog.LevelInfo,
+		"debug":   slog.LevelDebug,
+		" WARN ":  slog.LevelWarn,
+		"warning": slog.LevelWarn,
+		"error":   slog.LevelError,
+		"verbose": slog.LevelInfo,
+	}
+	for in, want := range tests {
+		if got := ParseLevel(in); got != want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestNewFormatAndLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "warn", "")
+	l.Info("hidden")
+	l.Warn("shown", "key", "value")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a single JSON line, got %q", buf.String())
+	}
+	if entry["msg"] != "shown" || entry["key"] != "value" {
+		t.Errorf("entry = %v", entry)
+	}
+
+	buf.Reset()
+	New(&buf, "", "text").Info("plain")
+	if !bytes.HasPrefix(buf.Bytes(), []byte("time=")) {
+		t.Errorf("text format = %q", buf.String())
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("context without logger should use the default logger")
+	}
+
+	var buf bytes.Buffer
+	base := New(&buf, "info", "json")
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.SetUserContext(WithContext(c.UserContext(), base))
+		With(c, "request_id", "abc")
+		From(c).Info("handled")
+		return nil
+	})
+	if _, err := app.Test(httptest.NewRequest("GET", "/", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["request_id"] != "abc" {
+		t.Errorf("request logger lost its attributes: %v", entry)
+	}
+}
+
+func TestBackground(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(New(&buf, "info", "json"))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	FromContext(Background("file_gc")).Info("finished")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["worker"] != "file_gc" {
+		t.Errorf("worker attribute missing: %v", entry)
+	}
+}
@@ -0,0 +1,48 @@
+// Package mailer sends notification emails. The SMTP implementation is
+// configured from the environment; without SMTP_HOST a no-op mailer is used.
+package mailer
+
+import (
+	"context"
+	"os"
+)
+
+// Mailer sends a plain text email
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+	// Enabled reports whether emails are actually delivered
+	Enabled() bool
+}
+
+// Pinger is implemented by mailers that can check their server is reachable
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Noop discards every email
+type Noop struct{}
+
+func (Noop) Send(ctx context.Context, to, subject, body string) error { return nil }
+
+func (Noop) Enabled() bool { return false }
+
+// NewFromEnv returns an SMTP mailer when SMTP_HOST is set, otherwise Noop.
+// Uses SMTP_PORT (default 587), SMTP_USERNAME, SMTP_PASSWORD and SMTP_FROM.
+func NewFromEnv() Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return Noop{}
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = os.Getenv("SMTP_USERNAME")
+	}
+
+	return NewSMTP(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from)
+}
@@ -0,0 +1,118 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeSMTP accepts a single session and sends the DATA payload on the channel
+func fakeSMTP(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	data := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 fake ESMTP")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 fake")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var b strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					b.WriteString(l)
+				}
+				data <- b.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().String(), data
+}
+
+func TestSMTPSend(t *testing.T) {
+	addr, data := fakeSMTP(t)
+	host, port, _ := net.SplitHostPort(addr)
+
+	m := NewSMTP(host, port, "", "", "noreply@kampus.ac.id")
+	err := m.Send(context.Background(), "alumni@example.com", "Sertifikat disetujui", "Halo,\nsertifikat anda disetujui.")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := <-data
+	for _, want := range []string{
+		"From: noreply@kampus.ac.id\r\n",
+		"To: alumni@example.com\r\n",
+		"Subject: Sertifikat disetujui\r\n",
+		"\r\n\r\nHalo,\r\nsertifikat anda disetujui.",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestBuildMessageStripsHeaderInjection(t *testing.T) {
+	msg := string(buildMessage("a@example.com", "b@example.com", "hi\r\nBcc: evil@example.com", "body"))
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("subject must not inject headers:\n%s", msg)
+	}
+}
+
+func TestSMTPPing(t *testing.T) {
+	addr, _ := fakeSMTP(t)
+	host, port, _ := net.SplitHostPort(addr)
+
+	if err := NewSMTP(host, port, "", "", "noreply@kampus.ac.id").Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port, _ = net.SplitHostPort(ln.Addr().String())
+	ln.Close()
+	if err := NewSMTP(host, port, "", "", "").Ping(context.Background()); err == nil {
+		t.Error("expected an error for a closed port")
+	}
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+var mongoPoolConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "mongo_pool_connections",
+	Help: "Connections of the MongoDB pool by state (open, in_use).",
+}, []string{"state"})
+
+// MongoCommandMonitor records the duration of every MongoDB command
+func MongoCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			ObserveQuery("mongo", evt.CommandName, evt.Duration, nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			ObserveQuery("mongo", evt.CommandName, evt.Duration, errors.New(evt.Failure))
+		},
+	}
+}
+
+// MongoPoolMonitor tracks open and checked-out connections of the pool
+func MongoPoolMonitor() *event.PoolMonitor {
+	open := mongoPoolConnections.WithLabelValues("open")
+	inUse := mongoPoolConnections.WithLabelValues("in_use")
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			switch evt.Type {
+			case event.ConnectionCreated:
+				open.Inc()
+			case event.ConnectionClosed:
+				open.Dec()
+			case event.GetSucceeded:
+				inUse.Inc()
+			case event.ConnectionReturned:
+				inUse.Dec()
+			}
+		},
+	}
+}
+
+// RegisterSQLStats exposes the pool statistics of db (go_sql_*) with the
+// label db_name=name
+func RegisterSQLStats(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
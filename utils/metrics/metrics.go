@@ -0,0 +1,125 @@
+// Package metrics holds the Prometheus collectors of the application:
+// latency HTTP per route, durasi query database dan statistik pool, serta
+// counter domain (login, upload) dan jumlah record alumni.
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// RouteUnmatched labels requests that matched no route, sehingga path acak
+// (scanner, typo) tidak menambah deret waktu baru
+const RouteUnmatched = "unmatched"
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of database commands by driver, operation and outcome.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"driver", "operation", "status"})
+
+	loginAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_login_attempts_total",
+		Help: "Login attempts by account type (user, alumni) and result (success, failure, error).",
+	}, []string{"type", "result"})
+
+	uploadBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "upload_bytes_total",
+		Help: "Bytes of stored uploads by file category.",
+	}, []string{"category"})
+)
+
+// Handler serves the registry in the Prometheus text format
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.Handler())
+}
+
+// ObserveRequest records one HTTP request. route harus berupa template route
+// (misalnya /alumni/:id), bukan path asli.
+func ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(elapsed.Seconds())
+}
+
+// ObserveQuery records one database command
+func ObserveQuery(driver, operation string, elapsed time.Duration, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	dbQueryDuration.WithLabelValues(driver, operation, status).Observe(elapsed.Seconds())
+}
+
+// Login results
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+	LoginError   = "error"
+)
+
+// ObserveLogin counts a login attempt of accountType (user atau alumni)
+func ObserveLogin(accountType, result string) {
+	loginAttempts.WithLabelValues(accountType, result).Inc()
+}
+
+// ObserveUpload counts the stored bytes of an upload
+func ObserveUpload(category string, size int64) {
+	uploadBytes.WithLabelValues(category).Add(float64(size))
+}
+
+// RecordCounts are the record gauges read on every scrape
+type RecordCounts struct {
+	Alumni  int
+	Trashed map[string]int // per entity, misalnya alumni dan pekerjaan
+}
+
+type recordCollector struct {
+	count   func(ctx context.Context) (RecordCounts, error)
+	alumni  *prometheus.Desc
+	trashed *prometheus.Desc
+}
+
+// RegisterRecordCounts exposes alumni_total and trashed_records from count,
+// dipanggil sekali per scrape dengan batas waktu 5 detik
+func RegisterRecordCounts(count func(ctx context.Context) (RecordCounts, error)) {
+	prometheus.MustRegister(&recordCollector{
+		count:   count,
+		alumni:  prometheus.NewDesc("alumni_total", "Alumni that are not in the trash.", nil, nil),
+		trashed: prometheus.NewDesc("trashed_records", "Soft-deleted records by entity.", []string{"entity"}, nil),
+	})
+}
+
+func (rc *recordCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rc.alumni
+	ch <- rc.trashed
+}
+
+func (rc *recordCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	counts, err := rc.count(ctx)
+	if err != nil {
+		// Gauge dilewati pada scrape ini agar nilai lama tidak tampak aktual
+		slog.Warn("Failed to count records for metrics", "error", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(rc.alumni, prometheus.GaugeValue, float64(counts.Alumni))
+	for entity, n := range counts.Trashed {
+		ch <- prometheus.MustNewConstMetric(rc.trashed, prometheus.GaugeValue, float64(n), entity)
+	}
+}
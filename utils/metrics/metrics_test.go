@@ -0,0 +1,71 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"clean-arch/middleware/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func scrape(t *testing.T, app *fiber.App) string {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	metrics.RegisterRecordCounts(func(ctx context.Context) (metrics.RecordCounts, error) {
+		return metrics.RecordCounts{Alumni: 42, Trashed: map[string]int{"alumni": 3}}, nil
+	})
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Use(middleware.LoggerMiddleware)
+	app.Use(middleware.Metrics)
+	app.Get("/metrics", metrics.Handler())
+	app.Get("/alumni/:id", func(c *fiber.Ctx) error {
+		if c.Params("id") == "missing" {
+			return apperror.NotFound("alumni.not_found")
+		}
+		return c.SendString("ok")
+	})
+
+	for _, path := range []string{"/alumni/1", "/alumni/2", "/alumni/missing", "/no/such/path"} {
+		if _, err := app.Test(httptest.NewRequest("GET", path, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	metrics.ObserveLogin("user", metrics.LoginFailure)
+	metrics.ObserveUpload("photo", 2048)
+	metrics.ObserveQuery("postgres", "select", 0, errors.New("timeout"))
+
+	body := scrape(t, app)
+	for _, want := range []string{
+		`http_request_duration_seconds_count{method="GET",route="/alumni/:id",status="200"} 2`,
+		`http_request_duration_seconds_count{method="GET",route="/alumni/:id",status="404"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`,
+		`auth_login_attempts_total{result="failure",type="user"} 1`,
+		`upload_bytes_total{category="photo"} 2048`,
+		`db_query_duration_seconds_count{driver="postgres",operation="select",status="error"} 1`,
+		`alumni_total 42`,
+		`trashed_records{entity="alumni"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %s", want)
+		}
+	}
+	if strings.Contains(body, "/alumni/1") || strings.Contains(body, "/no/such/path") {
+		t.Error("raw paths must not be used as route labels")
+	}
+}
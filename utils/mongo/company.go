@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Bentuk badan usaha yang diabaikan saat mencocokkan nama perusahaan
+var companyLegalWords = map[string]bool{
+	"pt": true, "cv": true, "tbk": true, "persero": true, "perum": true, "ud": true,
+	"inc": true, "ltd": true, "llc": true, "corp": true, "corporation": true,
+	"co": true, "company": true, "limited": true, "gmbh": true, "bv": true, "plc": true,
+}
+
+// NormalizeCompanyName reduces a company name to its comparable form:
+// huruf kecil, tanpa tanda baca dan tanpa bentuk badan usaha, sehingga
+// "PT. Telkom Indonesia (Persero) Tbk" menjadi "telkom indonesia".
+func NormalizeCompanyName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, w := range fields {
+		if !companyLegalWords[w] {
+			words = append(words, w)
+		}
+	}
+	// Nama yang seluruhnya bentuk badan usaha tetap dipakai apa adanya
+	if len(words) == 0 {
+		words = fields
+	}
+	return strings.Join(words, " ")
+}
+
+// CompanyKeys returns the distinct normalized forms of a name and its aliases
+func CompanyKeys(nama string, aliases []string) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, s := range append([]string{nama}, aliases...) {
+		k := NormalizeCompanyName(s)
+		if k != "" && !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// CompanySimilarity scores how well a typed name matches a normalized company
+// key, 0 sampai 1. Dasarnya kemiripan trigram seperti pg_trgm, ditambah bobot
+// bila ketikan merupakan awalan atau bagian dari nama (untuk autocomplete).
+func CompanySimilarity(query, key string) float64 {
+	q := NormalizeCompanyName(query)
+	if q == "" || key == "" {
+		return 0
+	}
+	if q == key {
+		return 1
+	}
+
+	score := trigramSimilarity(q, key)
+	if len(q) >= 3 && strings.Contains(key, q) {
+		contained := 0.5 + 0.4*float64(len(q))/float64(len(key))
+		if strings.HasPrefix(key, q) {
+			contained += 0.05
+		}
+		if contained > score {
+			score = contained
+		}
+	}
+	return score
+}
+
+// trigramSimilarity is the Jaccard similarity of the word trigrams of a and b
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// trigrams splits s into trigrams per word, diawali dua spasi dan diakhiri satu spasi
+func trigrams(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.Fields(s) {
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			set[string(r[i:i+3])] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeCompanyName(t *testing.T) {
+	tests := map[string]string{
+		"PT. Telkom Indonesia (Persero) Tbk": "telkom indonesia",
+		"  Gojek  ":                          "gojek",
+		"Google, Inc.":                       "google",
+		"PT":                                 "pt",
+		"":                                   "",
+	}
+	for in, want := range tests {
+		if got := NormalizeCompanyName(in); got != want {
+			t.Errorf("NormalizeCompanyName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCompanyKeys(t *testing.T) {
+	got := CompanyKeys("PT Telkom Indonesia", []string{"Telkom Indonesia Tbk", "Telkom", ""})
+	want := []string{"telkom indonesia", "telkom"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompanyKeys = %v, want %v", got, want)
+	}
+}
+
+func TestCompanySimilarity(t *testing.T) {
+	if s := CompanySimilarity("PT Telkom Indonesia", "telkom indonesia"); s != 1 {
+		t.Errorf("exact match score = %v, want 1", s)
+	}
+	typo := CompanySimilarity("Telkom Indonesa", "telkom indonesia")
+	other := CompanySimilarity("Telkom Indonesa", "bank mandiri")
+	if typo < 0.4 || other > 0.1 {
+		t.Errorf("typo score = %v, unrelated score = %v", typo, other)
+	}
+	if s := CompanySimilarity("tok", "tokopedia"); s < 0.5 {
+		t.Errorf("prefix score = %v, want >= 0.5", s)
+	}
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FieldSelection is the sparse fieldset and embedded relations of a read
+// request, contoh ?fields=nama,email&include=pekerjaan,current_job
+type FieldSelection struct {
+	Fields  []string
+	Include []string
+
+	// IncludeSet true jika query include ada, walaupun kosong
+	IncludeSet bool
+}
+
+// IsEmpty reports whether the request asked for the default representation
+func (s FieldSelection) IsEmpty() bool {
+	return len(s.Fields) == 0 && !s.IncludeSet
+}
+
+// Includes reports whether relation was requested
+func (s FieldSelection) Includes(relation string) bool {
+	return slices.Contains(s.Include, relation)
+}
+
+// ParseFieldSelection reads ?fields= and ?include=, nilai di luar daftar yang
+// diizinkan ditolak agar typo tidak diam-diam menghasilkan respons kosong
+func ParseFieldSelection(c *fiber.Ctx, allowedFields, allowedIncludes []string) (FieldSelection, error) {
+	var sel FieldSelection
+
+	fields, err := parseListQuery(c, "fields")
+	if err != nil {
+		return sel, err
+	}
+	for _, f := range fields {
+		if !slices.Contains(allowedFields, f) {
+			return sel, fmt.Errorf("field %q tidak dikenal", f)
+		}
+		if !slices.Contains(sel.Fields, f) {
+			sel.Fields = append(sel.Fields, f)
+		}
+	}
+
+	include, err := parseListQuery(c, "include")
+	if err != nil {
+		return sel, err
+	}
+	for _, r := range include {
+		if !slices.Contains(allowedIncludes, r) {
+			return sel, fmt.Errorf("include %q tidak didukung", r)
+		}
+		if !slices.Contains(sel.Include, r) {
+			sel.Include = append(sel.Include, r)
+		}
+	}
+	sel.IncludeSet = c.Context().QueryArgs().Has("include")
+
+	return sel, nil
+}
+
+// SelectFields converts v to its JSON object and keeps only fields, semua
+// field dikembalikan jika fields kosong
+func SelectFields(v interface{}, fields []string) (fiber.Map, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m fiber.Map
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return m, nil
+	}
+
+	selected := fiber.Map{}
+	for _, f := range fields {
+		if value, ok := m[f]; ok {
+			selected[f] = value
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"testing"
+
+	"clean-arch/app/model/mongo"
+)
+
+func TestParseGajiRange(t *testing.T) {
+	tests := []struct {
+		in   string
+		want model.Gaji
+	}{
+		{"5-10 juta", model.Gaji{Min: 5000000, Max: 10000000, Currency: "IDR", Period: "monthly"}},
+		{"Rp 5.000.000 - Rp 8.000.000", model.Gaji{Min: 5000000, Max: 8000000, Currency: "IDR", Period: "monthly"}},
+		{"Rp5.000.000,00 s/d Rp7.500.000,00", model.Gaji{Min: 5000000, Max: 7500000, Currency: "IDR", Period: "monthly"}},
+		{"7,5 jt/bulan", model.Gaji{Min: 7500000, Max: 7500000, Currency: "IDR", Period: "monthly"}},
+		{"USD 3000-4000", model.Gaji{Min: 3000, Max: 4000, Currency: "USD", Period: "monthly"}},
+		{"120 juta per tahun", model.Gaji{Min: 120000000, Max: 120000000, Currency: "IDR", Period: "yearly"}},
+		{"< 5 juta", model.Gaji{Min: 0, Max: 5000000, Currency: "IDR", Period: "monthly"}},
+		{"S$ 4.5k - 6k", model.Gaji{Min: 4500, Max: 6000, Currency: "SGD", Period: "monthly"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseGajiRange(tt.in)
+		if err != nil {
+			t.Errorf("ParseGajiRange(%q) error: %v", tt.in, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("ParseGajiRange(%q) = %+v, want %+v", tt.in, *got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "rahasia", "5-10", "10 - 5 juta", "1-2-3 juta"} {
+		if _, err := ParseGajiRange(in); err == nil {
+			t.Errorf("ParseGajiRange(%q) should fail", in)
+		}
+	}
+}
+
+func TestNormalizeGaji(t *testing.T) {
+	g := model.Gaji{Min: 1, Max: 2, Currency: " usd "}
+	if err := NormalizeGaji(&g); err != nil || g.Currency != "USD" || g.Period != model.GajiPeriodMonthly {
+		t.Errorf("NormalizeGaji = %+v, %v", g, err)
+	}
+
+	for _, g := range []model.Gaji{
+		{Min: 2, Max: 1},
+		{Min: -1, Max: 1},
+		{Max: 1, Currency: "rupiah"},
+		{Max: 1, Period: "weekly"},
+	} {
+		if err := NormalizeGaji(&g); err == nil {
+			t.Errorf("NormalizeGaji(%+v) should fail", g)
+		}
+	}
+}
+
+func TestFormatGaji(t *testing.T) {
+	g := model.Gaji{Min: 5000000, Max: 12500000, Currency: "IDR", Period: model.GajiPeriodMonthly}
+	if got, want := FormatGaji(g), "IDR 5.000.000 - 12.500.000 / bulan"; got != want {
+		t.Errorf("FormatGaji = %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"clean-arch/app/model/mongo"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -33,8 +34,31 @@ func ParsePaginationParams(c *fiber.Ctx) model.PaginationParams {
 	}
 }
 
-// CalculateTotalPages calculates total pages based on total records and limit
+// ParseSearchMode reads ?search_mode=, default "regex" (substring biasa)
+func ParseSearchMode(c *fiber.Ctx) (string, error) {
+	switch mode := strings.ToLower(c.Query("search_mode", model.SearchModeRegex)); mode {
+	case model.SearchModeRegex, model.SearchModeFullText:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("search_mode harus %s atau %s", model.SearchModeRegex, model.SearchModeFullText)
+	}
+}
+
+// ParseCursorParams reads the keyset pagination options into params. Mode
+// cursor aktif jika query "cursor" ada, kosong untuk halaman pertama.
+// count=false melewati penghitungan total pada mode apa pun.
+func ParseCursorParams(c *fiber.Ctx, params *model.PaginationParams) bool {
+	params.SkipCount = strings.ToLower(c.Query("count")) == "false"
+	params.Cursor = c.Query("cursor")
+	return c.Context().QueryArgs().Has("cursor")
+}
+
+// CalculateTotalPages calculates total pages based on total records and limit.
+// Total -1 (tidak dihitung) menghasilkan -1.
 func CalculateTotalPages(total, limit int) int {
+	if total < 0 {
+		return -1
+	}
 	return (total + limit - 1) / limit
 }
 
@@ -50,6 +74,8 @@ func CreatePaginationResponse(data interface{}, params model.PaginationParams, t
 		SortBy: params.SortBy,
 		Order:  params.Order,
 		Search: params.Search,
+
+		SearchMode: params.SearchMode,
 	}
 
 	return fiber.Map{
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"clean-arch/app/model/mongo"
+)
+
+func validationFields(t *testing.T, v interface{}) []FieldError {
+	t.Helper()
+	err := ValidateStruct(v)
+	if err == nil {
+		return nil
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ValidateStruct error = %v, want *ValidationError", err)
+	}
+	return verr.Fields
+}
+
+func TestValidateCreateAlumniRequest(t *testing.T) {
+	valid := model.CreateAlumniRequest{
+		NIM: "2101234567", Nama: "Budi", Jurusan: "Teknik Informatika",
+		Angkatan: 2019, TahunLulus: 2023, Email: "budi@example.com", Password: "rahasia",
+	}
+	if fields := validationFields(t, valid); fields != nil {
+		t.Fatalf("valid request rejected: %+v", fields)
+	}
+
+	invalid := valid
+	invalid.NIM = "12"
+	invalid.TahunLulus = 2018
+	invalid.Email = "budi"
+	invalid.Password = "123"
+	got := map[string]FieldError{}
+	for _, f := range validationFields(t, invalid) {
+		got[f.Field] = f
+	}
+
+	want := map[string]string{"nim": "nim", "tahun_lulus": "gtefield", "email": "email", "password": "min"}
+	if len(got) != len(want) {
+		t.Errorf("fields = %+v", got)
+	}
+	for field, rule := range want {
+		if got[field].Rule != rule {
+			t.Errorf("%s rule = %q, want %q", field, got[field].Rule, rule)
+		}
+	}
+	if got["tahun_lulus"].Param != "angkatan" {
+		t.Errorf("gtefield param = %q, want angkatan", got["tahun_lulus"].Param)
+	}
+}
+
+func TestValidateCreatePekerjaanRequest(t *testing.T) {
+	start := model.Date{Time: time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)}
+	req := model.CreatePekerjaanRequest{
+		PosisiJabatan: "Backend Engineer", BidangIndustri: "Teknologi", LokasiKerja: "Jakarta",
+		TanggalMulaiKerja: start, StatusPekerjaan: "aktif",
+	}
+
+	var fields []string
+	for _, f := range validationFields(t, req) {
+		fields = append(fields, f.Field+":"+f.Rule)
+	}
+	if !reflect.DeepEqual(fields, []string{"nama_perusahaan:required_without"}) {
+		t.Errorf("fields = %v", fields)
+	}
+
+	req.NamaPerusahaan = "Gojek"
+	req.TanggalSelesaiKerja = &model.Date{Time: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)}
+	req.TanggalMulaiKerja = model.Date{}
+	fields = nil
+	for _, f := range validationFields(t, req) {
+		fields = append(fields, f.Field+":"+f.Rule)
+	}
+	want := []string{"tanggal_mulai_kerja:required", "tanggal_selesai_kerja:tanggal"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+}
+
+func TestFieldErrorsLocalize(t *testing.T) {
+	fields := validationFields(t, model.CreateAlumniRequest{})
+	if fields[0].Message != "wajib diisi" {
+		t.Errorf("default message = %q", fields[0].Message)
+	}
+
+	localized := FieldErrors(fields).Localize("en").([]FieldError)
+	if localized[0].Message != "is required" || localized[0].Field != fields[0].Field {
+		t.Errorf("localized = %+v", localized[0])
+	}
+	if fields[0].Message != "wajib diisi" {
+		t.Error("Localize must not modify the original fields")
+	}
+}
@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"clean-arch/app/model/postgre"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxFilterValues membatasi jumlah nilai dalam satu filter daftar
+const maxFilterValues = 50
+
+// ParseAlumniFilter reads the structured alumni filters from the query string.
+//
+//	jurusan=Informatika,Sistem Informasi   salah satu dari daftar (dipisah koma)
+//	angkatan=2018..2020                    rentang inklusif; juga 2019, 2018.. atau ..2020
+//	tahun_lulus=2022..2024                 rentang inklusif seperti angkatan
+//	has_job=true|false                     punya riwayat pekerjaan
+//	employed=true|false                    sedang bekerja (pekerjaan berstatus aktif)
+//	bidang_industri=Teknologi,Perbankan    bidang industri pekerjaan aktif
+//	created=2024-01-01..2024-06-30         tanggal data dibuat, rentang inklusif
+//
+// Semua filter digabung dengan AND, dan bisa dipakai bersama search.
+func ParseAlumniFilter(c *fiber.Ctx) (model.AlumniFilter, error) {
+	var filter model.AlumniFilter
+	var err error
+
+	if filter.Jurusan, err = parseListQuery(c, "jurusan"); err != nil {
+		return filter, err
+	}
+	if filter.BidangIndustri, err = parseListQuery(c, "bidang_industri"); err != nil {
+		return filter, err
+	}
+	if filter.AngkatanMin, filter.AngkatanMax, err = parseIntRange(c, "angkatan"); err != nil {
+		return filter, err
+	}
+	if filter.TahunLulusMin, filter.TahunLulusMax, err = parseIntRange(c, "tahun_lulus"); err != nil {
+		return filter, err
+	}
+	if filter.HasJob, err = parseBoolQuery(c, "has_job"); err != nil {
+		return filter, err
+	}
+	if filter.Employed, err = parseBoolQuery(c, "employed"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedFrom, filter.CreatedTo, err = parseDateRangeQuery(c, "created"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func parseListQuery(c *fiber.Ctx, key string) ([]string, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+
+	var values []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	if len(values) > maxFilterValues {
+		return nil, fmt.Errorf("%s maksimal %d nilai", key, maxFilterValues)
+	}
+	return values, nil
+}
+
+// splitRange splits "a..b" into its bounds; a single value is both bounds
+func splitRange(v string) (string, string) {
+	if from, to, ok := strings.Cut(v, ".."); ok {
+		return strings.TrimSpace(from), strings.TrimSpace(to)
+	}
+	v = strings.TrimSpace(v)
+	return v, v
+}
+
+func parseIntRange(c *fiber.Ctx, key string) (*int, *int, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil, nil
+	}
+
+	fromStr, toStr := splitRange(v)
+	bounds := make([]*int, 2)
+	for i, s := range []string{fromStr, toStr} {
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s harus berupa tahun atau rentang seperti 2018..2020", key)
+		}
+		bounds[i] = &n
+	}
+
+	if bounds[0] == nil && bounds[1] == nil {
+		return nil, nil, fmt.Errorf("%s harus berupa tahun atau rentang seperti 2018..2020", key)
+	}
+	if bounds[0] != nil && bounds[1] != nil && *bounds[0] > *bounds[1] {
+		return nil, nil, fmt.Errorf("awal rentang %s tidak boleh lebih besar dari akhir", key)
+	}
+	return bounds[0], bounds[1], nil
+}
+
+// parseDateRangeQuery parses "YYYY-MM-DD..YYYY-MM-DD"; tanggal akhir inklusif
+// sampai akhir hari
+func parseDateRangeQuery(c *fiber.Ctx, key string) (*time.Time, *time.Time, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil, nil
+	}
+
+	fromStr, toStr := splitRange(v)
+	var from, to *time.Time
+	if fromStr != "" {
+		t, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s harus berformat YYYY-MM-DD..YYYY-MM-DD", key)
+		}
+		from = &t
+	}
+	if toStr != "" {
+		t, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s harus berformat YYYY-MM-DD..YYYY-MM-DD", key)
+		}
+		t = t.Add(24*time.Hour - time.Nanosecond)
+		to = &t
+	}
+
+	if from == nil && to == nil {
+		return nil, nil, fmt.Errorf("%s harus berformat YYYY-MM-DD..YYYY-MM-DD", key)
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return nil, nil, fmt.Errorf("awal rentang %s tidak boleh lebih besar dari akhir", key)
+	}
+	return from, to, nil
+}
+
+func parseBoolQuery(c *fiber.Ctx, key string) (*bool, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s harus bernilai true atau false", key)
+	}
+	return &b, nil
+}
@@ -0,0 +1,204 @@
+package utils
+
+import (
+	"clean-arch/app/model/postgre"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxGajiAmount is a sanity bound for a salary amount
+const maxGajiAmount = 1_000_000_000_000
+
+var (
+	gajiNumberRe   = regexp.MustCompile(`(\d+(?:[.,]\d+)*)(?:\s*(juta|jt|ribu|rb|million|k)\b)?`)
+	gajiCurrencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// Penanda mata uang pada gaji_range, simbol yang lebih spesifik dicek lebih dulu
+var gajiCurrencies = []struct{ token, code string }{
+	{"idr", "IDR"}, {"rp", "IDR"},
+	{"sgd", "SGD"}, {"s$", "SGD"},
+	{"usd", "USD"}, {"us$", "USD"}, {"$", "USD"},
+	{"myr", "MYR"}, {"rm", "MYR"},
+	{"eur", "EUR"}, {"€", "EUR"},
+	{"jpy", "JPY"}, {"¥", "JPY"},
+	{"aud", "AUD"},
+}
+
+var (
+	gajiYearlyWords     = []string{"tahun", "thn", "year", "annual", "p.a"}
+	gajiUpperOnlyPrefix = []string{"<", "di bawah", "dibawah", "kurang dari", "maks", "max", "under", "below"}
+)
+
+// ParseGajiRange converts a free-text gaji_range into a structured salary.
+// Format yang dikenali antara lain "5-10 juta", "Rp 5.000.000 - Rp 8.000.000",
+// "7,5 jt/bulan", "USD 3000-4000", "120 juta per tahun" dan "< 5 juta".
+// Tanpa penanda mata uang dianggap IDR, tanpa penanda periode dianggap bulanan.
+func ParseGajiRange(s string) (*model.Gaji, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	if text == "" {
+		return nil, errors.New("gaji_range kosong")
+	}
+
+	g := &model.Gaji{Currency: model.DefaultGajiCurrency, Period: model.GajiPeriodMonthly}
+	if code := detectGajiCurrency(text); code != "" {
+		g.Currency = code
+	}
+	for _, w := range gajiYearlyWords {
+		if strings.Contains(text, w) {
+			g.Period = model.GajiPeriodYearly
+			break
+		}
+	}
+
+	matches := gajiNumberRe.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 || len(matches) > 2 {
+		return nil, fmt.Errorf("gaji_range %q tidak dikenali", s)
+	}
+
+	// "5-10 juta": satuan angka terakhir berlaku juga untuk angka pertama
+	if len(matches) == 2 && matches[0][2] == "" {
+		matches[0][2] = matches[1][2]
+	}
+	amounts := make([]int64, len(matches))
+	for i, m := range matches {
+		n, err := parseGajiAmount(m[1], m[2])
+		if err != nil {
+			return nil, fmt.Errorf("gaji_range %q tidak dikenali", s)
+		}
+		if g.Currency == model.DefaultGajiCurrency && n < 1000 {
+			return nil, fmt.Errorf("nominal gaji_range %q terlalu kecil, sertakan satuan seperti juta", s)
+		}
+		amounts[i] = n
+	}
+
+	if len(amounts) == 2 {
+		g.Min, g.Max = amounts[0], amounts[1]
+	} else {
+		g.Min, g.Max = amounts[0], amounts[0]
+		for _, p := range gajiUpperOnlyPrefix {
+			if strings.HasPrefix(text, p) {
+				g.Min = 0
+				break
+			}
+		}
+	}
+
+	if err := NormalizeGaji(g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func detectGajiCurrency(text string) string {
+	for _, c := range gajiCurrencies {
+		i := strings.Index(text, c.token)
+		if i < 0 {
+			continue
+		}
+		// Kode huruf harus berdiri sendiri, "rp" di dalam kata lain tidak dihitung
+		if isLetter(c.token[0]) {
+			end := i + len(c.token)
+			if (i > 0 && isLetter(text[i-1])) || (end < len(text) && isLetter(text[end])) {
+				continue
+			}
+		}
+		return c.code
+	}
+	return ""
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z'
+}
+
+// parseGajiAmount parses one amount. Dengan satuan, satu pemisah diikuti 1-2
+// digit adalah desimal ("7,5 juta"); selain itu titik dan koma dianggap
+// pemisah ribuan, kecuali pemisah terakhir yang berbeda ("5.000.000,00").
+func parseGajiAmount(num, unit string) (int64, error) {
+	multiplier := 1.0
+	switch unit {
+	case "juta", "jt", "million":
+		multiplier = 1e6
+	case "ribu", "rb", "k":
+		multiplier = 1e3
+	}
+
+	whole, fraction := num, ""
+	if i := strings.LastIndexAny(num, ".,"); i >= 0 {
+		separators := strings.Count(num, ".") + strings.Count(num, ",")
+		mixed := strings.Contains(num, ".") && strings.Contains(num, ",")
+		if (multiplier > 1 && separators == 1 && len(num)-i-1 <= 2) || mixed {
+			whole, fraction = num[:i], num[i+1:]
+		}
+	}
+	whole = strings.NewReplacer(".", "", ",", "").Replace(whole)
+
+	f, err := strconv.ParseFloat(whole+"."+fraction+"0", 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(math.Round(f * multiplier)), nil
+}
+
+// NormalizeGaji fills the defaults of a salary and validates it
+func NormalizeGaji(g *model.Gaji) error {
+	g.Currency = strings.ToUpper(strings.TrimSpace(g.Currency))
+	if g.Currency == "" {
+		g.Currency = model.DefaultGajiCurrency
+	}
+	g.Period = strings.ToLower(strings.TrimSpace(g.Period))
+	if g.Period == "" {
+		g.Period = model.GajiPeriodMonthly
+	}
+
+	switch {
+	case !gajiCurrencyRe.MatchString(g.Currency):
+		return errors.New("gaji.currency harus berupa kode mata uang ISO 4217, contoh IDR")
+	case g.Period != model.GajiPeriodMonthly && g.Period != model.GajiPeriodYearly:
+		return errors.New("gaji.period harus monthly atau yearly")
+	case g.Min < 0:
+		return errors.New("gaji.min tidak boleh negatif")
+	case g.Max <= 0:
+		return errors.New("gaji.max wajib diisi")
+	case g.Max < g.Min:
+		return errors.New("gaji.max tidak boleh lebih kecil dari gaji.min")
+	case g.Max > maxGajiAmount:
+		return errors.New("gaji.max terlalu besar")
+	}
+	return nil
+}
+
+// FormatGaji renders a salary as text, dipakai untuk mengisi gaji_range
+func FormatGaji(g model.Gaji) string {
+	period := "bulan"
+	if g.Period == model.GajiPeriodYearly {
+		period = "tahun"
+	}
+
+	switch {
+	case g.Min == 0:
+		return fmt.Sprintf("maks %s %s / %s", g.Currency, formatThousands(g.Max), period)
+	case g.Min == g.Max:
+		return fmt.Sprintf("%s %s / %s", g.Currency, formatThousands(g.Min), period)
+	default:
+		return fmt.Sprintf("%s %s - %s / %s", g.Currency, formatThousands(g.Min), formatThousands(g.Max), period)
+	}
+}
+
+// formatThousands groups digits with dots, contoh 5.000.000
+func formatThousands(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"clean-arch/app/model/postgre"
+	"clean-arch/utils/apperror"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultNIMPattern menerima huruf, angka, titik, garis miring dan tanda
+// hubung; kampus dapat memperketat lewat NIM_PATTERN
+const defaultNIMPattern = `^[A-Za-z0-9][A-Za-z0-9./-]{4,19}$`
+
+// Rentang yang diterima aturan tahun dan tanggal
+const (
+	minTahun        = 1900
+	maxTahunAhead   = 10 // tahun setelah tahun berjalan
+	minTanggal      = "1950-01-01"
+	maxTanggalAhead = 10 // tahun setelah hari ini
+)
+
+// FieldError is one invalid field of a request body. Field memakai nama JSON
+// (dengan titik untuk field bersarang), Rule adalah nama aturan validate.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+
+	key  string
+	args []interface{}
+}
+
+// NewFieldError builds a FieldError whose message comes from the apperror
+// catalog, sehingga ikut diterjemahkan sesuai Accept-Language
+func NewFieldError(field, rule, key string, args ...interface{}) FieldError {
+	return FieldError{
+		Field:   field,
+		Rule:    rule,
+		Message: apperror.Message(apperror.DefaultLanguage(), key, args...),
+		key:     key,
+		args:    args,
+	}
+}
+
+// FieldErrors renders its messages in the language of the request when used
+// as apperror details
+type FieldErrors []FieldError
+
+func (fields FieldErrors) Localize(lang string) interface{} {
+	localized := make([]FieldError, len(fields))
+	for i, f := range fields {
+		localized[i] = f
+		if f.key != "" {
+			localized[i].Message = apperror.Message(lang, f.key, f.args...)
+		}
+	}
+	return localized
+}
+
+// ValidationError is returned by ParseBody and ValidateStruct when the body
+// is malformed or breaks the validate tags of the request struct
+type ValidationError struct {
+	Message string
+	Fields  []FieldError
+
+	key string // validation.failed atau validation.malformed
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+var (
+	validateOnce sync.Once
+	validate     *validator.Validate
+)
+
+// getValidator builds the validator on first use, setelah .env dimuat
+func getValidator() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+		validate.RegisterTagNameFunc(jsonFieldName)
+		validate.RegisterCustomTypeFunc(func(v reflect.Value) interface{} {
+			return v.Interface().(model.Date).Time
+		}, model.Date{})
+
+		pattern := os.Getenv("NIM_PATTERN")
+		if pattern == "" {
+			pattern = defaultNIMPattern
+		}
+		nim, err := regexp.Compile(pattern)
+		if err != nil {
+			nim = regexp.MustCompile(defaultNIMPattern)
+		}
+		validate.RegisterValidation("nim", func(fl validator.FieldLevel) bool {
+			return nim.MatchString(fl.Field().String())
+		})
+		validate.RegisterValidation("tahun", func(fl validator.FieldLevel) bool {
+			y := fl.Field().Int()
+			return y >= minTahun && y <= int64(time.Now().Year()+maxTahunAhead)
+		})
+		validate.RegisterValidation("tanggal", func(fl validator.FieldLevel) bool {
+			t, ok := fl.Field().Interface().(time.Time)
+			if !ok {
+				return false
+			}
+			lower, _ := time.Parse("2006-01-02", minTanggal)
+			return !t.Before(lower) && !t.After(time.Now().AddDate(maxTanggalAhead, 0, 0))
+		})
+	})
+	return validate
+}
+
+// jsonFieldName reports fields by their JSON name
+func jsonFieldName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// ValidateStruct checks v against its validate tags
+func ValidateStruct(v interface{}) error {
+	err := getValidator().Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fields := make([]FieldError, len(verrs))
+	summary := make([]string, len(verrs))
+	for i, fe := range verrs {
+		field := fe.Namespace()
+		if dot := strings.Index(field, "."); dot >= 0 {
+			field = field[dot+1:]
+		}
+		param := fe.Param()
+		if strings.HasSuffix(fe.Tag(), "field") || strings.HasPrefix(fe.Tag(), "required_with") {
+			param = paramFieldName(t, param)
+		}
+		key, args := fieldMessage(fe, param)
+		fields[i] = NewFieldError(field, fe.Tag(), key, args...)
+		fields[i].Param = param
+		summary[i] = field + " " + fields[i].Message
+	}
+	return &ValidationError{Message: "Data tidak valid: " + strings.Join(summary, "; "), Fields: fields, key: "validation.failed"}
+}
+
+// paramFieldName translates the struct field named in a cross-field rule to
+// its JSON name
+func paramFieldName(t reflect.Type, name string) string {
+	if t.Kind() != reflect.Struct {
+		return name
+	}
+	if f, ok := t.FieldByName(name); ok {
+		return jsonFieldName(f)
+	}
+	return name
+}
+
+// fieldMessage returns the catalog key and arguments describing a failed rule
+func fieldMessage(fe validator.FieldError, param string) (string, []interface{}) {
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = "_string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = "_items"
+	}
+
+	switch fe.Tag() {
+	case "required", "email", "nim":
+		return "validation." + fe.Tag(), nil
+	case "http_url", "url":
+		return "validation.url", nil
+	case "required_without", "gtefield":
+		return "validation." + fe.Tag(), []interface{}{param}
+	case "min", "max":
+		return "validation." + fe.Tag() + unit, []interface{}{param}
+	case "oneof":
+		return "validation.oneof", []interface{}{strings.Join(strings.Fields(param), ", ")}
+	case "tahun":
+		return "validation.tahun", []interface{}{minTahun, time.Now().Year() + maxTahunAhead}
+	case "tanggal":
+		return "validation.tanggal", []interface{}{minTanggal,
+			time.Now().AddDate(maxTanggalAhead, 0, 0).Format("2006-01-02")}
+	}
+	return "validation.invalid", nil
+}
+
+// ParseBody decodes the request body into out and validates it. Error yang
+// dikembalikan selalu *ValidationError, tulis dengan ValidationErrorResponse.
+func ParseBody(c *fiber.Ctx, out interface{}) error {
+	if err := c.BodyParser(out); err != nil {
+		verr := &ValidationError{Message: "Format data tidak valid: " + err.Error(), Fields: []FieldError{}, key: "validation.malformed"}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			field := NewFieldError(typeErr.Field, "type", "validation.type")
+			field.Param = typeErr.Type.String()
+			verr.Fields = append(verr.Fields, field)
+		}
+		return verr
+	}
+
+	if err := ValidateStruct(out); err != nil {
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			return verr
+		}
+		return &ValidationError{Message: "Data tidak valid: " + err.Error(), Fields: []FieldError{}, key: "validation.failed"}
+	}
+	return nil
+}
+
+// ValidationErrorResponse returns the 400 error for an error of ParseBody;
+// daftar field dikirim di error.details.fields
+func ValidationErrorResponse(c *fiber.Ctx, err error) error {
+	verr := &ValidationError{Message: err.Error(), Fields: []FieldError{}, key: "validation.failed"}
+	errors.As(err, &verr)
+	return apperror.BadRequest(verr.key).WithDetails(fiber.Map{"fields": FieldErrors(verr.Fields)})
+}
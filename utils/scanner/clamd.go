@@ -0,0 +1,179 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultClamdTimeout = 60 * time.Second
+	defaultChunkSize    = 64 * 1024
+)
+
+// Clamd talks to a ClamAV daemon over TCP or a unix socket using the
+// null-terminated ("z" prefixed) command format
+type Clamd struct {
+	Network   string // "tcp" atau "unix"
+	Address   string
+	Timeout   time.Duration
+	ChunkSize int
+}
+
+// NewClamd parses an address of the form tcp://host:port, unix:///path or a
+// bare host:port (assumed TCP)
+func NewClamd(address string) *Clamd {
+	network := "tcp"
+	switch {
+	case strings.HasPrefix(address, "unix://"):
+		network = "unix"
+		address = strings.TrimPrefix(address, "unix://")
+	case strings.HasPrefix(address, "tcp://"):
+		address = strings.TrimPrefix(address, "tcp://")
+	case strings.HasPrefix(address, "/"):
+		network = "unix"
+	}
+
+	return &Clamd{
+		Network:   network,
+		Address:   address,
+		Timeout:   defaultClamdTimeout,
+		ChunkSize: defaultChunkSize,
+	}
+}
+
+func (c *Clamd) Enabled() bool {
+	return true
+}
+
+// Scan streams r to clamd with the INSTREAM command
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, err
+	}
+
+	chunkSize := c.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	buf := make([]byte, chunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return Result{}, err
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return Result{}, err
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return Result{}, readErr
+		}
+	}
+
+	// Zero-length chunk menandakan akhir stream
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return Result{}, err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return parseScanReply(reply)
+}
+
+// Version returns the signature database version reported by clamd, e.g.
+// "26830" from "ClamAV 1.0.0/26830/Mon Feb 27 08:21:02 2023"
+func (c *Clamd) Version(ctx context.Context) (string, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zVERSION\x00")); err != nil {
+		return "", err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(reply, "/")
+	if len(parts) < 2 {
+		return reply, nil
+	}
+	return parts[1], nil
+}
+
+func (c *Clamd) dial(ctx context.Context) (net.Conn, error) {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultClamdTimeout
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, c.Network, c.Address)
+	if err != nil {
+		return nil, fmt.Errorf("clamd: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	return conn, nil
+}
+
+func readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(err == io.EOF && reply != "") {
+		return "", fmt.Errorf("clamd: %w", err)
+	}
+	return strings.TrimRight(reply, "\x00\n"), nil
+}
+
+// parseScanReply interprets replies such as "stream: OK",
+// "stream: Eicar-Test-Signature FOUND" and "... ERROR"
+func parseScanReply(reply string) (Result, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case reply == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return Result{
+			Infected:  true,
+			Signature: strings.TrimSuffix(reply, " FOUND"),
+		}, nil
+	case strings.HasSuffix(reply, " ERROR"):
+		return Result{}, errors.New("clamd: " + strings.TrimSuffix(reply, " ERROR"))
+	default:
+		return Result{}, errors.New("clamd: unexpected reply " + reply)
+	}
+}
@@ -0,0 +1,130 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd speaks enough of the clamd protocol to answer INSTREAM and VERSION
+func fakeClamd(t *testing.T, network, address string) net.Listener {
+	t.Helper()
+
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go handleFakeClamd(conn)
+		}
+	}()
+
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+func handleFakeClamd(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	cmd, err := r.ReadString(0)
+	if err != nil {
+		return
+	}
+
+	switch strings.TrimRight(cmd, "\x00") {
+	case "zVERSION":
+		conn.Write([]byte("ClamAV 1.0.0/26830/Mon Feb 27 08:21:02 2023\x00"))
+	case "zINSTREAM":
+		var data bytes.Buffer
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(r, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			if _, err := io.CopyN(&data, r, int64(n)); err != nil {
+				return
+			}
+		}
+		if strings.Contains(data.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+			conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+			return
+		}
+		conn.Write([]byte("stream: OK\x00"))
+	default:
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+	}
+}
+
+func TestClamdScanTCP(t *testing.T) {
+	ln := fakeClamd(t, "tcp", "127.0.0.1:0")
+	c := NewClamd("tcp://" + ln.Addr().String())
+	c.ChunkSize = 8 // paksa beberapa chunk
+
+	result, err := c.Scan(context.Background(), strings.NewReader("%PDF-1.4 certificate"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Infected {
+		t.Errorf("expected clean result, got %+v", result)
+	}
+
+	result, err = c.Scan(context.Background(), strings.NewReader("prefix "+eicar))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Infected || result.Signature != "Eicar-Test-Signature" {
+		t.Errorf("expected Eicar-Test-Signature, got %+v", result)
+	}
+}
+
+func TestClamdScanUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "clamd.sock")
+	fakeClamd(t, "unix", socket)
+	c := NewClamd("unix://" + socket)
+
+	result, err := c.Scan(context.Background(), strings.NewReader(eicar))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Infected {
+		t.Errorf("expected infected result")
+	}
+}
+
+func TestClamdVersion(t *testing.T) {
+	ln := fakeClamd(t, "tcp", "127.0.0.1:0")
+	c := NewClamd(ln.Addr().String())
+
+	version, err := c.Version(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "26830" {
+		t.Errorf("expected 26830, got %q", version)
+	}
+}
+
+func TestParseScanReplyError(t *testing.T) {
+	if _, err := parseScanReply("INSTREAM size limit exceeded. ERROR"); err == nil {
+		t.Error("expected error for size limit reply")
+	}
+}
@@ -0,0 +1,48 @@
+package scanner
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// Result is the outcome of scanning a single stream
+type Result struct {
+	Infected  bool   `json:"infected"`
+	Signature string `json:"signature,omitempty"` // Nama malware yang terdeteksi
+}
+
+// Scanner inspects uploaded bytes before they are stored as a file record
+type Scanner interface {
+	// Scan reads r until EOF and reports whether it contains malware
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+	// Version returns the signature database version, used to detect updates
+	Version(ctx context.Context) (string, error)
+	// Enabled reports whether the scanner actually inspects content
+	Enabled() bool
+}
+
+// Noop is used when no scanner is configured; every file is reported clean
+type Noop struct{}
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{}, nil
+}
+
+func (Noop) Version(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (Noop) Enabled() bool {
+	return false
+}
+
+// NewFromEnv builds a scanner from CLAMD_ADDRESS, e.g. "tcp://127.0.0.1:3310"
+// or "unix:///var/run/clamav/clamd.ctl". Empty address disables scanning.
+func NewFromEnv() Scanner {
+	address := os.Getenv("CLAMD_ADDRESS")
+	if address == "" {
+		return Noop{}
+	}
+	return NewClamd(address)
+}
@@ -0,0 +1,122 @@
+// Package tracing configures OpenTelemetry for the application: tracer
+// provider dengan exporter OTLP atau stdout, propagasi W3C trace-context dan
+// helper untuk membuat span dari handler, middleware maupun repository.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans created by this application
+const instrumentationName = "clean-arch"
+
+// defaultServiceName is used when OTEL_SERVICE_NAME is not set
+const defaultServiceName = "alumni-api"
+
+// Init installs the tracer provider selected by OTEL_TRACES_EXPORTER:
+//
+//	otlp   - OTLP/HTTP ke collector (OTEL_EXPORTER_OTLP_ENDPOINT, default http://localhost:4318)
+//	stdout - span ditulis ke stdout, untuk debugging lokal
+//	none   - default; span tidak diekspor tetapi trace-context tetap diteruskan
+//
+// Sampling mengikuti OTEL_TRACES_SAMPLER. Fungsi yang dikembalikan mengirim
+// span yang tersisa dan harus dipanggil saat aplikasi berhenti.
+func Init(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch kind := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER"))); kind {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout", "console":
+		exporter, err = stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q (use otlp, stdout or none)", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(defaultServiceName)),
+		resource.WithFromEnv(), // OTEL_SERVICE_NAME dan OTEL_RESOURCE_ATTRIBUTES
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the application
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts an internal span named name as a child of the span in ctx
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End records err on span, bila ada, lalu mengakhiri span tersebut
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// headerCarrier exposes the request headers of a Fiber context to the
+// propagator
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (hc headerCarrier) Get(key string) string {
+	return hc.c.Get(key)
+}
+
+func (hc headerCarrier) Set(key, value string) {
+	hc.c.Request().Header.Set(key, value)
+}
+
+func (hc headerCarrier) Keys() []string {
+	var keys []string
+	hc.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// Extract returns the user context of c carrying the remote span from the
+// traceparent/tracestate header, bila klien mengirimnya
+func Extract(c *fiber.Ctx) context.Context {
+	return otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+}
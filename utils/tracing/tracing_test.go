@@ -0,0 +1,80 @@
+package tracing_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"clean-arch/middleware/mongo"
+	"clean-arch/utils/apperror"
+	"clean-arch/utils/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInitExporter(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+	if _, err := tracing.Init(t.Context()); err == nil {
+		t.Error("unknown exporter should be rejected")
+	}
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	shutdown, err := tracing.Init(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(t.Context()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Use(middleware.LoggerMiddleware)
+	app.Use(middleware.Tracing)
+	app.Get("/alumni/:id", func(c *fiber.Ctx) error {
+		_, span := tracing.Start(c.UserContext(), "repository")
+		span.End()
+		if c.Params("id") == "broken" {
+			return apperror.Internal(errors.New("driver: bad connection"), "internal")
+		}
+		return c.SendString("ok")
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest("GET", "/alumni/7", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Test(httptest.NewRequest("GET", "/alumni/broken", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("got %d spans, want 4", len(spans))
+	}
+	child, server := spans[0], spans[1]
+	if server.Name() != "GET /alumni/:id" {
+		t.Errorf("server span name = %q", server.Name())
+	}
+	if server.SpanContext().TraceID().String() != traceID || server.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Error("server span should continue the trace from traceparent")
+	}
+	if child.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Error("repository span should be a child of the request span")
+	}
+	if spans[3].Status().Code != codes.Error {
+		t.Errorf("5xx request should mark the span as error, got %v", spans[3].Status())
+	}
+}